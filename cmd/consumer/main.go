@@ -1,5 +1,7 @@
 package main
 
+import "github.com/rohanchauhan02/sequence-service/internal/app"
+
 func main() {
-	// TODO implement
+	app.InitConsumer()
 }
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Enrollments keep their position: current_step becomes the number of live steps already sent.
+UPDATE sequence_contacts sc
+SET current_step = (
+    SELECT COUNT(*) FROM steps s
+    WHERE s.sequence_id = sc.sequence_id AND s.deleted_at IS NULL AND s.step_order <= sc.current_step
+)
+WHERE sc.status IN ('pending', 'in_progress', 'paused');
+
+-- Renumber live steps from 1 so duplicate and gapped orders do not block the unique index.
+UPDATE steps s
+SET step_order = r.new_order
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY sequence_id ORDER BY step_order, created_at, id) AS new_order
+    FROM steps
+    WHERE deleted_at IS NULL
+) r
+WHERE s.id = r.id AND s.step_order <> r.new_order;
+
+DROP INDEX IF EXISTS idx_steps_order;
+CREATE UNIQUE INDEX idx_steps_order ON steps(sequence_id, step_order) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_steps_order;
+CREATE INDEX idx_steps_order ON steps(sequence_id, step_order);
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE steps
+    ADD COLUMN wait_hours INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN wait_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN send_window_start VARCHAR(5),
+    ADD COLUMN send_window_end VARCHAR(5),
+    ADD COLUMN send_window_days VARCHAR(27);
+
+ALTER TABLE contacts ADD COLUMN timezone VARCHAR(64);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE contacts DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE steps
+    DROP COLUMN IF EXISTS send_window_days,
+    DROP COLUMN IF EXISTS send_window_end,
+    DROP COLUMN IF EXISTS send_window_start,
+    DROP COLUMN IF EXISTS wait_minutes,
+    DROP COLUMN IF EXISTS wait_hours;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE sequence_status AS ENUM ('draft', 'active', 'paused', 'archived');
+
+ALTER TABLE sequences ADD COLUMN status sequence_status NOT NULL DEFAULT 'draft';
+
+-- Sequences created before the lifecycle existed were already sending.
+UPDATE sequences SET status = 'active';
+
+CREATE INDEX idx_sequences_status ON sequences(status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sequences_status;
+
+ALTER TABLE sequences DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS sequence_status;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE sequence_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sequence_id UUID NOT NULL REFERENCES sequences(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    steps JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_sequence_versions_version ON sequence_versions(sequence_id, version);
+
+-- Publish the current steps of every sequence as its first version.
+INSERT INTO sequence_versions (sequence_id, version, steps)
+SELECT s.id, 1, COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+        'id', st.id,
+        'sequence_id', st.sequence_id,
+        'step_order', st.step_order,
+        'subject', st.subject,
+        'content', st.content,
+        'wait_days', st.wait_days,
+        'wait_hours', st.wait_hours,
+        'wait_minutes', st.wait_minutes,
+        'send_window_start', st.send_window_start,
+        'send_window_end', st.send_window_end,
+        'send_window_days', string_to_array(st.send_window_days, ','),
+        'created_at', st.created_at,
+        'updated_at', st.updated_at
+    ) ORDER BY st.step_order)
+    FROM steps st
+    WHERE st.sequence_id = s.id AND st.deleted_at IS NULL
+), '[]'::jsonb)
+FROM sequences s;
+
+-- Existing enrollments continue on the first version.
+ALTER TABLE sequence_contacts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sequence_contacts DROP COLUMN IF EXISTS version;
+
+DROP INDEX IF EXISTS idx_sequence_versions_version;
+DROP TABLE IF EXISTS sequence_versions;
+-- +goose StatementEnd
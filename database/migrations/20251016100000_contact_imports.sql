@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE contact_import_status AS ENUM ('pending', 'processing', 'completed', 'failed');
+
+CREATE TABLE contact_imports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    file_name VARCHAR(255),
+    mapping JSONB NOT NULL DEFAULT '{}',
+    sequence_id UUID REFERENCES sequences(id) ON DELETE SET NULL,
+    status contact_import_status NOT NULL DEFAULT 'pending',
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    imported INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    enrolled INTEGER NOT NULL DEFAULT 0,
+    issues JSONB NOT NULL DEFAULT '[]',
+    error TEXT,
+    data BYTEA NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_contact_imports_status ON contact_imports(status);
+
+-- Imports look contacts up by lowercase email in batches.
+CREATE INDEX idx_contacts_email_lower ON contacts(LOWER(email)) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_contacts_email_lower;
+DROP INDEX IF EXISTS idx_contact_imports_status;
+DROP TABLE IF EXISTS contact_imports;
+
+DROP TYPE IF EXISTS contact_import_status;
+-- +goose StatementEnd
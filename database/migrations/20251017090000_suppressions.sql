@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE suppression_type AS ENUM ('email', 'domain');
+CREATE TYPE suppression_reason AS ENUM ('unsubscribed', 'bounced', 'complaint', 'manual');
+
+CREATE TABLE suppressions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type suppression_type NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    reason suppression_reason NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_suppressions_value ON suppressions(type, value);
+
+-- Contacts that already unsubscribed are suppressed.
+INSERT INTO suppressions (type, value, reason)
+SELECT DISTINCT 'email'::suppression_type, LOWER(email), 'unsubscribed'::suppression_reason
+FROM contacts
+WHERE status = 'unsubscribed' AND deleted_at IS NULL
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_suppressions_value;
+DROP TABLE IF EXISTS suppressions;
+
+DROP TYPE IF EXISTS suppression_reason;
+DROP TYPE IF EXISTS suppression_type;
+-- +goose StatementEnd
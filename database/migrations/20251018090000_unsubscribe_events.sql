@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE email_event_type ADD VALUE IF NOT EXISTS 'unsubscribed';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Postgres cannot drop a value from an enum, so the type is recreated without it.
+DELETE FROM email_events WHERE event_type = 'unsubscribed';
+
+ALTER TYPE email_event_type RENAME TO email_event_type_old;
+CREATE TYPE email_event_type AS ENUM ('sent', 'delivered', 'opened', 'clicked', 'bounced', 'failed');
+ALTER TABLE email_events ALTER COLUMN event_type TYPE email_event_type USING event_type::text::email_event_type;
+DROP TYPE email_event_type_old;
+-- +goose StatementEnd
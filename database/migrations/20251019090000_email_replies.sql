@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE email_event_type ADD VALUE IF NOT EXISTS 'replied';
+
+-- Replies reference the Message-ID of the email they answer in In-Reply-To and References.
+ALTER TABLE email_queues ADD COLUMN message_id VARCHAR(255);
+CREATE UNIQUE INDEX idx_email_queues_message_id ON email_queues(message_id) WHERE message_id IS NOT NULL;
+
+ALTER TABLE sequence_contacts ADD COLUMN completed_reason VARCHAR(50);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sequence_contacts DROP COLUMN IF EXISTS completed_reason;
+
+DROP INDEX IF EXISTS idx_email_queues_message_id;
+ALTER TABLE email_queues DROP COLUMN IF EXISTS message_id;
+
+-- Postgres cannot drop a value from an enum, so the type is recreated without it.
+DELETE FROM email_events WHERE event_type = 'replied';
+
+ALTER TYPE email_event_type RENAME TO email_event_type_old;
+CREATE TYPE email_event_type AS ENUM ('sent', 'delivered', 'opened', 'clicked', 'bounced', 'failed', 'unsubscribed');
+ALTER TABLE email_events ALTER COLUMN event_type TYPE email_event_type USING event_type::text::email_event_type;
+DROP TYPE email_event_type_old;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Bounces are counted on the day the bounced email was scheduled for, next to its sent_count.
+ALTER TABLE mailbox_daily_counts ADD COLUMN hard_bounce_count INTEGER DEFAULT 0;
+ALTER TABLE mailbox_daily_counts ADD COLUMN soft_bounce_count INTEGER DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE mailbox_daily_counts DROP COLUMN IF EXISTS soft_bounce_count;
+ALTER TABLE mailbox_daily_counts DROP COLUMN IF EXISTS hard_bounce_count;
+-- +goose StatementEnd
@@ -391,8 +391,7 @@ const docTemplate = `{
             "required": [
                 "content",
                 "step_order",
-                "subject",
-                "wait_days"
+                "subject"
             ],
             "properties": {
                 "content": {
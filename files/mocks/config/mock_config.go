@@ -48,6 +48,20 @@ func (mr *MockImmutableConfigMockRecorder) GetDBConf() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDBConf", reflect.TypeOf((*MockImmutableConfig)(nil).GetDBConf))
 }
 
+// GetImportConf mocks base method.
+func (m *MockImmutableConfig) GetImportConf() config.Import {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportConf")
+	ret0, _ := ret[0].(config.Import)
+	return ret0
+}
+
+// GetImportConf indicates an expected call of GetImportConf.
+func (mr *MockImmutableConfigMockRecorder) GetImportConf() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportConf", reflect.TypeOf((*MockImmutableConfig)(nil).GetImportConf))
+}
+
 // GetKafkaConf mocks base method.
 func (m *MockImmutableConfig) GetKafkaConf() config.Kafka {
 	m.ctrl.T.Helper()
@@ -62,6 +76,20 @@ func (mr *MockImmutableConfigMockRecorder) GetKafkaConf() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKafkaConf", reflect.TypeOf((*MockImmutableConfig)(nil).GetKafkaConf))
 }
 
+// GetMailConf mocks base method.
+func (m *MockImmutableConfig) GetMailConf() config.Mail {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMailConf")
+	ret0, _ := ret[0].(config.Mail)
+	return ret0
+}
+
+// GetMailConf indicates an expected call of GetMailConf.
+func (mr *MockImmutableConfigMockRecorder) GetMailConf() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMailConf", reflect.TypeOf((*MockImmutableConfig)(nil).GetMailConf))
+}
+
 // GetPort mocks base method.
 func (m *MockImmutableConfig) GetPort() string {
 	m.ctrl.T.Helper()
@@ -75,3 +103,45 @@ func (mr *MockImmutableConfigMockRecorder) GetPort() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPort", reflect.TypeOf((*MockImmutableConfig)(nil).GetPort))
 }
+
+// GetSchedulerConf mocks base method.
+func (m *MockImmutableConfig) GetSchedulerConf() config.Scheduler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedulerConf")
+	ret0, _ := ret[0].(config.Scheduler)
+	return ret0
+}
+
+// GetSchedulerConf indicates an expected call of GetSchedulerConf.
+func (mr *MockImmutableConfigMockRecorder) GetSchedulerConf() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulerConf", reflect.TypeOf((*MockImmutableConfig)(nil).GetSchedulerConf))
+}
+
+// GetSecurityConf mocks base method.
+func (m *MockImmutableConfig) GetSecurityConf() config.Security {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityConf")
+	ret0, _ := ret[0].(config.Security)
+	return ret0
+}
+
+// GetSecurityConf indicates an expected call of GetSecurityConf.
+func (mr *MockImmutableConfigMockRecorder) GetSecurityConf() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityConf", reflect.TypeOf((*MockImmutableConfig)(nil).GetSecurityConf))
+}
+
+// GetTrackingConf mocks base method.
+func (m *MockImmutableConfig) GetTrackingConf() config.Tracking {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackingConf")
+	ret0, _ := ret[0].(config.Tracking)
+	return ret0
+}
+
+// GetTrackingConf indicates an expected call of GetTrackingConf.
+func (mr *MockImmutableConfigMockRecorder) GetTrackingConf() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackingConf", reflect.TypeOf((*MockImmutableConfig)(nil).GetTrackingConf))
+}
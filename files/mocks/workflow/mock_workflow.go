@@ -38,6 +38,81 @@ func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 	return m.recorder
 }
 
+// ActivateSequence mocks base method.
+func (m *MockUsecase) ActivateSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateSequence", c, sequenceID)
+	ret0, _ := ret[0].(*models.Sequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateSequence indicates an expected call of ActivateSequence.
+func (mr *MockUsecaseMockRecorder) ActivateSequence(c, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateSequence", reflect.TypeOf((*MockUsecase)(nil).ActivateSequence), c, sequenceID)
+}
+
+// AddStep mocks base method.
+func (m *MockUsecase) AddStep(c echo.Context, sequenceID uuid.UUID, req *dto.AddStepRequest, force bool) (*models.Step, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStep", c, sequenceID, req, force)
+	ret0, _ := ret[0].(*models.Step)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStep indicates an expected call of AddStep.
+func (mr *MockUsecaseMockRecorder) AddStep(c, sequenceID, req, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStep", reflect.TypeOf((*MockUsecase)(nil).AddStep), c, sequenceID, req, force)
+}
+
+// ArchiveSequence mocks base method.
+func (m *MockUsecase) ArchiveSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveSequence", c, sequenceID)
+	ret0, _ := ret[0].(*models.Sequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveSequence indicates an expected call of ArchiveSequence.
+func (mr *MockUsecaseMockRecorder) ArchiveSequence(c, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveSequence", reflect.TypeOf((*MockUsecase)(nil).ArchiveSequence), c, sequenceID)
+}
+
+// AssignMailboxes mocks base method.
+func (m *MockUsecase) AssignMailboxes(c echo.Context, sequenceID uuid.UUID, req *dto.AssignMailboxesRequest) ([]models.Mailbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignMailboxes", c, sequenceID, req)
+	ret0, _ := ret[0].([]models.Mailbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignMailboxes indicates an expected call of AssignMailboxes.
+func (mr *MockUsecaseMockRecorder) AssignMailboxes(c, sequenceID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignMailboxes", reflect.TypeOf((*MockUsecase)(nil).AssignMailboxes), c, sequenceID, req)
+}
+
+// CloneSequence mocks base method.
+func (m *MockUsecase) CloneSequence(c echo.Context, sequenceID uuid.UUID, req *dto.CloneSequenceRequest) (*models.Sequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneSequence", c, sequenceID, req)
+	ret0, _ := ret[0].(*models.Sequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneSequence indicates an expected call of CloneSequence.
+func (mr *MockUsecaseMockRecorder) CloneSequence(c, sequenceID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneSequence", reflect.TypeOf((*MockUsecase)(nil).CloneSequence), c, sequenceID, req)
+}
+
 // CreateSequence mocks base method.
 func (m *MockUsecase) CreateSequence(c echo.Context, req *dto.CreateSequenceRequest) (*dto.CreateSequenceResponse, error) {
 	m.ctrl.T.Helper()
@@ -53,18 +128,48 @@ func (mr *MockUsecaseMockRecorder) CreateSequence(c, req interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSequence", reflect.TypeOf((*MockUsecase)(nil).CreateSequence), c, req)
 }
 
+// DeleteSequence mocks base method.
+func (m *MockUsecase) DeleteSequence(c echo.Context, sequenceID uuid.UUID) (*dto.DeleteSequenceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSequence", c, sequenceID)
+	ret0, _ := ret[0].(*dto.DeleteSequenceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSequence indicates an expected call of DeleteSequence.
+func (mr *MockUsecaseMockRecorder) DeleteSequence(c, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSequence", reflect.TypeOf((*MockUsecase)(nil).DeleteSequence), c, sequenceID)
+}
+
 // DeleteStep mocks base method.
-func (m *MockUsecase) DeleteStep(c echo.Context, sequenceID, stepID uuid.UUID) error {
+func (m *MockUsecase) DeleteStep(c echo.Context, sequenceID, stepID uuid.UUID, force bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteStep", c, sequenceID, stepID)
+	ret := m.ctrl.Call(m, "DeleteStep", c, sequenceID, stepID, force)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteStep indicates an expected call of DeleteStep.
-func (mr *MockUsecaseMockRecorder) DeleteStep(c, sequenceID, stepID interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) DeleteStep(c, sequenceID, stepID, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStep", reflect.TypeOf((*MockUsecase)(nil).DeleteStep), c, sequenceID, stepID, force)
+}
+
+// DiffSequenceVersions mocks base method.
+func (m *MockUsecase) DiffSequenceVersions(c echo.Context, sequenceID uuid.UUID, req *dto.DiffSequenceVersionsRequest) (*dto.SequenceVersionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffSequenceVersions", c, sequenceID, req)
+	ret0, _ := ret[0].(*dto.SequenceVersionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffSequenceVersions indicates an expected call of DiffSequenceVersions.
+func (mr *MockUsecaseMockRecorder) DiffSequenceVersions(c, sequenceID, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStep", reflect.TypeOf((*MockUsecase)(nil).DeleteStep), c, sequenceID, stepID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffSequenceVersions", reflect.TypeOf((*MockUsecase)(nil).DiffSequenceVersions), c, sequenceID, req)
 }
 
 // GetSequence mocks base method.
@@ -82,6 +187,141 @@ func (mr *MockUsecaseMockRecorder) GetSequence(c, sequenceID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequence", reflect.TypeOf((*MockUsecase)(nil).GetSequence), c, sequenceID)
 }
 
+// GetSequenceMailboxes mocks base method.
+func (m *MockUsecase) GetSequenceMailboxes(c echo.Context, sequenceID uuid.UUID) ([]models.Mailbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSequenceMailboxes", c, sequenceID)
+	ret0, _ := ret[0].([]models.Mailbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSequenceMailboxes indicates an expected call of GetSequenceMailboxes.
+func (mr *MockUsecaseMockRecorder) GetSequenceMailboxes(c, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequenceMailboxes", reflect.TypeOf((*MockUsecase)(nil).GetSequenceMailboxes), c, sequenceID)
+}
+
+// ListDeletedSteps mocks base method.
+func (m *MockUsecase) ListDeletedSteps(c echo.Context, sequenceID uuid.UUID) ([]models.Step, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedSteps", c, sequenceID)
+	ret0, _ := ret[0].([]models.Step)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedSteps indicates an expected call of ListDeletedSteps.
+func (mr *MockUsecaseMockRecorder) ListDeletedSteps(c, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedSteps", reflect.TypeOf((*MockUsecase)(nil).ListDeletedSteps), c, sequenceID)
+}
+
+// ListSequenceVersions mocks base method.
+func (m *MockUsecase) ListSequenceVersions(c echo.Context, sequenceID uuid.UUID) ([]models.SequenceVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSequenceVersions", c, sequenceID)
+	ret0, _ := ret[0].([]models.SequenceVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSequenceVersions indicates an expected call of ListSequenceVersions.
+func (mr *MockUsecaseMockRecorder) ListSequenceVersions(c, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSequenceVersions", reflect.TypeOf((*MockUsecase)(nil).ListSequenceVersions), c, sequenceID)
+}
+
+// ListSequences mocks base method.
+func (m *MockUsecase) ListSequences(c echo.Context, req *dto.ListSequencesRequest) ([]models.Sequence, *dto.PaginationMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSequences", c, req)
+	ret0, _ := ret[0].([]models.Sequence)
+	ret1, _ := ret[1].(*dto.PaginationMeta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSequences indicates an expected call of ListSequences.
+func (mr *MockUsecaseMockRecorder) ListSequences(c, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSequences", reflect.TypeOf((*MockUsecase)(nil).ListSequences), c, req)
+}
+
+// PauseSequence mocks base method.
+func (m *MockUsecase) PauseSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseSequence", c, sequenceID)
+	ret0, _ := ret[0].(*models.Sequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseSequence indicates an expected call of PauseSequence.
+func (mr *MockUsecaseMockRecorder) PauseSequence(c, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseSequence", reflect.TypeOf((*MockUsecase)(nil).PauseSequence), c, sequenceID)
+}
+
+// ReorderSteps mocks base method.
+func (m *MockUsecase) ReorderSteps(c echo.Context, sequenceID uuid.UUID, req *dto.ReorderStepsRequest, force bool) ([]models.Step, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderSteps", c, sequenceID, req, force)
+	ret0, _ := ret[0].([]models.Step)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderSteps indicates an expected call of ReorderSteps.
+func (mr *MockUsecaseMockRecorder) ReorderSteps(c, sequenceID, req, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSteps", reflect.TypeOf((*MockUsecase)(nil).ReorderSteps), c, sequenceID, req, force)
+}
+
+// RestoreSequence mocks base method.
+func (m *MockUsecase) RestoreSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSequence", c, sequenceID)
+	ret0, _ := ret[0].(*models.Sequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSequence indicates an expected call of RestoreSequence.
+func (mr *MockUsecaseMockRecorder) RestoreSequence(c, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSequence", reflect.TypeOf((*MockUsecase)(nil).RestoreSequence), c, sequenceID)
+}
+
+// RestoreStep mocks base method.
+func (m *MockUsecase) RestoreStep(c echo.Context, sequenceID, stepID uuid.UUID, force bool) (*models.Step, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreStep", c, sequenceID, stepID, force)
+	ret0, _ := ret[0].(*models.Step)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreStep indicates an expected call of RestoreStep.
+func (mr *MockUsecaseMockRecorder) RestoreStep(c, sequenceID, stepID, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreStep", reflect.TypeOf((*MockUsecase)(nil).RestoreStep), c, sequenceID, stepID, force)
+}
+
+// UnassignMailbox mocks base method.
+func (m *MockUsecase) UnassignMailbox(c echo.Context, sequenceID, mailboxID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignMailbox", c, sequenceID, mailboxID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignMailbox indicates an expected call of UnassignMailbox.
+func (mr *MockUsecaseMockRecorder) UnassignMailbox(c, sequenceID, mailboxID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignMailbox", reflect.TypeOf((*MockUsecase)(nil).UnassignMailbox), c, sequenceID, mailboxID)
+}
+
 // UpdateSequenceTracking mocks base method.
 func (m *MockUsecase) UpdateSequenceTracking(c echo.Context, sequenceID uuid.UUID, req *dto.UpdateSequenceTrackingRequest) error {
 	m.ctrl.T.Helper()
@@ -97,17 +337,17 @@ func (mr *MockUsecaseMockRecorder) UpdateSequenceTracking(c, sequenceID, req int
 }
 
 // UpdateStep mocks base method.
-func (m *MockUsecase) UpdateStep(c echo.Context, sequenceID, stepID uuid.UUID, req *dto.UpdateStepRequest) error {
+func (m *MockUsecase) UpdateStep(c echo.Context, sequenceID, stepID uuid.UUID, req *dto.UpdateStepRequest, force bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStep", c, sequenceID, stepID, req)
+	ret := m.ctrl.Call(m, "UpdateStep", c, sequenceID, stepID, req, force)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStep indicates an expected call of UpdateStep.
-func (mr *MockUsecaseMockRecorder) UpdateStep(c, sequenceID, stepID, req interface{}) *gomock.Call {
+func (mr *MockUsecaseMockRecorder) UpdateStep(c, sequenceID, stepID, req, force interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStep", reflect.TypeOf((*MockUsecase)(nil).UpdateStep), c, sequenceID, stepID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStep", reflect.TypeOf((*MockUsecase)(nil).UpdateStep), c, sequenceID, stepID, req, force)
 }
 
 // MockRepository is a mock of Repository interface.
@@ -133,6 +373,50 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CancelSequenceEnrollments mocks base method.
+func (m *MockRepository) CancelSequenceEnrollments(tx *gorm.DB, sequenceID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSequenceEnrollments", tx, sequenceID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSequenceEnrollments indicates an expected call of CancelSequenceEnrollments.
+func (mr *MockRepositoryMockRecorder) CancelSequenceEnrollments(tx, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSequenceEnrollments", reflect.TypeOf((*MockRepository)(nil).CancelSequenceEnrollments), tx, sequenceID)
+}
+
+// CancelSequenceScheduledEmails mocks base method.
+func (m *MockRepository) CancelSequenceScheduledEmails(tx *gorm.DB, sequenceID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSequenceScheduledEmails", tx, sequenceID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSequenceScheduledEmails indicates an expected call of CancelSequenceScheduledEmails.
+func (mr *MockRepositoryMockRecorder) CancelSequenceScheduledEmails(tx, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSequenceScheduledEmails", reflect.TypeOf((*MockRepository)(nil).CancelSequenceScheduledEmails), tx, sequenceID)
+}
+
+// CopySequenceMailboxes mocks base method.
+func (m *MockRepository) CopySequenceMailboxes(tx *gorm.DB, fromSequenceID, toSequenceID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopySequenceMailboxes", tx, fromSequenceID, toSequenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopySequenceMailboxes indicates an expected call of CopySequenceMailboxes.
+func (mr *MockRepositoryMockRecorder) CopySequenceMailboxes(tx, fromSequenceID, toSequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopySequenceMailboxes", reflect.TypeOf((*MockRepository)(nil).CopySequenceMailboxes), tx, fromSequenceID, toSequenceID)
+}
+
 // CreateSequence mocks base method.
 func (m *MockRepository) CreateSequence(tx *gorm.DB, sequence *models.Sequence) (*models.Sequence, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +432,34 @@ func (mr *MockRepositoryMockRecorder) CreateSequence(tx, sequence interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSequence", reflect.TypeOf((*MockRepository)(nil).CreateSequence), tx, sequence)
 }
 
+// CreateSequenceMailboxes mocks base method.
+func (m *MockRepository) CreateSequenceMailboxes(tx *gorm.DB, sequenceMailboxes []models.SequenceMailbox) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSequenceMailboxes", tx, sequenceMailboxes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSequenceMailboxes indicates an expected call of CreateSequenceMailboxes.
+func (mr *MockRepositoryMockRecorder) CreateSequenceMailboxes(tx, sequenceMailboxes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSequenceMailboxes", reflect.TypeOf((*MockRepository)(nil).CreateSequenceMailboxes), tx, sequenceMailboxes)
+}
+
+// CreateSequenceVersion mocks base method.
+func (m *MockRepository) CreateSequenceVersion(tx *gorm.DB, version *models.SequenceVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSequenceVersion", tx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSequenceVersion indicates an expected call of CreateSequenceVersion.
+func (mr *MockRepositoryMockRecorder) CreateSequenceVersion(tx, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSequenceVersion", reflect.TypeOf((*MockRepository)(nil).CreateSequenceVersion), tx, version)
+}
+
 // CreateSteps mocks base method.
 func (m *MockRepository) CreateSteps(tx *gorm.DB, steps []models.Step) (*[]models.Step, error) {
 	m.ctrl.T.Helper()
@@ -163,6 +475,35 @@ func (mr *MockRepositoryMockRecorder) CreateSteps(tx, steps interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSteps", reflect.TypeOf((*MockRepository)(nil).CreateSteps), tx, steps)
 }
 
+// DeleteSequence mocks base method.
+func (m *MockRepository) DeleteSequence(tx *gorm.DB, sequenceID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSequence", tx, sequenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSequence indicates an expected call of DeleteSequence.
+func (mr *MockRepositoryMockRecorder) DeleteSequence(tx, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSequence", reflect.TypeOf((*MockRepository)(nil).DeleteSequence), tx, sequenceID)
+}
+
+// DeleteSequenceMailbox mocks base method.
+func (m *MockRepository) DeleteSequenceMailbox(tx *gorm.DB, sequenceID, mailboxID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSequenceMailbox", tx, sequenceID, mailboxID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSequenceMailbox indicates an expected call of DeleteSequenceMailbox.
+func (mr *MockRepositoryMockRecorder) DeleteSequenceMailbox(tx, sequenceID, mailboxID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSequenceMailbox", reflect.TypeOf((*MockRepository)(nil).DeleteSequenceMailbox), tx, sequenceID, mailboxID)
+}
+
 // DeleteStep mocks base method.
 func (m *MockRepository) DeleteStep(tx *gorm.DB, sequenceID, stepID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -177,6 +518,66 @@ func (mr *MockRepositoryMockRecorder) DeleteStep(tx, sequenceID, stepID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStep", reflect.TypeOf((*MockRepository)(nil).DeleteStep), tx, sequenceID, stepID)
 }
 
+// GetDeletedStep mocks base method.
+func (m *MockRepository) GetDeletedStep(tx *gorm.DB, sequenceID, stepID uuid.UUID) (*models.Step, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedStep", tx, sequenceID, stepID)
+	ret0, _ := ret[0].(*models.Step)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedStep indicates an expected call of GetDeletedStep.
+func (mr *MockRepositoryMockRecorder) GetDeletedStep(tx, sequenceID, stepID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedStep", reflect.TypeOf((*MockRepository)(nil).GetDeletedStep), tx, sequenceID, stepID)
+}
+
+// GetDeletedSteps mocks base method.
+func (m *MockRepository) GetDeletedSteps(sequenceID uuid.UUID) ([]models.Step, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedSteps", sequenceID)
+	ret0, _ := ret[0].([]models.Step)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedSteps indicates an expected call of GetDeletedSteps.
+func (mr *MockRepositoryMockRecorder) GetDeletedSteps(sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedSteps", reflect.TypeOf((*MockRepository)(nil).GetDeletedSteps), sequenceID)
+}
+
+// GetLatestVersion mocks base method.
+func (m *MockRepository) GetLatestVersion(tx *gorm.DB, sequenceID uuid.UUID) (*models.SequenceVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestVersion", tx, sequenceID)
+	ret0, _ := ret[0].(*models.SequenceVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestVersion indicates an expected call of GetLatestVersion.
+func (mr *MockRepositoryMockRecorder) GetLatestVersion(tx, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestVersion", reflect.TypeOf((*MockRepository)(nil).GetLatestVersion), tx, sequenceID)
+}
+
+// GetMailboxesByIDs mocks base method.
+func (m *MockRepository) GetMailboxesByIDs(mailboxIDs []uuid.UUID) ([]models.Mailbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMailboxesByIDs", mailboxIDs)
+	ret0, _ := ret[0].([]models.Mailbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMailboxesByIDs indicates an expected call of GetMailboxesByIDs.
+func (mr *MockRepositoryMockRecorder) GetMailboxesByIDs(mailboxIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMailboxesByIDs", reflect.TypeOf((*MockRepository)(nil).GetMailboxesByIDs), mailboxIDs)
+}
+
 // GetSequence mocks base method.
 func (m *MockRepository) GetSequence(sequenceID uuid.UUID) (*models.Sequence, error) {
 	m.ctrl.T.Helper()
@@ -192,19 +593,166 @@ func (mr *MockRepositoryMockRecorder) GetSequence(sequenceID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequence", reflect.TypeOf((*MockRepository)(nil).GetSequence), sequenceID)
 }
 
-// GetStepByID mocks base method.
-func (m *MockRepository) GetStepByID(sequenceID, stepID uuid.UUID) (*models.Step, error) {
+// GetSequenceMailboxes mocks base method.
+func (m *MockRepository) GetSequenceMailboxes(sequenceID uuid.UUID) ([]models.Mailbox, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStepByID", sequenceID, stepID)
-	ret0, _ := ret[0].(*models.Step)
+	ret := m.ctrl.Call(m, "GetSequenceMailboxes", sequenceID)
+	ret0, _ := ret[0].([]models.Mailbox)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetStepByID indicates an expected call of GetStepByID.
-func (mr *MockRepositoryMockRecorder) GetStepByID(sequenceID, stepID interface{}) *gomock.Call {
+// GetSequenceMailboxes indicates an expected call of GetSequenceMailboxes.
+func (mr *MockRepositoryMockRecorder) GetSequenceMailboxes(sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequenceMailboxes", reflect.TypeOf((*MockRepository)(nil).GetSequenceMailboxes), sequenceID)
+}
+
+// GetSequenceVersion mocks base method.
+func (m *MockRepository) GetSequenceVersion(sequenceID uuid.UUID, version int) (*models.SequenceVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSequenceVersion", sequenceID, version)
+	ret0, _ := ret[0].(*models.SequenceVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSequenceVersion indicates an expected call of GetSequenceVersion.
+func (mr *MockRepositoryMockRecorder) GetSequenceVersion(sequenceID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequenceVersion", reflect.TypeOf((*MockRepository)(nil).GetSequenceVersion), sequenceID, version)
+}
+
+// GetSteps mocks base method.
+func (m *MockRepository) GetSteps(tx *gorm.DB, sequenceID uuid.UUID) ([]models.Step, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSteps", tx, sequenceID)
+	ret0, _ := ret[0].([]models.Step)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSteps indicates an expected call of GetSteps.
+func (mr *MockRepositoryMockRecorder) GetSteps(tx, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSteps", reflect.TypeOf((*MockRepository)(nil).GetSteps), tx, sequenceID)
+}
+
+// ListSequenceVersions mocks base method.
+func (m *MockRepository) ListSequenceVersions(sequenceID uuid.UUID) ([]models.SequenceVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSequenceVersions", sequenceID)
+	ret0, _ := ret[0].([]models.SequenceVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSequenceVersions indicates an expected call of ListSequenceVersions.
+func (mr *MockRepositoryMockRecorder) ListSequenceVersions(sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSequenceVersions", reflect.TypeOf((*MockRepository)(nil).ListSequenceVersions), sequenceID)
+}
+
+// ListSequences mocks base method.
+func (m *MockRepository) ListSequences(search, status, sortBy, order string, offset, limit int) ([]models.Sequence, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSequences", search, status, sortBy, order, offset, limit)
+	ret0, _ := ret[0].([]models.Sequence)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSequences indicates an expected call of ListSequences.
+func (mr *MockRepositoryMockRecorder) ListSequences(search, status, sortBy, order, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSequences", reflect.TypeOf((*MockRepository)(nil).ListSequences), search, status, sortBy, order, offset, limit)
+}
+
+// LockSequence mocks base method.
+func (m *MockRepository) LockSequence(tx *gorm.DB, sequenceID uuid.UUID) (*models.Sequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSequence", tx, sequenceID)
+	ret0, _ := ret[0].(*models.Sequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockSequence indicates an expected call of LockSequence.
+func (mr *MockRepositoryMockRecorder) LockSequence(tx, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSequence", reflect.TypeOf((*MockRepository)(nil).LockSequence), tx, sequenceID)
+}
+
+// LockSequenceWithDeleted mocks base method.
+func (m *MockRepository) LockSequenceWithDeleted(tx *gorm.DB, sequenceID uuid.UUID) (*models.Sequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSequenceWithDeleted", tx, sequenceID)
+	ret0, _ := ret[0].(*models.Sequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockSequenceWithDeleted indicates an expected call of LockSequenceWithDeleted.
+func (mr *MockRepositoryMockRecorder) LockSequenceWithDeleted(tx, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSequenceWithDeleted", reflect.TypeOf((*MockRepository)(nil).LockSequenceWithDeleted), tx, sequenceID)
+}
+
+// RestoreSequence mocks base method.
+func (m *MockRepository) RestoreSequence(tx *gorm.DB, sequenceID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSequence", tx, sequenceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreSequence indicates an expected call of RestoreSequence.
+func (mr *MockRepositoryMockRecorder) RestoreSequence(tx, sequenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSequence", reflect.TypeOf((*MockRepository)(nil).RestoreSequence), tx, sequenceID)
+}
+
+// RestoreStep mocks base method.
+func (m *MockRepository) RestoreStep(tx *gorm.DB, sequenceID, stepID uuid.UUID, stepOrder int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreStep", tx, sequenceID, stepID, stepOrder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreStep indicates an expected call of RestoreStep.
+func (mr *MockRepositoryMockRecorder) RestoreStep(tx, sequenceID, stepID, stepOrder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreStep", reflect.TypeOf((*MockRepository)(nil).RestoreStep), tx, sequenceID, stepID, stepOrder)
+}
+
+// SetStepOrders mocks base method.
+func (m *MockRepository) SetStepOrders(tx *gorm.DB, sequenceID uuid.UUID, orders map[uuid.UUID]int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStepOrders", tx, sequenceID, orders)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStepOrders indicates an expected call of SetStepOrders.
+func (mr *MockRepositoryMockRecorder) SetStepOrders(tx, sequenceID, orders interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStepOrders", reflect.TypeOf((*MockRepository)(nil).SetStepOrders), tx, sequenceID, orders)
+}
+
+// UpdateSequenceStatus mocks base method.
+func (m *MockRepository) UpdateSequenceStatus(tx *gorm.DB, sequenceID uuid.UUID, status models.SequenceStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSequenceStatus", tx, sequenceID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSequenceStatus indicates an expected call of UpdateSequenceStatus.
+func (mr *MockRepositoryMockRecorder) UpdateSequenceStatus(tx, sequenceID, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStepByID", reflect.TypeOf((*MockRepository)(nil).GetStepByID), sequenceID, stepID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSequenceStatus", reflect.TypeOf((*MockRepository)(nil).UpdateSequenceStatus), tx, sequenceID, status)
 }
 
 // UpdateSequenceTracking mocks base method.
@@ -22,14 +22,42 @@ import (
 	HealthHandler "github.com/rohanchauhan02/sequence-service/internal/module/health/delivery/https"
 	HealthRepository "github.com/rohanchauhan02/sequence-service/internal/module/health/repository"
 	HealthUsecase "github.com/rohanchauhan02/sequence-service/internal/module/health/usecase"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
 	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
 	"github.com/rohanchauhan02/sequence-service/internal/pkg/database"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/tracking"
 	"github.com/rohanchauhan02/sequence-service/internal/pkg/utils"
 
 	WorkflowHandler "github.com/rohanchauhan02/sequence-service/internal/module/workflow/delivery/https"
 	WorkflowRepository "github.com/rohanchauhan02/sequence-service/internal/module/workflow/repository"
 	WorkflowUsecase "github.com/rohanchauhan02/sequence-service/internal/module/workflow/usecase"
 
+	ContactCron "github.com/rohanchauhan02/sequence-service/internal/module/contact/delivery/cron"
+	ContactHandler "github.com/rohanchauhan02/sequence-service/internal/module/contact/delivery/https"
+	ContactRepository "github.com/rohanchauhan02/sequence-service/internal/module/contact/repository"
+	ContactUsecase "github.com/rohanchauhan02/sequence-service/internal/module/contact/usecase"
+
+	EnrollmentHandler "github.com/rohanchauhan02/sequence-service/internal/module/enrollment/delivery/https"
+	EnrollmentRepository "github.com/rohanchauhan02/sequence-service/internal/module/enrollment/repository"
+	EnrollmentUsecase "github.com/rohanchauhan02/sequence-service/internal/module/enrollment/usecase"
+
+	MailboxHandler "github.com/rohanchauhan02/sequence-service/internal/module/mailbox/delivery/https"
+	MailboxRepository "github.com/rohanchauhan02/sequence-service/internal/module/mailbox/repository"
+	MailboxUsecase "github.com/rohanchauhan02/sequence-service/internal/module/mailbox/usecase"
+
+	EmailHandler "github.com/rohanchauhan02/sequence-service/internal/module/email/delivery/https"
+	EmailRepository "github.com/rohanchauhan02/sequence-service/internal/module/email/repository"
+	EmailUsecase "github.com/rohanchauhan02/sequence-service/internal/module/email/usecase"
+
+	TrackingHandler "github.com/rohanchauhan02/sequence-service/internal/module/tracking/delivery/https"
+	TrackingRepository "github.com/rohanchauhan02/sequence-service/internal/module/tracking/repository"
+	TrackingUsecase "github.com/rohanchauhan02/sequence-service/internal/module/tracking/usecase"
+
+	SuppressionHandler "github.com/rohanchauhan02/sequence-service/internal/module/suppression/delivery/https"
+	SuppressionRepository "github.com/rohanchauhan02/sequence-service/internal/module/suppression/repository"
+	SuppressionUsecase "github.com/rohanchauhan02/sequence-service/internal/module/suppression/usecase"
+
+	SchedulerCron "github.com/rohanchauhan02/sequence-service/internal/module/scheduler/delivery/cron"
 	SchedulerHandler "github.com/rohanchauhan02/sequence-service/internal/module/scheduler/delivery/https"
 	SchedulerRepository "github.com/rohanchauhan02/sequence-service/internal/module/scheduler/repository"
 	SchedulerUsecase "github.com/rohanchauhan02/sequence-service/internal/module/scheduler/usecase"
@@ -64,6 +92,20 @@ func Init() {
 		}
 	}()
 
+	cipher, err := crypto.NewAESCipher(cnf.GetSecurityConf().EncryptionKey)
+	if err != nil {
+		log.Errorf("Failed to initialize cipher: %v", err)
+		panic(err)
+	}
+
+	signer, err := crypto.NewHMACSigner(cnf.GetSecurityConf().SigningKey)
+	if err != nil {
+		log.Errorf("Failed to initialize signer: %v", err)
+		panic(err)
+	}
+
+	tracker := tracking.NewTracker(cnf.GetTrackingConf().BaseURL, signer)
+
 	// use requestID middleware
 	e.Use(CustomMiddleware.MiddlewareRequestID())
 	e.Pre(middleware.RemoveTrailingSlash())
@@ -97,16 +139,41 @@ func Init() {
 	healthRepo := HealthRepository.NewHealthRepository(db)
 	workflowRepo := WorkflowRepository.NewWorkflowRepository(db)
 	schedulerRepo := SchedulerRepository.NewSchedulerRepository(db)
+	contactRepo := ContactRepository.NewContactRepository(db)
+	enrollmentRepo := EnrollmentRepository.NewEnrollmentRepository(db)
+	mailboxRepo := MailboxRepository.NewMailboxRepository(db)
+	emailRepo := EmailRepository.NewEmailRepository(db)
+	trackingRepo := TrackingRepository.NewTrackingRepository(db)
+	suppressionRepo := SuppressionRepository.NewSuppressionRepository(db)
 
 	// Initialize usecases
 	healthUsecase := HealthUsecase.NewHealthUsecase(healthRepo)
 	workflowUsecase := WorkflowUsecase.NewWorkflowUsecase(workflowRepo)
-	schedulerUsecase := SchedulerUsecase.NewSchedulerUsecase(schedulerRepo)
+	schedulerUsecase := SchedulerUsecase.NewSchedulerUsecase(schedulerRepo, db, cnf, kafkaClient, tracker)
+	contactUsecase := ContactUsecase.NewContactUsecase(contactRepo, db)
+	enrollmentUsecase := EnrollmentUsecase.NewEnrollmentUsecase(enrollmentRepo)
+	mailboxUsecase := MailboxUsecase.NewMailboxUsecase(mailboxRepo, cipher)
+	emailUsecase := EmailUsecase.NewEmailUsecase(emailRepo)
+	trackingUsecase := TrackingUsecase.NewTrackingUsecase(trackingRepo, tracker)
+	suppressionUsecase := SuppressionUsecase.NewSuppressionUsecase(suppressionRepo)
 
 	// Initialize handlers
 	HealthHandler.NewHealthHandler(e, healthUsecase)
 	WorkflowHandler.NewWorkflowHandler(e, workflowUsecase)
 	SchedulerHandler.NewSchedulerHandler(e, schedulerUsecase)
+	ContactHandler.NewContactHandler(e, contactUsecase)
+	EnrollmentHandler.NewEnrollmentHandler(e, enrollmentUsecase)
+	MailboxHandler.NewMailboxHandler(e, mailboxUsecase)
+	EmailHandler.NewEmailHandler(e, emailUsecase)
+	TrackingHandler.NewTrackingHandler(e, trackingUsecase)
+	SuppressionHandler.NewSuppressionHandler(e, suppressionUsecase)
+
+	// Start background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	SchedulerCron.NewSchedulerCron(workerCtx, cnf, schedulerUsecase)
+	ContactCron.NewImportCron(workerCtx, cnf, contactUsecase)
 
 	// Start server in a separate goroutine
 	serverAddr := fmt.Sprintf(":%s", cnf.GetPort())
@@ -122,6 +189,7 @@ func Init() {
 	<-quit
 
 	log.Info("Shutting down server...")
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"io"
+	"os/signal"
+	"syscall"
+
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/database"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/kafka"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/mail"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/smtp"
+
+	SenderConsumer "github.com/rohanchauhan02/sequence-service/internal/module/sender/delivery/consumer"
+	SenderRepository "github.com/rohanchauhan02/sequence-service/internal/module/sender/repository"
+	SenderUsecase "github.com/rohanchauhan02/sequence-service/internal/module/sender/usecase"
+)
+
+func InitConsumer() {
+	// Load configuration
+	cnf := config.NewImmutableConfig()
+
+	// Initialize database
+	dbClient := database.NewPostgressClient(cnf)
+
+	db, err := dbClient.InitClient(context.TODO())
+	if err != nil {
+		log.Errorf("Failed to connect to database: %v", err)
+		panic(err)
+	}
+
+	kafkaConsumer, err := kafka.NewKafkaConsumer(cnf)
+	if err != nil {
+		log.Errorf("Failed to initialize Kafka consumer: %v", err)
+		panic(err)
+	}
+
+	defer func() {
+		if err := kafkaConsumer.Close(); err != nil {
+			log.Errorf("Failed to close Kafka consumer: %v", err)
+		}
+	}()
+
+	kafkaClient, err := kafka.NewKafkaClient(cnf)
+	if err != nil {
+		log.Errorf("Failed to initialize Kafka client: %v", err)
+		panic(err)
+	}
+
+	defer func() {
+		if err := kafkaClient.Close(); err != nil {
+			log.Errorf("Failed to close Kafka client: %v", err)
+		}
+	}()
+
+	mailer, err := newMailer(cnf)
+	if err != nil {
+		log.Errorf("Failed to initialize mail transport: %v", err)
+		panic(err)
+	}
+
+	if closer, ok := mailer.(io.Closer); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				log.Errorf("Failed to close mail transport: %v", err)
+			}
+		}()
+	}
+
+	cipher, err := crypto.NewAESCipher(cnf.GetSecurityConf().EncryptionKey)
+	if err != nil {
+		log.Errorf("Failed to initialize cipher: %v", err)
+		panic(err)
+	}
+
+	// Initialize repositories
+	senderRepo := SenderRepository.NewSenderRepository(db)
+
+	// Initialize usecases
+	senderUsecase := SenderUsecase.NewSenderUsecase(senderRepo, db, mailer, cipher, kafkaClient, cnf)
+
+	// Consume until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("Starting email consumer...")
+	if err := SenderConsumer.NewSenderConsumer(ctx, cnf, kafkaConsumer, senderUsecase); err != nil {
+		log.Errorf("Email consumer stopped unexpectedly: %v", err)
+	}
+	log.Info("Consumer exited properly.")
+}
+
+// newMailer returns the mail transport selected by MAIL.TRANSPORT.
+func newMailer(cnf config.ImmutableConfig) (mail.Sender, error) {
+	if cnf.GetMailConf().Transport == mail.TransportSMTP {
+		return smtp.NewSender(cnf), nil
+	}
+	return mail.NewSender(cnf)
+}
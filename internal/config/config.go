@@ -14,12 +14,22 @@ type (
 		GetPort() string
 		GetDBConf() DB
 		GetKafkaConf() Kafka
+		GetSchedulerConf() Scheduler
+		GetMailConf() Mail
+		GetSecurityConf() Security
+		GetTrackingConf() Tracking
+		GetImportConf() Import
 	}
 
 	config struct {
-		Port  string `mapstructure:"PORT"`
-		DB    DB     `mapstructure:"DB"`
-		Kafka Kafka  `mapstructure:"KAFKA"`
+		Port      string    `mapstructure:"PORT"`
+		DB        DB        `mapstructure:"DB"`
+		Kafka     Kafka     `mapstructure:"KAFKA"`
+		Scheduler Scheduler `mapstructure:"SCHEDULER"`
+		Mail      Mail      `mapstructure:"MAIL"`
+		Security  Security  `mapstructure:"SECURITY"`
+		Tracking  Tracking  `mapstructure:"TRACKING"`
+		Import    Import    `mapstructure:"IMPORT"`
 	}
 	DB struct {
 		Host             string `mapstructure:"HOST"`
@@ -33,8 +43,9 @@ type (
 		SSLMode          string `mapstructure:"SSL_MODE"`
 	}
 	Kafka struct {
-		Broker string `mapstructure:"BROKERS"`
-		Topics Topic    `mapstructure:"TOPICS"`
+		Broker        string `mapstructure:"BROKERS"`
+		ConsumerGroup string `mapstructure:"CONSUMER_GROUP"`
+		Topics        Topic  `mapstructure:"TOPICS"`
 	}
 
 	Topic struct {
@@ -43,6 +54,42 @@ type (
 		EmailRetries   string `mapstructure:"EMAIL_RETRIES"`
 		EmailEvents    string `mapstructure:"EMAIL_EVENTS"`
 	}
+
+	Scheduler struct {
+		IntervalSeconds int `mapstructure:"INTERVAL_SECONDS"`
+		BatchSize       int `mapstructure:"BATCH_SIZE"`
+	}
+
+	Mail struct {
+		Transport string `mapstructure:"TRANSPORT"`
+		SMTP      SMTP   `mapstructure:"SMTP"`
+	}
+
+	SMTP struct {
+		TimeoutSeconds     int `mapstructure:"TIMEOUT_SECONDS"`
+		IdleTimeoutSeconds int `mapstructure:"IDLE_TIMEOUT_SECONDS"`
+		// MaxIdleConns is the number of open connections kept per mailbox between sends.
+		MaxIdleConns int    `mapstructure:"MAX_IDLE_CONNS"`
+		LocalName    string `mapstructure:"LOCAL_NAME"`
+		// AllowInsecure allows sending through servers without STARTTLS, for local development only.
+		AllowInsecure bool `mapstructure:"ALLOW_INSECURE"`
+	}
+
+	Security struct {
+		EncryptionKey string `mapstructure:"ENCRYPTION_KEY"`
+		SigningKey    string `mapstructure:"SIGNING_KEY"`
+		// WebhookSecret must be sent in the X-Webhook-Secret header of inbound mail webhooks.
+		WebhookSecret string `mapstructure:"WEBHOOK_SECRET"`
+	}
+
+	Tracking struct {
+		BaseURL string `mapstructure:"BASE_URL"`
+	}
+
+	Import struct {
+		IntervalSeconds int `mapstructure:"INTERVAL_SECONDS"`
+		MaxFileSizeMB   int `mapstructure:"MAX_FILE_SIZE_MB"`
+	}
 )
 
 var (
@@ -99,3 +146,23 @@ func (im *config) GetDBConf() DB {
 func (im *config) GetKafkaConf() Kafka {
 	return im.Kafka
 }
+
+func (im *config) GetSchedulerConf() Scheduler {
+	return im.Scheduler
+}
+
+func (im *config) GetMailConf() Mail {
+	return im.Mail
+}
+
+func (im *config) GetSecurityConf() Security {
+	return im.Security
+}
+
+func (im *config) GetTrackingConf() Tracking {
+	return im.Tracking
+}
+
+func (im *config) GetImportConf() Import {
+	return im.Import
+}
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateContactRequest struct {
+	Email     string `json:"email" validate:"required,email,max=255"`
+	FirstName string `json:"first_name" validate:"max=100"`
+	LastName  string `json:"last_name" validate:"max=100"`
+	Company   string `json:"company" validate:"max=255"`
+	Phone     string `json:"phone" validate:"max=50"`
+	Timezone  string `json:"timezone" validate:"omitempty,timezone" example:"America/New_York"`
+}
+
+type CreateContactResponse struct {
+	ID string `json:"id"`
+}
+
+type UpdateContactRequest struct {
+	Email     *string `json:"email" validate:"omitempty,email,max=255"`
+	FirstName *string `json:"first_name" validate:"omitempty,max=100"`
+	LastName  *string `json:"last_name" validate:"omitempty,max=100"`
+	Company   *string `json:"company" validate:"omitempty,max=255"`
+	Phone     *string `json:"phone" validate:"omitempty,max=50"`
+	Timezone  *string `json:"timezone" validate:"omitempty,timezone" example:"America/New_York"`
+}
+
+type ListContactsRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status string `query:"status" validate:"omitempty,oneof=active unsubscribed"`
+}
+
+// ImportContactsRequest is built from the multipart upload of a contact import. Mapping maps contact
+// fields to the CSV column holding their value and must include email.
+type ImportContactsRequest struct {
+	FileName   string
+	Data       []byte
+	Mapping    map[string]string
+	SequenceID *uuid.UUID
+}
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// ExportContactsRequest filters a contact export. With SequenceID or Status only contacts with a matching
+// enrollment are exported.
+type ExportContactsRequest struct {
+	Format     string     `query:"format" validate:"omitempty,oneof=csv ndjson"`
+	SequenceID *uuid.UUID `query:"sequence_id"`
+	Status     []string   `query:"status" validate:"omitempty,dive,oneof=pending in_progress completed paused bounced cancelled"`
+}
+
+// ContactExportRow is a contact with one of its enrollments. A contact is exported once per enrollment,
+// and once with empty enrollment fields when it has none.
+type ContactExportRow struct {
+	ContactID     uuid.UUID  `json:"contact_id"`
+	Email         string     `json:"email"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	Company       string     `json:"company"`
+	Phone         string     `json:"phone"`
+	Timezone      string     `json:"timezone"`
+	ContactStatus string     `json:"contact_status"`
+	SequenceID    *uuid.UUID `json:"sequence_id"`
+	CurrentStep   *int       `json:"current_step"`
+	Status        *string    `json:"status"`
+	StartedAt     *time.Time `json:"started_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "strings"
+
 type ResponsePattern struct {
 	RequestID    string `json:"request_id"`
 	Status       string `json:"status"`
@@ -9,3 +11,20 @@ type ResponsePattern struct {
 	Code         int    `json:"code"`
 	Meta         any    `json:"meta,omitempty"`
 }
+
+// FieldError describes why the value of a request field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrors is used as the message of a 400 *echo.HTTPError to report every rejected field at once.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
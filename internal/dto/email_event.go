@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+)
+
+// EmailEventMessage is the payload published to the email-events topic.
+type EmailEventMessage struct {
+	ID           uuid.UUID             `json:"id"`
+	EmailQueueID uuid.UUID             `json:"email_queue_id"`
+	EventType    models.EmailEventType `json:"event_type"`
+	EventData    models.EventData      `json:"event_data,omitempty"`
+	OccurredAt   time.Time             `json:"occurred_at"`
+}
+
+// InboundReplyRequest is a reply posted by the inbound mail webhook. InReplyTo and References are the
+// raw header values of the reply, which name the Message-IDs of the emails it answers.
+type InboundReplyRequest struct {
+	From       string `json:"from" validate:"required,max=255"`
+	Subject    string `json:"subject" validate:"max=998"`
+	MessageID  string `json:"message_id" validate:"max=998"`
+	InReplyTo  string `json:"in_reply_to" validate:"required_without=References"`
+	References string `json:"references"`
+}
+
+type InboundReplyResponse struct {
+	// Matched is false when the reply does not answer an email sent by the service.
+	Matched           bool       `json:"matched"`
+	EmailQueueID      *uuid.UUID `json:"email_queue_id,omitempty"`
+	SequenceContactID *uuid.UUID `json:"sequence_contact_id,omitempty"`
+	// Stopped is true when the reply stopped the enrollment, false when it already ended.
+	Stopped bool `json:"stopped"`
+}
+
+type InboundBounceResponse struct {
+	// Matched is false when the bounce does not report an email sent by the service.
+	Matched      bool       `json:"matched"`
+	EmailQueueID *uuid.UUID `json:"email_queue_id,omitempty"`
+	BounceType   string     `json:"bounce_type,omitempty"`
+	// Suppressed is true when a hard bounce suppressed the recipient.
+	Suppressed bool `json:"suppressed"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailJobBatch is the payload published to the email-jobs topic.
+type EmailJobBatch struct {
+	BatchID    uuid.UUID  `json:"batch_id"`
+	Emails     []EmailJob `json:"emails"`
+	ProducedAt time.Time  `json:"produced_at"`
+}
+
+type EmailJob struct {
+	EmailQueueID      uuid.UUID  `json:"email_queue_id"`
+	SequenceContactID uuid.UUID  `json:"sequence_contact_id"`
+	MailboxID         *uuid.UUID `json:"mailbox_id,omitempty"`
+	From              string     `json:"from,omitempty"`
+	To                string     `json:"to"`
+	Subject           string     `json:"subject"`
+	Content           string     `json:"content"`
+	// UnsubscribeURL is the one-click unsubscribe URL sent in the List-Unsubscribe header.
+	UnsubscribeURL string `json:"unsubscribe_url,omitempty"`
+
+	OpenTrackingEnabled  bool `json:"-"`
+	ClickTrackingEnabled bool `json:"-"`
+}
+
+// EmailRetry is the payload published to the email-retries topic for a failed send.
+// The consumer holds it until RetryAt before sending again.
+type EmailRetry struct {
+	Email   EmailJob  `json:"email"`
+	Attempt int       `json:"attempt"`
+	RetryAt time.Time `json:"retry_at"`
+}
+
+type RequeueEmailsRequest struct {
+	EmailQueueIDs []uuid.UUID `json:"email_queue_ids" validate:"required,min=1,max=500"`
+}
+
+type RequeueEmailsResponse struct {
+	Requeued int `json:"requeued"`
+	// Skipped lists the emails that are not failed, may no longer be sent to their contact, or whose
+	// mailbox has no capacity left today.
+	Skipped []uuid.UUID `json:"skipped"`
+}
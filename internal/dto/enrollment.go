@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/google/uuid"
+
+type EnrollContactsRequest struct {
+	ContactIDs []uuid.UUID `json:"contact_ids" validate:"required,min=1,max=1000"`
+}
+
+// EnrollContactsResponse lists the created enrollments. Suppressed and unsubscribed contacts are
+// skipped rather than failing the request.
+type EnrollContactsResponse struct {
+	Enrolled               int      `json:"enrolled"`
+	SequenceContactIDs     []string `json:"sequence_contact_ids"`
+	SuppressedContactIDs   []string `json:"suppressed_contact_ids"`
+	UnsubscribedContactIDs []string `json:"unsubscribed_contact_ids"`
+}
+
+type ListEnrollmentsRequest struct {
+	Page   int      `query:"page" validate:"omitempty,min=1"`
+	Limit  int      `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status []string `query:"status" validate:"omitempty,dive,oneof=pending in_progress completed paused bounced cancelled"`
+}
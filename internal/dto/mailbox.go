@@ -0,0 +1,63 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateMailboxRequest struct {
+	Email         string `json:"email" validate:"required,email,max=255"`
+	DailyCapacity int    `json:"daily_capacity" validate:"omitempty,min=1"`
+	Provider      string `json:"provider" validate:"max=100"`
+	SMTPHost      string `json:"smtp_host" validate:"required,hostname_rfc1123,max=255"`
+	SMTPPort      int    `json:"smtp_port" validate:"required,min=1,max=65535"`
+	SMTPUsername  string `json:"smtp_username" validate:"required,max=255"`
+	SMTPPassword  string `json:"smtp_password" validate:"required"`
+}
+
+type CreateMailboxResponse struct {
+	ID string `json:"id"`
+}
+
+type UpdateMailboxRequest struct {
+	DailyCapacity *int    `json:"daily_capacity" validate:"omitempty,min=1"`
+	Provider      *string `json:"provider" validate:"omitempty,max=100"`
+	SMTPHost      *string `json:"smtp_host" validate:"omitempty,hostname_rfc1123,max=255"`
+	SMTPPort      *int    `json:"smtp_port" validate:"omitempty,min=1,max=65535"`
+	SMTPUsername  *string `json:"smtp_username" validate:"omitempty,max=255"`
+	SMTPPassword  *string `json:"smtp_password" validate:"omitempty,min=1"`
+}
+
+type ListMailboxesRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status string `query:"status" validate:"omitempty,oneof=active inactive suspended"`
+}
+
+type AssignMailboxesRequest struct {
+	MailboxIDs []uuid.UUID `json:"mailbox_ids" validate:"required,min=1,max=200"`
+}
+
+// MailboxLoad is an active mailbox assigned to a sequence with the number of emails reserved on it for a day.
+type MailboxLoad struct {
+	MailboxID     uuid.UUID `json:"mailbox_id"`
+	Email         string    `json:"email"`
+	DailyCapacity int       `json:"daily_capacity"`
+	Queued        int       `json:"queued"`
+}
+
+type MailboxUsageRequest struct {
+	Page  int    `query:"page" validate:"omitempty,min=1"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Date  string `query:"date"`
+}
+
+// MailboxUsage is the daily capacity usage of a mailbox for a UTC day.
+type MailboxUsage struct {
+	MailboxID       uuid.UUID `json:"mailbox_id"`
+	Email           string    `json:"email"`
+	Date            string    `json:"date"`
+	DailyCapacity   int       `json:"daily_capacity"`
+	SentCount       int       `json:"sent_count"`
+	FailedCount     int       `json:"failed_count"`
+	HardBounceCount int       `json:"hard_bounce_count"`
+	SoftBounceCount int       `json:"soft_bounce_count"`
+	Remaining       int       `json:"remaining"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type ScheduleDueContactsResponse struct {
+	Scheduled int `json:"scheduled"`
+	// Deferred counts the scheduled emails pushed to a later day because their mailboxes were at capacity.
+	Deferred  int `json:"deferred"`
+	Completed int `json:"completed"`
+	// Rescheduled counts the enrollments moved to the next opening of their step's send window.
+	Rescheduled int `json:"rescheduled"`
+}
+
+type DispatchDueEmailsResponse struct {
+	Dispatched int `json:"dispatched"`
+	Batches    int `json:"batches"`
+}
+
+// RecoverStaleEmailsResponse counts the emails left behind by a dispatcher or sender that stopped midway.
+type RecoverStaleEmailsResponse struct {
+	// Requeued counts the queued emails that were never claimed and are dispatched again.
+	Requeued int64 `json:"requeued"`
+	// Retried counts the emails whose send outcome was lost and that are retried.
+	Retried int64 `json:"retried"`
+	// Failed counts the emails whose send outcome was lost after their last retry.
+	Failed int64 `json:"failed"`
+}
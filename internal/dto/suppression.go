@@ -0,0 +1,16 @@
+package dto
+
+// CreateSuppressionRequest suppresses either a single email address or a whole domain.
+type CreateSuppressionRequest struct {
+	Email  string `json:"email" validate:"required_without=Domain,excluded_with=Domain,omitempty,email,max=255"`
+	Domain string `json:"domain" validate:"required_without=Email,excluded_with=Email,omitempty,fqdn,max=255" example:"example.com"`
+	Reason string `json:"reason" validate:"required,oneof=unsubscribed bounced complaint manual"`
+}
+
+type ListSuppressionsRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Type   string `query:"type" validate:"omitempty,oneof=email domain"`
+	Reason string `query:"reason" validate:"omitempty,oneof=unsubscribed bounced complaint manual"`
+	Search string `query:"search" validate:"omitempty,max=255"`
+}
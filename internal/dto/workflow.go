@@ -1,32 +1,119 @@
 package dto
 
+import (
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+)
+
 type CreateSequenceRequest struct {
 	Name                 string              `json:"name" validate:"required,min=1,max=255"`
 	OpenTrackingEnabled  bool                `json:"open_tracking_enabled"`
 	ClickTrackingEnabled bool                `json:"click_tracking_enabled"`
-	Steps                []CreateStepRequest `json:"steps"`
+	Status               string              `json:"status" validate:"omitempty,oneof=draft active"`
+	Steps                []CreateStepRequest `json:"steps" validate:"dive"`
 }
 type CreateSequenceResponse struct {
 	ID string `json:"id"`
 }
+
+// CloneSequenceRequest names the copy of a sequence. The source name suffixed with " (copy)" is used
+// when Name is empty.
+type CloneSequenceRequest struct {
+	Name string `json:"name" validate:"omitempty,min=1,max=255"`
+}
+
+type DeleteSequenceResponse struct {
+	CancelledEnrollments int64 `json:"cancelled_enrollments"`
+	CancelledEmails      int64 `json:"cancelled_emails"`
+}
+
 type UpdateSequenceTrackingRequest struct {
 	OpenTrackingEnabled  *bool `json:"open_tracking_enabled"`
 	ClickTrackingEnabled *bool `json:"click_tracking_enabled"`
 }
 
+// SendWindow restricts sending to a daily time range in the contact's timezone, e.g. 09:00-17:00 on
+// mon-fri. All days are allowed when Days is empty.
+type SendWindow struct {
+	Start string   `json:"start" validate:"required" example:"09:00"`
+	End   string   `json:"end" validate:"required" example:"17:00"`
+	Days  []string `json:"days" validate:"omitempty,max=7,dive,oneof=sun mon tue wed thu fri sat"`
+}
+
 type CreateStepRequest struct {
-	StepOrder int    `json:"step_order" validate:"required,min=0"`
-	Subject   string `json:"subject" validate:"required,min=1"`
-	Content   string `json:"content" validate:"required,min=1"`
-	WaitDays  int    `json:"wait_days" validate:"required,min=0"`
+	StepOrder   int         `json:"step_order" validate:"required,min=1"`
+	Subject     string      `json:"subject" validate:"required,min=1"`
+	Content     string      `json:"content" validate:"required,min=1"`
+	WaitDays    int         `json:"wait_days" validate:"min=0"`
+	WaitHours   int         `json:"wait_hours" validate:"min=0,max=23"`
+	WaitMinutes int         `json:"wait_minutes" validate:"min=0,max=59"`
+	SendWindow  *SendWindow `json:"send_window"`
 }
 
+// UpdateStepRequest patches a step. RemoveSendWindow clears the send window of the step.
 type UpdateStepRequest struct {
-	Subject *string `json:"subject" validate:"omitempty,min=1"`
-	Content *string `json:"content" validate:"omitempty,min=1"`
+	Subject          *string     `json:"subject" validate:"omitempty,min=1"`
+	Content          *string     `json:"content" validate:"omitempty,min=1"`
+	WaitDays         *int        `json:"wait_days" validate:"omitempty,min=0"`
+	WaitHours        *int        `json:"wait_hours" validate:"omitempty,min=0,max=23"`
+	WaitMinutes      *int        `json:"wait_minutes" validate:"omitempty,min=0,max=59"`
+	SendWindow       *SendWindow `json:"send_window"`
+	RemoveSendWindow bool        `json:"remove_send_window"`
+}
+
+// AddStepRequest inserts a step at Position, shifting the steps from there on. It is appended when
+// Position is omitted.
+type AddStepRequest struct {
+	Position    int         `json:"position" validate:"omitempty,min=1"`
+	Subject     string      `json:"subject" validate:"required,min=1"`
+	Content     string      `json:"content" validate:"required,min=1"`
+	WaitDays    int         `json:"wait_days" validate:"min=0"`
+	WaitHours   int         `json:"wait_hours" validate:"min=0,max=23"`
+	WaitMinutes int         `json:"wait_minutes" validate:"min=0,max=59"`
+	SendWindow  *SendWindow `json:"send_window"`
+}
+
+// ReorderStepsRequest lists every step of a sequence in its new order.
+type ReorderStepsRequest struct {
+	StepIDs []uuid.UUID `json:"step_ids" validate:"required,min=1"`
 }
 
 type UpdateSequenceRequest struct {
 	OpenTrackingEnabled  *bool `json:"open_tracking_enabled"`
 	ClickTrackingEnabled *bool `json:"click_tracking_enabled"`
 }
+
+type ListSequencesRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Search string `query:"search" validate:"omitempty,max=255"`
+	Status string `query:"status" validate:"omitempty,oneof=draft active paused archived"`
+	SortBy string `query:"sort_by" validate:"omitempty,oneof=created_at updated_at"`
+	Order  string `query:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+type DiffSequenceVersionsRequest struct {
+	From int `query:"from" validate:"required,min=1"`
+	To   int `query:"to" validate:"required,min=1"`
+}
+
+// SequenceVersionDiff lists the steps added, removed and changed from one version of a sequence to
+// another. Steps are matched by ID, so a moved step shows up as a step_order change.
+type SequenceVersionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Added   []models.Step `json:"added"`
+	Removed []models.Step `json:"removed"`
+	Changed []StepDiff    `json:"changed"`
+}
+
+type StepDiff struct {
+	StepID  uuid.UUID     `json:"step_id"`
+	Changes []FieldChange `json:"changes"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
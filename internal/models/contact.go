@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ContactStatus string
+
+const (
+	ContactStatusActive       ContactStatus = "active"
+	ContactStatusUnsubscribed ContactStatus = "unsubscribed"
+)
+
+type Contact struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Email     string         `json:"email" gorm:"type:varchar(255);not null;index"`
+	FirstName string         `json:"first_name" gorm:"type:varchar(100)"`
+	LastName  string         `json:"last_name" gorm:"type:varchar(100)"`
+	Company   string         `json:"company" gorm:"type:varchar(255)"`
+	Phone     string         `json:"phone" gorm:"type:varchar(50)"`
+	Timezone  string         `json:"timezone,omitempty" gorm:"type:varchar(64)"`
+	Status    ContactStatus  `json:"status" gorm:"type:contact_status;default:active"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggerignore:"true"`
+}
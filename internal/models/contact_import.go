@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ContactImportStatus string
+
+const (
+	ContactImportStatusPending    ContactImportStatus = "pending"
+	ContactImportStatusProcessing ContactImportStatus = "processing"
+	ContactImportStatusCompleted  ContactImportStatus = "completed"
+	ContactImportStatusFailed     ContactImportStatus = "failed"
+)
+
+type ImportRowOutcome string
+
+const (
+	ImportRowOutcomeSkipped ImportRowOutcome = "skipped"
+	ImportRowOutcomeFailed  ImportRowOutcome = "failed"
+)
+
+// ContactImport is a CSV upload that is turned into contacts in the background. The uploaded file is
+// kept with the job so an interrupted import resumes from the last processed row.
+type ContactImport struct {
+	ID            uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FileName      string              `json:"file_name" gorm:"type:varchar(255)"`
+	Mapping       ImportMapping       `json:"mapping" gorm:"type:jsonb;not null"`
+	SequenceID    *uuid.UUID          `json:"sequence_id,omitempty" gorm:"type:uuid"`
+	Status        ContactImportStatus `json:"status" gorm:"type:contact_import_status;default:pending;index"`
+	TotalRows     int                 `json:"total_rows" gorm:"not null;default:0"`
+	ProcessedRows int                 `json:"processed_rows" gorm:"not null;default:0"`
+	Imported      int                 `json:"imported" gorm:"not null;default:0"`
+	Skipped       int                 `json:"skipped" gorm:"not null;default:0"`
+	Failed        int                 `json:"failed" gorm:"not null;default:0"`
+	Enrolled      int                 `json:"enrolled" gorm:"not null;default:0"`
+	Issues        ImportIssues        `json:"issues" gorm:"type:jsonb;not null"`
+	Error         string              `json:"error,omitempty" gorm:"type:text"`
+	Data          []byte              `json:"-" gorm:"type:bytea;not null"`
+	StartedAt     *time.Time          `json:"started_at"`
+	CompletedAt   *time.Time          `json:"completed_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// ImportMapping maps contact fields to the CSV column holding their value.
+type ImportMapping map[string]string
+
+func (m ImportMapping) Value() (driver.Value, error) {
+	if m == nil {
+		m = ImportMapping{}
+	}
+	return jsonValue(m)
+}
+
+func (m *ImportMapping) Scan(value any) error {
+	return scanJSON(value, m)
+}
+
+// ImportIssue explains why a row of an import was skipped or failed. Row is the line of the CSV file.
+type ImportIssue struct {
+	Row     int              `json:"row"`
+	Email   string           `json:"email,omitempty"`
+	Outcome ImportRowOutcome `json:"outcome"`
+	Reason  string           `json:"reason"`
+}
+
+// ImportIssues is the list of rows of an import that were not imported, stored in a JSONB column.
+type ImportIssues []ImportIssue
+
+func (i ImportIssues) Value() (driver.Value, error) {
+	if i == nil {
+		i = ImportIssues{}
+	}
+	return jsonValue(i)
+}
+
+func (i *ImportIssues) Scan(value any) error {
+	return scanJSON(value, i)
+}
+
+func jsonValue(v any) (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func scanJSON(value any, dest any) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("unsupported JSON column type %T", value)
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailEventType string
+
+const (
+	EmailEventTypeSent         EmailEventType = "sent"
+	EmailEventTypeDelivered    EmailEventType = "delivered"
+	EmailEventTypeOpened       EmailEventType = "opened"
+	EmailEventTypeClicked      EmailEventType = "clicked"
+	EmailEventTypeBounced      EmailEventType = "bounced"
+	EmailEventTypeFailed       EmailEventType = "failed"
+	EmailEventTypeUnsubscribed EmailEventType = "unsubscribed"
+	EmailEventTypeReplied      EmailEventType = "replied"
+)
+
+// EventData is stored in a JSONB column.
+type EventData map[string]any
+
+func (d EventData) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (d *EventData) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*d = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	default:
+		return fmt.Errorf("unsupported event data type %T", value)
+	}
+}
+
+type EmailEvent struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EmailQueueID uuid.UUID      `json:"email_queue_id" gorm:"type:uuid;index"`
+	EventType    EmailEventType `json:"event_type" gorm:"type:email_event_type;not null"`
+	EventData    EventData      `json:"event_data" gorm:"type:jsonb"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmailQueueStatus string
+
+const (
+	EmailQueueStatusScheduled EmailQueueStatus = "scheduled"
+	EmailQueueStatusQueued    EmailQueueStatus = "queued"
+	EmailQueueStatusSending   EmailQueueStatus = "sending"
+	EmailQueueStatusSent      EmailQueueStatus = "sent"
+	EmailQueueStatusFailed    EmailQueueStatus = "failed"
+	EmailQueueStatusCancelled EmailQueueStatus = "cancelled"
+)
+
+type EmailQueue struct {
+	ID                uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SequenceContactID uuid.UUID        `json:"sequence_contact_id" gorm:"type:uuid;not null;index"`
+	MailboxID         *uuid.UUID       `json:"mailbox_id" gorm:"type:uuid"`
+	StepOrder         int              `json:"step_order" gorm:"not null"`
+	Subject           string           `json:"subject" gorm:"type:text;not null"`
+	Content           string           `json:"content" gorm:"type:text;not null"`
+	ScheduledFor      time.Time        `json:"scheduled_for" gorm:"not null"`
+	Status            EmailQueueStatus `json:"status" gorm:"type:email_queue_status;default:scheduled"`
+	RetryCount        int              `json:"retry_count" gorm:"default:0"`
+	MaxRetries        int              `json:"max_retries" gorm:"default:3"`
+	LastAttemptAt     *time.Time       `json:"last_attempt_at"`
+	SentAt            *time.Time       `json:"sent_at"`
+	ErrorMessage      *string          `json:"error_message"`
+	MessageID         *string          `json:"message_id" gorm:"type:varchar(255)"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type KafkaBatch struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Topic       string    `json:"topic" gorm:"type:varchar(100);not null"`
+	Partition   int32     `json:"partition" gorm:"not null"`
+	KafkaOffset int64     `json:"kafka_offset" gorm:"not null"`
+	ProcessedAt time.Time `json:"processed_at" gorm:"default:now()"`
+	EmailCount  int       `json:"email_count" gorm:"default:0"`
+	BatchSize   int       `json:"batch_size" gorm:"default:0"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MailboxStatus string
+
+const (
+	MailboxStatusActive    MailboxStatus = "active"
+	MailboxStatusInactive  MailboxStatus = "inactive"
+	MailboxStatusSuspended MailboxStatus = "suspended"
+)
+
+type Mailbox struct {
+	ID                    uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Email                 string         `json:"email" gorm:"type:varchar(255);unique;not null"`
+	DailyCapacity         int            `json:"daily_capacity" gorm:"default:30"`
+	Status                MailboxStatus  `json:"status" gorm:"type:mailbox_status;default:active"`
+	Provider              string         `json:"provider" gorm:"type:varchar(100)"`
+	SMTPHost              string         `json:"smtp_host" gorm:"column:smtp_host;type:varchar(255)"`
+	SMTPPort              int            `json:"smtp_port" gorm:"column:smtp_port"`
+	SMTPUsername          string         `json:"smtp_username" gorm:"column:smtp_username;type:varchar(255)"`
+	EncryptedSMTPPassword []byte         `json:"-" gorm:"column:encrypted_smtp_password;type:bytea"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggerignore:"true"`
+}
+
+type SequenceMailbox struct {
+	SequenceID uuid.UUID `json:"sequence_id" gorm:"type:uuid;primaryKey"`
+	MailboxID  uuid.UUID `json:"mailbox_id" gorm:"type:uuid;primaryKey"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// MailboxDailyCount tracks how much of a mailbox's daily capacity is used on a UTC day.
+// SentCount holds the emails reserved for the day, including those not sent yet.
+type MailboxDailyCount struct {
+	MailboxID       uuid.UUID `json:"mailbox_id" gorm:"type:uuid;primaryKey"`
+	Date            time.Time `json:"date" gorm:"type:date;primaryKey"`
+	SentCount       int       `json:"sent_count" gorm:"default:0"`
+	FailedCount     int       `json:"failed_count" gorm:"default:0"`
+	HardBounceCount int       `json:"hard_bounce_count" gorm:"default:0"`
+	SoftBounceCount int       `json:"soft_bounce_count" gorm:"default:0"`
+	ResetAt         time.Time `json:"reset_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SequenceContactStatus string
+
+const (
+	SequenceContactStatusPending    SequenceContactStatus = "pending"
+	SequenceContactStatusInProgress SequenceContactStatus = "in_progress"
+	SequenceContactStatusCompleted  SequenceContactStatus = "completed"
+	SequenceContactStatusPaused     SequenceContactStatus = "paused"
+	SequenceContactStatusBounced    SequenceContactStatus = "bounced"
+	SequenceContactStatusCancelled  SequenceContactStatus = "cancelled"
+)
+
+// CompletedReasonReplied is the completed_reason of enrollments stopped because the contact replied.
+const CompletedReasonReplied = "replied"
+
+type SequenceContact struct {
+	ID              uuid.UUID             `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SequenceID      uuid.UUID             `json:"sequence_id" gorm:"type:uuid;not null;index"`
+	ContactID       uuid.UUID             `json:"contact_id" gorm:"type:uuid;not null;index"`
+	Version         int                   `json:"version" gorm:"not null;default:1"`
+	CurrentStep     int                   `json:"current_step" gorm:"default:0"`
+	NextSendAt      *time.Time            `json:"next_send_at"`
+	Status          SequenceContactStatus `json:"status" gorm:"type:sequence_contact_status;default:pending"`
+	StartedAt       *time.Time            `json:"started_at"`
+	CompletedAt     *time.Time            `json:"completed_at"`
+	CompletedReason *string               `json:"completed_reason,omitempty" gorm:"type:varchar(50)"`
+	Contact         *Contact              `json:"contact,omitempty" gorm:"foreignKey:ContactID"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
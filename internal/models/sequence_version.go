@@ -0,0 +1,62 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SequenceVersion is an immutable snapshot of the steps of a sequence. A new version is published every
+// time the steps change, and each enrollment keeps the version that was latest when it was created.
+type SequenceVersion struct {
+	ID         uuid.UUID    `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SequenceID uuid.UUID    `json:"sequence_id" gorm:"type:uuid;not null;uniqueIndex:idx_sequence_versions_version"`
+	Version    int          `json:"version" gorm:"not null;uniqueIndex:idx_sequence_versions_version"`
+	Steps      VersionSteps `json:"steps,omitempty" gorm:"type:jsonb;not null"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// NextSteps returns up to limit steps of the version that come after the given step order.
+func (v *SequenceVersion) NextSteps(afterStepOrder int, limit int) []Step {
+	var steps []Step
+	for _, step := range v.Steps {
+		if len(steps) == limit {
+			break
+		}
+		if step.StepOrder > afterStepOrder {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// VersionSteps is the ordered step list of a version, stored in a JSONB column.
+type VersionSteps []Step
+
+func (s VersionSteps) Value() (driver.Value, error) {
+	if s == nil {
+		s = VersionSteps{}
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (s *VersionSteps) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported version steps type %T", value)
+	}
+}
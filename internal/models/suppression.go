@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuppressionType string
+
+const (
+	SuppressionTypeEmail  SuppressionType = "email"
+	SuppressionTypeDomain SuppressionType = "domain"
+)
+
+type SuppressionReason string
+
+const (
+	SuppressionReasonUnsubscribed SuppressionReason = "unsubscribed"
+	SuppressionReasonBounced      SuppressionReason = "bounced"
+	SuppressionReasonComplaint    SuppressionReason = "complaint"
+	SuppressionReasonManual       SuppressionReason = "manual"
+)
+
+// Suppression blocks an email address, or every address of a domain, from being enrolled or sent to.
+// Value is stored in lowercase.
+type Suppression struct {
+	ID        uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Type      SuppressionType   `json:"type" gorm:"type:suppression_type;not null;uniqueIndex:idx_suppressions_value"`
+	Value     string            `json:"value" gorm:"type:varchar(255);not null;uniqueIndex:idx_suppressions_value"`
+	Reason    SuppressionReason `json:"reason" gorm:"type:suppression_reason;not null"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// EmailDomain returns the lowercase domain of an email address, the value matched by domain suppressions.
+func EmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:]))
+}
@@ -1,31 +1,89 @@
 package models
 
 import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+type SequenceStatus string
+
+const (
+	SequenceStatusDraft    SequenceStatus = "draft"
+	SequenceStatusActive   SequenceStatus = "active"
+	SequenceStatusPaused   SequenceStatus = "paused"
+	SequenceStatusArchived SequenceStatus = "archived"
+)
+
 type Sequence struct {
 	ID                   uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	Name                 string         `json:"name" gorm:"type:varchar(255);not null"`
 	OpenTrackingEnabled  bool           `json:"open_tracking_enabled" gorm:"default:true"`
 	ClickTrackingEnabled bool           `json:"click_tracking_enabled" gorm:"default:true"`
+	Status               SequenceStatus `json:"status" gorm:"type:sequence_status;default:draft"`
 	Steps                []Step         `json:"steps,omitempty" gorm:"foreignKey:SequenceID;constraint:OnDelete:CASCADE"`
 	CreatedAt            time.Time      `json:"created_at"`
 	UpdatedAt            time.Time      `json:"updated_at"`
 	DeletedAt            gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggerignore:"true"`
 }
 
+// Step is an email of a sequence. It is due WaitDays, WaitHours and WaitMinutes after the previous step,
+// and only goes out inside the optional send window, given as HH:MM in the contact's timezone. All days
+// are allowed when SendWindowDays is empty.
 type Step struct {
-	ID         uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	SequenceID uuid.UUID      `json:"sequence_id" gorm:"type:uuid;not null;index"`
-	StepOrder  int            `json:"step_order" gorm:"not null"`
-	Subject    string         `json:"subject" gorm:"type:text;not null"`
-	Content    string         `json:"content" gorm:"type:text;not null"`
-	WaitDays   int            `json:"wait_days" gorm:"default:1"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggerignore:"true"`
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SequenceID      uuid.UUID      `json:"sequence_id" gorm:"type:uuid;not null;index"`
+	StepOrder       int            `json:"step_order" gorm:"not null"`
+	Subject         string         `json:"subject" gorm:"type:text;not null"`
+	Content         string         `json:"content" gorm:"type:text;not null"`
+	WaitDays        int            `json:"wait_days" gorm:"default:1"`
+	WaitHours       int            `json:"wait_hours" gorm:"default:0"`
+	WaitMinutes     int            `json:"wait_minutes" gorm:"default:0"`
+	SendWindowStart *string        `json:"send_window_start,omitempty" gorm:"type:varchar(5)"`
+	SendWindowEnd   *string        `json:"send_window_end,omitempty" gorm:"type:varchar(5)"`
+	SendWindowDays  Weekdays       `json:"send_window_days,omitempty" gorm:"type:varchar(27)"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggerignore:"true"`
+}
+
+// SendAfter returns when the step is due after the previous one was sent at t.
+func (s *Step) SendAfter(t time.Time) time.Time {
+	return t.AddDate(0, 0, s.WaitDays).
+		Add(time.Duration(s.WaitHours)*time.Hour + time.Duration(s.WaitMinutes)*time.Minute)
+}
+
+// Weekdays is a list of weekday names such as "mon", stored comma separated.
+type Weekdays []string
+
+func (w Weekdays) Value() (driver.Value, error) {
+	if len(w) == 0 {
+		return nil, nil
+	}
+	return strings.Join(w, ","), nil
+}
+
+func (w *Weekdays) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*w = nil
+	case string:
+		*w = splitWeekdays(v)
+	case []byte:
+		*w = splitWeekdays(string(v))
+	default:
+		return fmt.Errorf("unsupported type for Weekdays: %T", value)
+	}
+	return nil
+}
+
+func splitWeekdays(value string) Weekdays {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
 }
@@ -0,0 +1,56 @@
+package contact
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"gorm.io/gorm"
+)
+
+type Usecase interface {
+	CreateContact(c echo.Context, req *dto.CreateContactRequest) (*dto.CreateContactResponse, error)
+	GetContact(c echo.Context, contactID uuid.UUID) (*models.Contact, error)
+	ListContacts(c echo.Context, req *dto.ListContactsRequest) ([]models.Contact, *dto.PaginationMeta, error)
+	UpdateContact(c echo.Context, contactID uuid.UUID, req *dto.UpdateContactRequest) (*models.Contact, error)
+	DeleteContact(c echo.Context, contactID uuid.UUID) error
+	UnsubscribeContact(c echo.Context, contactID uuid.UUID) error
+
+	// ImportContacts validates the upload and queues it, the rows are imported by ProcessContactImport.
+	ImportContacts(c echo.Context, req *dto.ImportContactsRequest) (*models.ContactImport, error)
+	GetContactImport(c echo.Context, importID uuid.UUID) (*models.ContactImport, error)
+	ProcessContactImport(ctx context.Context) (*models.ContactImport, error)
+
+	// ExportContacts writes the contacts to w as they are read, flushing w every few hundred rows.
+	ExportContacts(c echo.Context, req *dto.ExportContactsRequest, w io.Writer) error
+}
+
+type Repository interface {
+	CreateContact(tx *gorm.DB, contact *models.Contact) (*models.Contact, error)
+	GetContact(contactID uuid.UUID) (*models.Contact, error)
+	GetContactByEmail(email string) (*models.Contact, error)
+	ListContacts(status string, offset, limit int) ([]models.Contact, int64, error)
+	UpdateContact(tx *gorm.DB, contact *models.Contact) error
+	DeleteContact(tx *gorm.DB, contactID uuid.UUID) error
+	CancelContactEnrollments(tx *gorm.DB, contactID uuid.UUID) (int64, error)
+	CancelContactEmails(tx *gorm.DB, contactID uuid.UUID, errorMessage string) (int64, error)
+	GetContactsByEmails(tx *gorm.DB, emails []string) ([]models.Contact, error)
+	CreateContacts(tx *gorm.DB, contacts []models.Contact) error
+	ExportContacts(db *gorm.DB, sequenceID *uuid.UUID, statuses []string, fn func(row *dto.ContactExportRow) error) error
+
+	CreateContactImport(tx *gorm.DB, contactImport *models.ContactImport) error
+	GetContactImport(importID uuid.UUID) (*models.ContactImport, error)
+	ClaimContactImport(tx *gorm.DB, staleBefore time.Time) (*models.ContactImport, error)
+	UpdateContactImport(tx *gorm.DB, contactImport *models.ContactImport) error
+
+	GetSequence(tx *gorm.DB, sequenceID uuid.UUID) (*models.Sequence, error)
+	GetLatestVersion(tx *gorm.DB, sequenceID uuid.UUID) (*models.SequenceVersion, error)
+	EnrollContacts(tx *gorm.DB, sequenceContacts []models.SequenceContact) (int64, error)
+
+	CreateSuppression(tx *gorm.DB, suppression *models.Suppression) error
+	GetSuppressedContactIDs(tx *gorm.DB, contactIDs []uuid.UUID) ([]uuid.UUID, error)
+}
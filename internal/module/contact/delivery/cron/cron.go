@@ -0,0 +1,65 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/module/contact"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+)
+
+const defaultInterval = 10 * time.Second
+
+var log = logger.NewLogger("IMPORT-CRON")
+
+type importCron struct {
+	usecase  contact.Usecase
+	interval time.Duration
+}
+
+// NewImportCron processes queued contact imports on a fixed interval until ctx is cancelled.
+func NewImportCron(ctx context.Context, conf config.ImmutableConfig, usecase contact.Usecase) {
+	interval := time.Duration(conf.GetImportConf().IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	c := &importCron{
+		usecase:  usecase,
+		interval: interval,
+	}
+
+	go c.run(ctx)
+}
+
+func (c *importCron) run(ctx context.Context) {
+	log.Infof("Import worker started with interval %s", c.interval)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Import worker stopped")
+			return
+		case <-ticker.C:
+			c.tick(ctx)
+		}
+	}
+}
+
+// tick works through the queued imports one at a time until none is left.
+func (c *importCron) tick(ctx context.Context) {
+	for ctx.Err() == nil {
+		contactImport, err := c.usecase.ProcessContactImport(ctx)
+		if err != nil {
+			log.Errorf("ProcessContactImport failed: %v", err)
+			return
+		}
+		if contactImport == nil {
+			return
+		}
+	}
+}
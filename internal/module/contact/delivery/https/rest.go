@@ -0,0 +1,414 @@
+package https
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/module/contact"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+)
+
+// defaultMaxImportFileSize applies when IMPORT.MAX_FILE_SIZE_MB is not configured.
+const defaultMaxImportFileSize = 10 << 20
+
+// maxImportFormOverhead is allowed on top of the file size for the multipart boundaries, part headers
+// and the mapping and sequence_id fields.
+const maxImportFormOverhead = 1 << 20
+
+type contactHandler struct {
+	usecase contact.Usecase
+}
+
+func NewContactHandler(e *echo.Echo, usecase contact.Usecase) {
+	h := &contactHandler{
+		usecase: usecase,
+	}
+
+	api := e.Group("/api/v1")
+
+	api.POST("/contact", h.CreateContact)
+	api.GET("/contacts", h.ListContacts)
+	api.GET("/contact/:id", h.GetContact)
+	api.PUT("/contact/:id", h.UpdateContact)
+	api.DELETE("/contact/:id", h.DeleteContact)
+	api.POST("/contact/:id/unsubscribe", h.UnsubscribeContact)
+	api.POST("/contacts/imports", h.ImportContacts)
+	api.GET("/contacts/imports/:id", h.GetContactImport)
+	api.GET("/contacts/export", h.ExportContacts)
+}
+
+// CreateContact godoc
+// @Summary      Create a new contact
+// @Description  Create a new contact that can be enrolled into sequences
+// @Tags         Contacts
+// @Accept       json
+// @Produce      json
+// @Param        contact  body      dto.CreateContactRequest  true  "Contact details"
+// @Success      201  {object}  dto.ResponsePattern{data=dto.CreateContactResponse}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contact [post]
+func (h *contactHandler) CreateContact(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.CreateContactRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("CreateContact - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	resp, err := h.usecase.CreateContact(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("CreateContact - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	ac.AppLoger.Infof("CreateContact - contact created with ID: %s", resp.ID)
+	return ac.CustomResponse("Contact created successfully", resp, "", "", http.StatusCreated, nil)
+}
+
+// ListContacts godoc
+// @Summary      List contacts
+// @Description  List contacts with pagination, optionally filtered by status
+// @Tags         Contacts
+// @Produce      json
+// @Param        page    query     int     false  "Page number"
+// @Param        limit   query     int     false  "Page size"
+// @Param        status  query     string  false  "Contact status"  Enums(active, unsubscribed)
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.Contact,meta=dto.PaginationMeta}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contacts [get]
+func (h *contactHandler) ListContacts(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.ListContactsRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("ListContacts - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	contacts, meta, err := h.usecase.ListContacts(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("ListContacts - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Contacts retrieved successfully", contacts, "", "", http.StatusOK, meta)
+}
+
+// GetContact godoc
+// @Summary      Get contact details
+// @Description  Retrieve a contact by its ID
+// @Tags         Contacts
+// @Produce      json
+// @Param        id   path      string  true  "Contact ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Contact}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contact/{id} [get]
+func (h *contactHandler) GetContact(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	contactID := c.Param("id")
+	contactUUID, err := uuid.Parse(contactID)
+	if err != nil {
+		ac.AppLoger.Errorf("GetContact - invalid contact ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid contact ID", http.StatusBadRequest, nil)
+	}
+
+	contactDetails, err := h.usecase.GetContact(c, contactUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("GetContact - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Contact details retrieved successfully", contactDetails, "", "", http.StatusOK, nil)
+}
+
+// UpdateContact godoc
+// @Summary      Update a contact
+// @Description  Update the details of a contact
+// @Tags         Contacts
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Contact ID"
+// @Param        contact  body      dto.UpdateContactRequest  true  "Contact details to update"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Contact}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contact/{id} [put]
+func (h *contactHandler) UpdateContact(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	contactID := c.Param("id")
+	contactUUID, err := uuid.Parse(contactID)
+	if err != nil {
+		ac.AppLoger.Errorf("UpdateContact - invalid contact ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid contact ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.UpdateContactRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("UpdateContact - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	contactDetails, err := h.usecase.UpdateContact(c, contactUUID, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("UpdateContact - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	ac.AppLoger.Infof("UpdateContact - contact updated for ID: %s", contactID)
+	return ac.CustomResponse("Contact updated successfully", contactDetails, "", "", http.StatusOK, nil)
+}
+
+// DeleteContact godoc
+// @Summary      Delete a contact
+// @Description  Soft-delete a contact
+// @Tags         Contacts
+// @Produce      json
+// @Param        id   path      string  true  "Contact ID"
+// @Success      200  {object}  dto.ResponsePattern
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contact/{id} [delete]
+func (h *contactHandler) DeleteContact(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	contactID := c.Param("id")
+	contactUUID, err := uuid.Parse(contactID)
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteContact - invalid contact ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid contact ID", http.StatusBadRequest, nil)
+	}
+
+	if err := h.usecase.DeleteContact(c, contactUUID); err != nil {
+		ac.AppLoger.Errorf("DeleteContact - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Contact deleted successfully", nil, "", "", http.StatusOK, nil)
+}
+
+// UnsubscribeContact godoc
+// @Summary      Unsubscribe a contact
+// @Description  Mark a contact as unsubscribed
+// @Tags         Contacts
+// @Produce      json
+// @Param        id   path      string  true  "Contact ID"
+// @Success      200  {object}  dto.ResponsePattern
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contact/{id}/unsubscribe [post]
+func (h *contactHandler) UnsubscribeContact(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	contactID := c.Param("id")
+	contactUUID, err := uuid.Parse(contactID)
+	if err != nil {
+		ac.AppLoger.Errorf("UnsubscribeContact - invalid contact ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid contact ID", http.StatusBadRequest, nil)
+	}
+
+	if err := h.usecase.UnsubscribeContact(c, contactUUID); err != nil {
+		ac.AppLoger.Errorf("UnsubscribeContact - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Contact unsubscribed successfully", nil, "", "", http.StatusOK, nil)
+}
+
+// ImportContacts godoc
+// @Summary      Import contacts from CSV
+// @Description  Upload a CSV file of contacts. The mapping is a JSON object from contact field (email, first_name, last_name, company, phone, timezone) to CSV column, email is required. Rows are imported in the background, existing emails are skipped, and when a sequence is given the contacts are enrolled into it.
+// @Tags         Contacts
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file         formData  file    true   "CSV file with a header row"
+// @Param        mapping      formData  string  true   "Column mapping"  example({"email":"Email","first_name":"First Name"})
+// @Param        sequence_id  formData  string  false  "Sequence to enroll the contacts into"
+// @Success      202  {object}  dto.ResponsePattern{data=models.ContactImport}
+// @Failure      400  {object}  dto.ResponsePattern{data=[]dto.FieldError}
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      413  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contacts/imports [post]
+func (h *contactHandler) ImportContacts(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	maxFileSize := int64(ac.Config.GetImportConf().MaxFileSizeMB) << 20
+	if maxFileSize <= 0 {
+		maxFileSize = defaultMaxImportFileSize
+	}
+	tooLarge := fmt.Sprintf("CSV file is larger than %d MB", maxFileSize>>20)
+
+	// Cap the body before the multipart form is parsed, so an oversized upload is rejected while it is
+	// being read instead of after it has been buffered in memory or spooled to disk.
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, maxFileSize+maxImportFormOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ac.CustomResponse(http.StatusText(http.StatusRequestEntityTooLarge), nil, "", tooLarge, http.StatusRequestEntityTooLarge, nil)
+		}
+		ac.AppLoger.Errorf("ImportContacts - missing file: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "CSV file is required", http.StatusBadRequest, nil)
+	}
+	if fileHeader.Size > maxFileSize {
+		return ac.CustomResponse(http.StatusText(http.StatusRequestEntityTooLarge), nil, "", tooLarge, http.StatusRequestEntityTooLarge, nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ac.AppLoger.Errorf("ImportContacts - failed to open file: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		ac.AppLoger.Errorf("ImportContacts - failed to read file: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	reqPayload := &dto.ImportContactsRequest{
+		FileName: fileHeader.Filename,
+		Data:     data,
+	}
+	if err := json.Unmarshal([]byte(c.FormValue("mapping")), &reqPayload.Mapping); err != nil {
+		ac.AppLoger.Errorf("ImportContacts - invalid mapping: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid mapping, expected a JSON object of contact field to CSV column", http.StatusBadRequest, nil)
+	}
+
+	if sequenceID := c.FormValue("sequence_id"); sequenceID != "" {
+		sequenceUUID, err := uuid.Parse(sequenceID)
+		if err != nil {
+			ac.AppLoger.Errorf("ImportContacts - invalid sequence ID: %v", err)
+			return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+		}
+		reqPayload.SequenceID = &sequenceUUID
+	}
+
+	contactImport, err := h.usecase.ImportContacts(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("ImportContacts - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Contact import queued successfully", contactImport, "", "", http.StatusAccepted, nil)
+}
+
+// GetContactImport godoc
+// @Summary      Get a contact import
+// @Description  Retrieve the progress of a contact import with the rows that were skipped or failed and why
+// @Tags         Contacts
+// @Produce      json
+// @Param        id   path      string  true  "Import ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.ContactImport}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contacts/imports/{id} [get]
+func (h *contactHandler) GetContactImport(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	importID := c.Param("id")
+	importUUID, err := uuid.Parse(importID)
+	if err != nil {
+		ac.AppLoger.Errorf("GetContactImport - invalid import ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid import ID", http.StatusBadRequest, nil)
+	}
+
+	contactImport, err := h.usecase.GetContactImport(c, importUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("GetContactImport - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Contact import retrieved successfully", contactImport, "", "", http.StatusOK, nil)
+}
+
+// ExportContacts godoc
+// @Summary      Export contacts
+// @Description  Stream contacts as CSV or NDJSON with their enrollments (sequence_id, current_step, status, started_at, completed_at). A contact is exported once per enrollment. Filtering by sequence or enrollment status exports only the contacts with a matching enrollment.
+// @Tags         Contacts
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format       query     string    false  "Export format"  Enums(csv, ndjson)  default(csv)
+// @Param        sequence_id  query     string    false  "Only enrollments of this sequence"
+// @Param        status       query     []string  false  "Enrollment statuses"  collectionFormat(multi)  Enums(pending, in_progress, completed, paused, bounced, cancelled)
+// @Success      200  {array}   dto.ContactExportRow
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /contacts/export [get]
+func (h *contactHandler) ExportContacts(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.ExportContactsRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("ExportContacts - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+	if reqPayload.Format == "" {
+		reqPayload.Format = dto.ExportFormatCSV
+	}
+
+	w := &exportWriter{
+		response:    c.Response(),
+		contentType: "text/csv; charset=utf-8",
+		fileName:    fmt.Sprintf("contacts-%s.%s", time.Now().UTC().Format("20060102-150405"), reqPayload.Format),
+	}
+	if reqPayload.Format == dto.ExportFormatNDJSON {
+		w.contentType = "application/x-ndjson"
+	}
+
+	if err := h.usecase.ExportContacts(c, reqPayload, w); err != nil {
+		ac.AppLoger.Errorf("ExportContacts - usecase error: %v", err)
+		if c.Response().Committed {
+			// The rows sent so far cannot be taken back, the client sees a truncated file.
+			return nil
+		}
+		return ac.CustomErrorResponse(err)
+	}
+	return nil
+}
+
+// exportWriter sends the export headers with the first write, so errors raised before any row is
+// written are still answered with a JSON error response.
+type exportWriter struct {
+	response    *echo.Response
+	contentType string
+	fileName    string
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.response.Committed {
+		w.response.Header().Set(echo.HeaderContentType, w.contentType)
+		w.response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", w.fileName))
+		w.response.WriteHeader(http.StatusOK)
+	}
+	return w.response.Write(p)
+}
+
+func (w *exportWriter) Flush() {
+	w.response.Flush()
+}
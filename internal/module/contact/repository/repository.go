@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/contact"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/suppression"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/unenroll"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type contactRepository struct {
+	db *gorm.DB
+}
+
+func NewContactRepository(db *gorm.DB) contact.Repository {
+	return &contactRepository{
+		db: db,
+	}
+}
+
+func (r *contactRepository) CreateContact(tx *gorm.DB, contact *models.Contact) (*models.Contact, error) {
+	if err := tx.Create(contact).Error; err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+func (r *contactRepository) GetContact(contactID uuid.UUID) (*models.Contact, error) {
+	var contact models.Contact
+	if err := r.db.First(&contact, "id = ?", contactID).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *contactRepository) GetContactByEmail(email string) (*models.Contact, error) {
+	var contact models.Contact
+	if err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *contactRepository) ListContacts(status string, offset, limit int) ([]models.Contact, int64, error) {
+	query := r.db.Model(&models.Contact{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var contacts []models.Contact
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&contacts).Error; err != nil {
+		return nil, 0, err
+	}
+	return contacts, total, nil
+}
+
+func (r *contactRepository) UpdateContact(tx *gorm.DB, contact *models.Contact) error {
+	return tx.Save(contact).Error
+}
+
+func (r *contactRepository) DeleteContact(tx *gorm.DB, contactID uuid.UUID) error {
+	return tx.Delete(&models.Contact{}, "id = ?", contactID).Error
+}
+
+// CancelContactEnrollments cancels the enrollments of a contact that did not finish yet and returns how
+// many were cancelled.
+func (r *contactRepository) CancelContactEnrollments(tx *gorm.DB, contactID uuid.UUID) (int64, error) {
+	return unenroll.Contact(tx, contactID)
+}
+
+// CancelContactEmails cancels the scheduled and queued emails of a contact and gives back the mailbox
+// capacity reserved for them. It returns how many emails were cancelled.
+func (r *contactRepository) CancelContactEmails(tx *gorm.DB, contactID uuid.UUID, errorMessage string) (int64, error) {
+	return unenroll.ContactEmails(tx, contactID, errorMessage)
+}
+
+// GetContactsByEmails returns the contacts whose lowercase email is one of emails.
+func (r *contactRepository) GetContactsByEmails(tx *gorm.DB, emails []string) ([]models.Contact, error) {
+	var contacts []models.Contact
+	if err := tx.Where("LOWER(email) IN ?", emails).Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+func (r *contactRepository) CreateContacts(tx *gorm.DB, contacts []models.Contact) error {
+	return tx.Create(&contacts).Error
+}
+
+// ExportContacts calls fn for every contact and enrollment row, reading them from a cursor so the
+// export is never held in memory. Iteration stops at the first error returned by fn.
+func (r *contactRepository) ExportContacts(db *gorm.DB, sequenceID *uuid.UUID, statuses []string, fn func(row *dto.ContactExportRow) error) error {
+	query := db.Table("contacts c").
+		Select(`c.id AS contact_id, c.email, c.first_name, c.last_name, c.company, c.phone, c.timezone,
+			c.status AS contact_status, sc.sequence_id, sc.current_step, sc.status, sc.started_at, sc.completed_at`).
+		Where("c.deleted_at IS NULL")
+
+	switch {
+	case sequenceID != nil:
+		query = query.Joins("JOIN sequence_contacts sc ON sc.contact_id = c.id AND sc.sequence_id = ?", *sequenceID)
+	case len(statuses) > 0:
+		query = query.Joins("JOIN sequence_contacts sc ON sc.contact_id = c.id")
+	default:
+		query = query.Joins("LEFT JOIN sequence_contacts sc ON sc.contact_id = c.id")
+	}
+	if len(statuses) > 0 {
+		query = query.Where("sc.status IN ?", statuses)
+	}
+
+	rows, err := query.Order("c.created_at, c.id, sc.created_at").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dto.ContactExportRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *contactRepository) CreateContactImport(tx *gorm.DB, contactImport *models.ContactImport) error {
+	return tx.Create(contactImport).Error
+}
+
+func (r *contactRepository) GetContactImport(importID uuid.UUID) (*models.ContactImport, error) {
+	var contactImport models.ContactImport
+	if err := r.db.Omit("data").First(&contactImport, "id = ?", importID).Error; err != nil {
+		return nil, err
+	}
+	return &contactImport, nil
+}
+
+// ClaimContactImport locks the oldest pending import, or an import whose worker stopped reporting
+// progress before staleBefore. Imports locked by another worker are skipped.
+func (r *contactRepository) ClaimContactImport(tx *gorm.DB, staleBefore time.Time) (*models.ContactImport, error) {
+	var contactImport models.ContactImport
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? OR (status = ? AND updated_at < ?)",
+			models.ContactImportStatusPending, models.ContactImportStatusProcessing, staleBefore).
+		Order("created_at").
+		First(&contactImport).Error; err != nil {
+		return nil, err
+	}
+	return &contactImport, nil
+}
+
+// UpdateContactImport saves the progress of an import. The uploaded file is never rewritten.
+func (r *contactRepository) UpdateContactImport(tx *gorm.DB, contactImport *models.ContactImport) error {
+	return tx.Omit("data").Save(contactImport).Error
+}
+
+func (r *contactRepository) GetSequence(tx *gorm.DB, sequenceID uuid.UUID) (*models.Sequence, error) {
+	var sequence models.Sequence
+	if err := tx.First(&sequence, "id = ?", sequenceID).Error; err != nil {
+		return nil, err
+	}
+	return &sequence, nil
+}
+
+func (r *contactRepository) GetLatestVersion(tx *gorm.DB, sequenceID uuid.UUID) (*models.SequenceVersion, error) {
+	var version models.SequenceVersion
+	if err := tx.Where("sequence_id = ?", sequenceID).Order("version DESC").First(&version).Error; err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// EnrollContacts creates the enrollments and returns how many were created. Contacts already enrolled
+// in the sequence are left as they are.
+func (r *contactRepository) EnrollContacts(tx *gorm.DB, sequenceContacts []models.SequenceContact) (int64, error) {
+	result := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sequence_id"}, {Name: "contact_id"}},
+		DoNothing: true,
+	}).Omit("Contact").Create(&sequenceContacts)
+	return result.RowsAffected, result.Error
+}
+
+// CreateSuppression adds a suppression unless the value is already suppressed.
+func (r *contactRepository) CreateSuppression(tx *gorm.DB, suppression *models.Suppression) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "type"}, {Name: "value"}},
+		DoNothing: true,
+	}).Create(suppression).Error
+}
+
+// GetSuppressedContactIDs returns the contacts whose email, or the domain of it, is on the suppression list.
+func (r *contactRepository) GetSuppressedContactIDs(tx *gorm.DB, contactIDs []uuid.UUID) ([]uuid.UUID, error) {
+	return suppression.ContactIDs(tx, contactIDs)
+}
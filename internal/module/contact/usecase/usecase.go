@@ -0,0 +1,913 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/contact"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/suppression"
+	"gorm.io/gorm"
+)
+
+const (
+	// importBatchSize is the number of CSV rows imported per transaction.
+	importBatchSize = 500
+	// maxImportIssues bounds the skipped and failed rows reported on an import, the counters keep counting.
+	maxImportIssues = 1000
+	// staleImportAfter is how long an import may go without progress before another worker resumes it.
+	staleImportAfter = 10 * time.Minute
+	// exportFlushRows is the number of exported rows buffered before they are flushed to the client.
+	exportFlushRows = 500
+)
+
+// importFields are the contact fields a CSV column can be mapped to.
+var importFields = []string{"email", "first_name", "last_name", "company", "phone", "timezone"}
+
+var log = logger.NewLogger("CONTACT")
+
+type contactUsecase struct {
+	repository contact.Repository
+	db         *gorm.DB
+	validate   *validator.Validate
+}
+
+func NewContactUsecase(repository contact.Repository, db *gorm.DB) contact.Usecase {
+	validate := validator.New()
+	// Report row errors with the contact field names used in the mapping.
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("json"), ",")[0]
+	})
+
+	return &contactUsecase{
+		repository: repository,
+		db:         db,
+		validate:   validate,
+	}
+}
+
+func (u *contactUsecase) CreateContact(c echo.Context, req *dto.CreateContactRequest) (*dto.CreateContactResponse, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	email := normalizeEmail(req.Email)
+
+	if err := u.ensureEmailAvailable(email, uuid.Nil); err != nil {
+		ac.AppLoger.Errorf("CreateContact - %v", err)
+		return nil, err
+	}
+
+	contactData := &models.Contact{
+		Email:     email,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Company:   req.Company,
+		Phone:     req.Phone,
+		Timezone:  req.Timezone,
+		Status:    models.ContactStatusActive,
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	resp, err := u.repository.CreateContact(tx, contactData)
+	if err != nil {
+		ac.AppLoger.Errorf("CreateContact - failed to create contact: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("CreateContact - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	return &dto.CreateContactResponse{
+		ID: resp.ID.String(),
+	}, nil
+}
+
+func (u *contactUsecase) GetContact(c echo.Context, contactID uuid.UUID) (*models.Contact, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	contactData, err := u.repository.GetContact(contactID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Error("GetContact - contact not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Contact not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("GetContact - failed to fetch contact: %v", err)
+		return nil, err
+	}
+	return contactData, nil
+}
+
+func (u *contactUsecase) ListContacts(c echo.Context, req *dto.ListContactsRequest) ([]models.Contact, *dto.PaginationMeta, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	page, limit := req.Page, req.Limit
+	if page == 0 {
+		page = dto.DefaultPage
+	}
+	if limit == 0 {
+		limit = dto.DefaultLimit
+	}
+
+	contacts, total, err := u.repository.ListContacts(req.Status, (page-1)*limit, limit)
+	if err != nil {
+		ac.AppLoger.Errorf("ListContacts - failed to list contacts: %v", err)
+		return nil, nil, err
+	}
+
+	return contacts, dto.NewPaginationMeta(page, limit, total), nil
+}
+
+func (u *contactUsecase) UpdateContact(c echo.Context, contactID uuid.UUID, req *dto.UpdateContactRequest) (*models.Contact, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	existingContact, err := u.GetContact(c, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Email != nil {
+		email := normalizeEmail(*req.Email)
+		if err := u.ensureEmailAvailable(email, contactID); err != nil {
+			ac.AppLoger.Errorf("UpdateContact - %v", err)
+			return nil, err
+		}
+		existingContact.Email = email
+	}
+
+	if req.FirstName != nil {
+		existingContact.FirstName = *req.FirstName
+	}
+
+	if req.LastName != nil {
+		existingContact.LastName = *req.LastName
+	}
+
+	if req.Company != nil {
+		existingContact.Company = *req.Company
+	}
+
+	if req.Phone != nil {
+		existingContact.Phone = *req.Phone
+	}
+
+	if req.Timezone != nil {
+		existingContact.Timezone = *req.Timezone
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	if err := u.repository.UpdateContact(tx, existingContact); err != nil {
+		ac.AppLoger.Errorf("UpdateContact - failed to update contact: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("UpdateContact - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	return existingContact, nil
+}
+
+func (u *contactUsecase) DeleteContact(c echo.Context, contactID uuid.UUID) error {
+	ac := c.(*ctx.CustomApplicationContext)
+	if _, err := u.GetContact(c, contactID); err != nil {
+		return err
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	// The scheduler still loads soft deleted contacts, so nothing may be left for it to send.
+	cancelledEmails, err := u.repository.CancelContactEmails(tx, contactID, "contact deleted")
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteContact - failed to cancel pending emails: %v", err)
+		return err
+	}
+
+	cancelledEnrollments, err := u.repository.CancelContactEnrollments(tx, contactID)
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteContact - failed to cancel enrollments: %v", err)
+		return err
+	}
+
+	if err := u.repository.DeleteContact(tx, contactID); err != nil {
+		ac.AppLoger.Errorf("DeleteContact - failed to delete contact: %v", err)
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("DeleteContact - failed to commit transaction: %v", err)
+		return err
+	}
+	ac.AppLoger.Infof("DeleteContact - contact %s deleted, cancelled %d enrollments and %d emails", contactID.String(), cancelledEnrollments, cancelledEmails)
+
+	return nil
+}
+
+func (u *contactUsecase) UnsubscribeContact(c echo.Context, contactID uuid.UUID) error {
+	ac := c.(*ctx.CustomApplicationContext)
+	existingContact, err := u.GetContact(c, contactID)
+	if err != nil {
+		return err
+	}
+
+	if existingContact.Status == models.ContactStatusUnsubscribed {
+		return nil
+	}
+	existingContact.Status = models.ContactStatusUnsubscribed
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	if err := u.repository.UpdateContact(tx, existingContact); err != nil {
+		ac.AppLoger.Errorf("UnsubscribeContact - failed to update contact: %v", err)
+		return err
+	}
+
+	suppressed := &models.Suppression{
+		Type:   models.SuppressionTypeEmail,
+		Value:  normalizeEmail(existingContact.Email),
+		Reason: models.SuppressionReasonUnsubscribed,
+	}
+	if err := u.repository.CreateSuppression(tx, suppressed); err != nil {
+		ac.AppLoger.Errorf("UnsubscribeContact - failed to suppress email: %v", err)
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("UnsubscribeContact - failed to commit transaction: %v", err)
+		return err
+	}
+	ac.AppLoger.Infof("UnsubscribeContact - contact unsubscribed for ID: %s", contactID.String())
+
+	return nil
+}
+
+// ImportContacts checks the CSV header against the mapping and queues the import. Rows are imported
+// in the background, the returned job reports their progress.
+func (u *contactUsecase) ImportContacts(c echo.Context, req *dto.ImportContactsRequest) (*models.ContactImport, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	records, err := newCSVReader(req.Data).ReadAll()
+	if err != nil {
+		ac.AppLoger.Errorf("ImportContacts - invalid CSV file: %v", err)
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid CSV file: "+err.Error())
+	}
+	if len(records) == 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "CSV file is empty")
+	}
+
+	if errs := validateMapping(records[0], req.Mapping); len(errs) > 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, errs)
+	}
+
+	if req.SequenceID != nil {
+		sequence, err := u.repository.GetSequence(ac.Postgres, *req.SequenceID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+		}
+		if err != nil {
+			ac.AppLoger.Errorf("ImportContacts - failed to fetch sequence: %v", err)
+			return nil, err
+		}
+		if sequence.Status == models.SequenceStatusArchived {
+			return nil, echo.NewHTTPError(http.StatusConflict, "Sequence is archived")
+		}
+	}
+
+	contactImport := &models.ContactImport{
+		FileName:   req.FileName,
+		Mapping:    models.ImportMapping(req.Mapping),
+		SequenceID: req.SequenceID,
+		Status:     models.ContactImportStatusPending,
+		TotalRows:  len(records) - 1,
+		Data:       req.Data,
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	if err := u.repository.CreateContactImport(tx, contactImport); err != nil {
+		ac.AppLoger.Errorf("ImportContacts - failed to create import: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("ImportContacts - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("ImportContacts - import %s queued with %d rows", contactImport.ID, contactImport.TotalRows)
+
+	return contactImport, nil
+}
+
+func (u *contactUsecase) GetContactImport(c echo.Context, importID uuid.UUID) (*models.ContactImport, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	contactImport, err := u.repository.GetContactImport(importID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Error("GetContactImport - import not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Import not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("GetContactImport - failed to fetch import: %v", err)
+		return nil, err
+	}
+	return contactImport, nil
+}
+
+// ProcessContactImport claims the next queued import and imports its rows in batches, committing the
+// progress after each batch. It returns nil when no import is waiting.
+func (u *contactUsecase) ProcessContactImport(ctx context.Context) (*models.ContactImport, error) {
+	contactImport, err := u.claimImport(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("ProcessContactImport - failed to claim import: %v", err)
+		return nil, err
+	}
+
+	err = u.runImport(ctx, contactImport)
+	if ctx.Err() != nil {
+		// Shutting down, the import is resumed once it goes stale.
+		return contactImport, ctx.Err()
+	}
+	if err != nil {
+		log.Errorf("ProcessContactImport - import %s failed: %v", contactImport.ID, err)
+		if err := u.finishImport(ctx, contactImport, err); err != nil {
+			log.Errorf("ProcessContactImport - failed to mark import %s as failed: %v", contactImport.ID, err)
+			return nil, err
+		}
+		return contactImport, nil
+	}
+
+	if err := u.finishImport(ctx, contactImport, nil); err != nil {
+		log.Errorf("ProcessContactImport - failed to complete import %s: %v", contactImport.ID, err)
+		return nil, err
+	}
+	log.Infof("ProcessContactImport - import %s completed, imported: %d, skipped: %d, failed: %d, enrolled: %d",
+		contactImport.ID, contactImport.Imported, contactImport.Skipped, contactImport.Failed, contactImport.Enrolled)
+	return contactImport, nil
+}
+
+func (u *contactUsecase) claimImport(ctx context.Context) (*models.ContactImport, error) {
+	now := time.Now()
+
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	contactImport, err := u.repository.ClaimContactImport(tx, now.Add(-staleImportAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	contactImport.Status = models.ContactImportStatusProcessing
+	if contactImport.StartedAt == nil {
+		contactImport.StartedAt = &now
+	}
+	if err := u.repository.UpdateContactImport(tx, contactImport); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return contactImport, nil
+}
+
+// runImport imports the rows that were not processed yet. An import claimed again after its worker
+// stopped picks up after the last committed batch.
+func (u *contactUsecase) runImport(ctx context.Context, contactImport *models.ContactImport) error {
+	reader := newCSVReader(contactImport.Data)
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns, err := mappingColumns(header, contactImport.Mapping)
+	if err != nil {
+		return err
+	}
+
+	version, err := u.enrollmentVersion(ctx, contactImport.SequenceID)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < contactImport.ProcessedRows; i++ {
+		if _, err := reader.Read(); err != nil {
+			return fmt.Errorf("failed to skip processed rows: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rows := make([]importRow, 0, importBatchSize)
+		for len(rows) < importBatchSize {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read CSV row: %w", err)
+			}
+			line, _ := reader.FieldPos(0)
+			rows = append(rows, importRow{line: line, record: record})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		if err := u.importBatch(ctx, contactImport, columns, version, rows, seen); err != nil {
+			return err
+		}
+	}
+}
+
+// enrollmentVersion returns the version imported contacts are enrolled on, or nil when the import
+// does not enroll them.
+func (u *contactUsecase) enrollmentVersion(ctx context.Context, sequenceID *uuid.UUID) (*models.SequenceVersion, error) {
+	if sequenceID == nil {
+		return nil, nil
+	}
+
+	db := u.db.WithContext(ctx)
+	sequence, err := u.repository.GetSequence(db, *sequenceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("sequence not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sequence.Status == models.SequenceStatusArchived {
+		return nil, errors.New("sequence is archived")
+	}
+
+	return u.repository.GetLatestVersion(db, *sequenceID)
+}
+
+type importRow struct {
+	line   int
+	record []string
+}
+
+// importBatch creates the contacts of a batch of rows and enrolls them along with the existing contacts
+// the rows matched, leaving out suppressed contacts. Emails are compared in lowercase, against existing
+// contacts and earlier rows.
+func (u *contactUsecase) importBatch(ctx context.Context, contactImport *models.ContactImport, columns map[string]int, version *models.SequenceVersion, rows []importRow, seen map[string]bool) error {
+	var candidates []models.Contact
+	var candidateRows []int
+	for _, row := range rows {
+		req := rowContact(row.record, columns)
+		if err := u.validate.Struct(req); err != nil {
+			addImportIssue(contactImport, row.line, req.Email, models.ImportRowOutcomeFailed, validationReason(err))
+			continue
+		}
+		if seen[req.Email] {
+			addImportIssue(contactImport, row.line, req.Email, models.ImportRowOutcomeSkipped, "duplicate email in file")
+			continue
+		}
+		seen[req.Email] = true
+
+		candidates = append(candidates, models.Contact{
+			Email:     req.Email,
+			FirstName: req.FirstName,
+			LastName:  req.LastName,
+			Company:   req.Company,
+			Phone:     req.Phone,
+			Timezone:  req.Timezone,
+			Status:    models.ContactStatusActive,
+		})
+		candidateRows = append(candidateRows, row.line)
+	}
+
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	existing := make(map[string]models.Contact)
+	if len(candidates) > 0 {
+		emails := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			emails[i] = candidate.Email
+		}
+		contacts, err := u.repository.GetContactsByEmails(tx, emails)
+		if err != nil {
+			return fmt.Errorf("failed to fetch existing contacts: %w", err)
+		}
+		for _, contact := range contacts {
+			existing[normalizeEmail(contact.Email)] = contact
+		}
+	}
+
+	var newContacts []models.Contact
+	var enrollIDs []uuid.UUID
+	for i, candidate := range candidates {
+		contact, ok := existing[candidate.Email]
+		if !ok {
+			newContacts = append(newContacts, candidate)
+			continue
+		}
+		addImportIssue(contactImport, candidateRows[i], candidate.Email, models.ImportRowOutcomeSkipped, "contact already exists")
+		if contact.Status == models.ContactStatusActive {
+			enrollIDs = append(enrollIDs, contact.ID)
+		}
+	}
+
+	if len(newContacts) > 0 {
+		if err := u.repository.CreateContacts(tx, newContacts); err != nil {
+			return fmt.Errorf("failed to create contacts: %w", err)
+		}
+		for _, contact := range newContacts {
+			enrollIDs = append(enrollIDs, contact.ID)
+		}
+	}
+
+	if version != nil && len(enrollIDs) > 0 {
+		suppressedIDs, err := u.repository.GetSuppressedContactIDs(tx, enrollIDs)
+		if err != nil {
+			return fmt.Errorf("failed to fetch suppressed contacts: %w", err)
+		}
+		enrollIDs = suppression.Without(enrollIDs, suppressedIDs)
+	}
+
+	if version != nil && len(enrollIDs) > 0 {
+		enrolled, err := u.repository.EnrollContacts(tx, importEnrollments(version, enrollIDs))
+		if err != nil {
+			return fmt.Errorf("failed to enroll contacts: %w", err)
+		}
+		contactImport.Enrolled += int(enrolled)
+	}
+
+	contactImport.Imported += len(newContacts)
+	contactImport.ProcessedRows += len(rows)
+	if err := u.repository.UpdateContactImport(tx, contactImport); err != nil {
+		return fmt.Errorf("failed to save import progress: %w", err)
+	}
+
+	return tx.Commit().Error
+}
+
+// finishImport marks the import completed, or failed with importErr.
+func (u *contactUsecase) finishImport(ctx context.Context, contactImport *models.ContactImport, importErr error) error {
+	now := time.Now()
+	contactImport.Status = models.ContactImportStatusCompleted
+	if importErr != nil {
+		contactImport.Status = models.ContactImportStatusFailed
+		contactImport.Error = importErr.Error()
+	}
+	contactImport.CompletedAt = &now
+
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.repository.UpdateContactImport(tx, contactImport); err != nil {
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// importEnrollments starts the version for the contacts the same way EnrollContacts does.
+func importEnrollments(version *models.SequenceVersion, contactIDs []uuid.UUID) []models.SequenceContact {
+	now := time.Now()
+	nextSendAt := now
+	if len(version.Steps) > 0 {
+		nextSendAt = version.Steps[0].SendAfter(now)
+	}
+
+	sequenceContacts := make([]models.SequenceContact, len(contactIDs))
+	for i, contactID := range contactIDs {
+		sequenceContacts[i] = models.SequenceContact{
+			SequenceID: version.SequenceID,
+			ContactID:  contactID,
+			Version:    version.Version,
+			NextSendAt: &nextSendAt,
+			Status:     models.SequenceContactStatusInProgress,
+			StartedAt:  &now,
+		}
+	}
+	return sequenceContacts
+}
+
+func addImportIssue(contactImport *models.ContactImport, row int, email string, outcome models.ImportRowOutcome, reason string) {
+	switch outcome {
+	case models.ImportRowOutcomeSkipped:
+		contactImport.Skipped++
+	case models.ImportRowOutcomeFailed:
+		contactImport.Failed++
+	}
+	if len(contactImport.Issues) < maxImportIssues {
+		contactImport.Issues = append(contactImport.Issues, models.ImportIssue{
+			Row:     row,
+			Email:   email,
+			Outcome: outcome,
+			Reason:  reason,
+		})
+	}
+}
+
+// rowContact reads the mapped columns of a row. Rows shorter than the header leave the missing fields empty.
+func rowContact(record []string, columns map[string]int) *dto.CreateContactRequest {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	return &dto.CreateContactRequest{
+		Email:     normalizeEmail(value("email")),
+		FirstName: value("first_name"),
+		LastName:  value("last_name"),
+		Company:   value("company"),
+		Phone:     value("phone"),
+		Timezone:  value("timezone"),
+	}
+}
+
+func validationReason(err error) string {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err.Error()
+	}
+
+	reasons := make([]string, len(validationErrs))
+	for i, fieldErr := range validationErrs {
+		switch fieldErr.Tag() {
+		case "required":
+			reasons[i] = fieldErr.Field() + " is required"
+		case "max":
+			reasons[i] = fmt.Sprintf("%s is longer than %s characters", fieldErr.Field(), fieldErr.Param())
+		default:
+			reasons[i] = fieldErr.Field() + " is not a valid " + fieldErr.Tag()
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// validateMapping checks that every mapped field is a contact field read from a column of the header.
+func validateMapping(header []string, mapping map[string]string) dto.FieldErrors {
+	var errs dto.FieldErrors
+	if _, ok := mapping["email"]; !ok {
+		errs = append(errs, dto.FieldError{Field: "mapping.email", Message: "is required"})
+	}
+
+	fields := make([]string, 0, len(mapping))
+	for field := range mapping {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if !isImportField(field) {
+			errs = append(errs, dto.FieldError{Field: "mapping." + field, Message: "is not a contact field, use one of " + strings.Join(importFields, ", ")})
+			continue
+		}
+		if columnIndex(header, mapping[field]) < 0 {
+			errs = append(errs, dto.FieldError{Field: "mapping." + field, Message: fmt.Sprintf("column %q is not in the CSV header", mapping[field])})
+		}
+	}
+	return errs
+}
+
+// mappingColumns resolves the mapped columns to their index in the header.
+func mappingColumns(header []string, mapping models.ImportMapping) (map[string]int, error) {
+	if errs := validateMapping(header, mapping); len(errs) > 0 {
+		return nil, errs
+	}
+
+	columns := make(map[string]int, len(mapping))
+	for field, column := range mapping {
+		columns[field] = columnIndex(header, column)
+	}
+	return columns, nil
+}
+
+// columnIndex finds a column of the header, ignoring case and surrounding spaces.
+func columnIndex(header []string, column string) int {
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(column)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isImportField(field string) bool {
+	for _, importField := range importFields {
+		if field == importField {
+			return true
+		}
+	}
+	return false
+}
+
+// newCSVReader reads an uploaded CSV file. Spreadsheet exports often start with a byte order mark and
+// leave out trailing empty cells, neither is an error.
+func newCSVReader(data []byte) *csv.Reader {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+func (u *contactUsecase) ExportContacts(c echo.Context, req *dto.ExportContactsRequest, w io.Writer) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	if req.SequenceID != nil {
+		_, err := u.repository.GetSequence(ac.Postgres, *req.SequenceID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+		}
+		if err != nil {
+			ac.AppLoger.Errorf("ExportContacts - failed to fetch sequence: %v", err)
+			return err
+		}
+	}
+
+	encoder := newExportEncoder(req.Format, w)
+	rows := 0
+	err := u.repository.ExportContacts(ac.Postgres, req.SequenceID, req.Status, func(row *dto.ContactExportRow) error {
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushRows == 0 {
+			return encoder.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		ac.AppLoger.Errorf("ExportContacts - export failed after %d rows: %v", rows, err)
+		return err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		ac.AppLoger.Errorf("ExportContacts - failed to flush export: %v", err)
+		return err
+	}
+	ac.AppLoger.Infof("ExportContacts - exported %d rows", rows)
+
+	return nil
+}
+
+// exportEncoder writes export rows to an output that may be flushed to the client while the export
+// is still being read.
+type exportEncoder interface {
+	Encode(row *dto.ContactExportRow) error
+	Flush() error
+}
+
+func newExportEncoder(format string, w io.Writer) exportEncoder {
+	if format == dto.ExportFormatNDJSON {
+		buf := bufio.NewWriter(w)
+		return &ndjsonEncoder{out: w, buf: buf, encoder: json.NewEncoder(buf)}
+	}
+	return &csvEncoder{out: w, writer: csv.NewWriter(w)}
+}
+
+var exportColumns = []string{
+	"contact_id", "email", "first_name", "last_name", "company", "phone", "timezone", "contact_status",
+	"sequence_id", "current_step", "status", "started_at", "completed_at",
+}
+
+type csvEncoder struct {
+	out           io.Writer
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvEncoder) Encode(row *dto.ContactExportRow) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	record := []string{
+		row.ContactID.String(), row.Email, row.FirstName, row.LastName, row.Company, row.Phone, row.Timezone, row.ContactStatus,
+		"", "", "", formatExportTime(row.StartedAt), formatExportTime(row.CompletedAt),
+	}
+	if row.SequenceID != nil {
+		record[8] = row.SequenceID.String()
+	}
+	if row.CurrentStep != nil {
+		record[9] = strconv.Itoa(*row.CurrentStep)
+	}
+	if row.Status != nil {
+		record[10] = *row.Status
+	}
+	for i := range record {
+		record[i] = escapeFormula(record[i])
+	}
+	return e.writer.Write(record)
+}
+
+// numericCellPattern matches plain numbers and phone numbers such as "-42", "+14155550100" or
+// "+1 (555) 010-0100", which spreadsheets cannot evaluate as anything but a number.
+var numericCellPattern = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$|^\+[0-9][0-9 ().-]*$`)
+
+// escapeFormula prefixes cells that spreadsheets would evaluate as a formula with a quote, so contact
+// data such as a first name of "=HYPERLINK(...)" is shown as text when the export is opened. Numbers and
+// phone numbers are left as they are so they round-trip through an import.
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) && !numericCellPattern.MatchString(cell) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// Flush writes the header even when nothing was exported.
+func (e *csvEncoder) Flush() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return err
+	}
+	flushOutput(e.out)
+	return nil
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.writer.Write(exportColumns)
+}
+
+type ndjsonEncoder struct {
+	out     io.Writer
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(row *dto.ContactExportRow) error {
+	return e.encoder.Encode(row)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	flushOutput(e.out)
+	return nil
+}
+
+// flushOutput sends the written rows to the client when the output is a streamed response.
+func flushOutput(w io.Writer) {
+	if flusher, ok := w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ensureEmailAvailable returns a 409 error when another contact already uses email.
+func (u *contactUsecase) ensureEmailAvailable(email string, contactID uuid.UUID) error {
+	existingContact, err := u.repository.GetContactByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existingContact.ID != contactID {
+		return echo.NewHTTPError(http.StatusConflict, "Contact with this email already exists")
+	}
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
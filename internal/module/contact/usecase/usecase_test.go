@@ -0,0 +1,774 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	mock_contact "github.com/rohanchauhan02/sequence-service/files/mocks/contact"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockCtx(db *gorm.DB) echo.Context {
+	e := echo.New()
+	return &ctx.CustomApplicationContext{
+		Context:  e.NewContext(nil, nil),
+		Postgres: db,
+		AppLoger: logger.NewLogger(),
+	}
+}
+
+func Test_CreateContact(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_contact.NewMockRepository(ctrl)
+	u := NewContactUsecase(mockRepo, gormDB)
+
+	tests := []struct {
+		name       string
+		req        *dto.CreateContactRequest
+		setupMocks func()
+		expectTx   bool
+		wantCode   int
+		wantErr    bool
+	}{
+		{
+			name: "success - create contact with normalized email",
+			req:  &dto.CreateContactRequest{Email: " Lead@Example.com ", FirstName: "Jane"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetContactByEmail("lead@example.com").
+					Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().
+					CreateContact(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, contact *models.Contact) (*models.Contact, error) {
+						if contact.Email != "lead@example.com" || contact.Status != models.ContactStatusActive {
+							t.Errorf("unexpected contact: %+v", contact)
+						}
+						contact.ID = uuid.New()
+						return contact, nil
+					})
+			},
+			expectTx: true,
+		},
+		{
+			name: "error - email already exists",
+			req:  &dto.CreateContactRequest{Email: "lead@example.com"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetContactByEmail("lead@example.com").
+					Return(&models.Contact{ID: uuid.New()}, nil)
+			},
+			wantCode: http.StatusConflict,
+			wantErr:  true,
+		},
+		{
+			name: "error - repository fails to create contact",
+			req:  &dto.CreateContactRequest{Email: "lead@example.com"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetContactByEmail("lead@example.com").
+					Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().
+					CreateContact(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
+			},
+			expectTx: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if !tt.wantErr {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			_, err := u.CreateContact(c, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateContact() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("CreateContact() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_UnsubscribeContact(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_contact.NewMockRepository(ctrl)
+	u := NewContactUsecase(mockRepo, gormDB)
+
+	contactID := uuid.New()
+
+	t.Run("success - status flips to unsubscribed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		mockRepo.EXPECT().
+			GetContact(contactID).
+			Return(&models.Contact{ID: contactID, Email: "Lead@Example.com", Status: models.ContactStatusActive}, nil)
+		mockRepo.EXPECT().
+			UpdateContact(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gorm.DB, contact *models.Contact) error {
+				if contact.Status != models.ContactStatusUnsubscribed {
+					t.Errorf("expected unsubscribed contact, got: %+v", contact)
+				}
+				return nil
+			})
+		mockRepo.EXPECT().
+			CreateSuppression(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gorm.DB, suppression *models.Suppression) error {
+				if suppression.Type != models.SuppressionTypeEmail || suppression.Value != "lead@example.com" || suppression.Reason != models.SuppressionReasonUnsubscribed {
+					t.Errorf("unexpected suppression: %+v", suppression)
+				}
+				return nil
+			})
+
+		if err := u.UnsubscribeContact(newMockCtx(gormDB), contactID); err != nil {
+			t.Errorf("UnsubscribeContact() unexpected error: %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet SQL expectations: %v", err)
+		}
+	})
+
+	t.Run("error - contact not found", func(t *testing.T) {
+		mockRepo.EXPECT().
+			GetContact(contactID).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		err := u.UnsubscribeContact(newMockCtx(gormDB), contactID)
+		var httpErr *echo.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusNotFound {
+			t.Errorf("UnsubscribeContact() error = %v, want status %d", err, http.StatusNotFound)
+		}
+	})
+}
+
+func Test_DeleteContact(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_contact.NewMockRepository(ctrl)
+	u := NewContactUsecase(mockRepo, gormDB)
+
+	contactID := uuid.New()
+
+	tests := []struct {
+		name       string
+		setupMocks func()
+		expectTx   bool
+		wantErr    bool
+	}{
+		{
+			name: "success - pending emails and enrollments are cancelled with the contact",
+			setupMocks: func() {
+				mockRepo.EXPECT().GetContact(contactID).Return(&models.Contact{ID: contactID}, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().CancelContactEmails(gomock.Any(), contactID, "contact deleted").Return(int64(2), nil),
+					mockRepo.EXPECT().CancelContactEnrollments(gomock.Any(), contactID).Return(int64(1), nil),
+					mockRepo.EXPECT().DeleteContact(gomock.Any(), contactID).Return(nil),
+				)
+			},
+			expectTx: true,
+		},
+		{
+			name: "error - contact is kept when cancelling its emails fails",
+			setupMocks: func() {
+				mockRepo.EXPECT().GetContact(contactID).Return(&models.Contact{ID: contactID}, nil)
+				mockRepo.EXPECT().CancelContactEmails(gomock.Any(), contactID, gomock.Any()).Return(int64(0), errors.New("db error"))
+			},
+			expectTx: true,
+			wantErr:  true,
+		},
+		{
+			name: "error - contact not found",
+			setupMocks: func() {
+				mockRepo.EXPECT().GetContact(contactID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if tt.wantErr {
+					mock.ExpectRollback()
+				} else {
+					mock.ExpectCommit()
+				}
+			}
+			tt.setupMocks()
+
+			err := u.DeleteContact(newMockCtx(gormDB), contactID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteContact() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ImportContacts(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_contact.NewMockRepository(ctrl)
+	u := NewContactUsecase(mockRepo, gormDB)
+
+	sequenceID := uuid.New()
+	data := []byte("\ufeffEmail,First Name\nlead@example.com,Jane\nother@example.com,John\n")
+
+	tests := []struct {
+		name       string
+		req        *dto.ImportContactsRequest
+		setupMocks func()
+		expectTx   bool
+		wantCode   int
+		wantErr    bool
+	}{
+		{
+			name: "success - import queued with row count",
+			req: &dto.ImportContactsRequest{
+				FileName:   "leads.csv",
+				Data:       data,
+				Mapping:    map[string]string{"email": "email", "first_name": "First Name"},
+				SequenceID: &sequenceID,
+			},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSequence(gomock.Any(), sequenceID).
+					Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusActive}, nil)
+				mockRepo.EXPECT().
+					CreateContactImport(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, contactImport *models.ContactImport) error {
+						if contactImport.Status != models.ContactImportStatusPending || contactImport.TotalRows != 2 {
+							t.Errorf("unexpected import: %+v", contactImport)
+						}
+						return nil
+					})
+			},
+			expectTx: true,
+		},
+		{
+			name:       "error - empty file",
+			req:        &dto.ImportContactsRequest{Mapping: map[string]string{"email": "Email"}},
+			setupMocks: func() {},
+			wantCode:   http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "error - email is not mapped and column is missing",
+			req: &dto.ImportContactsRequest{
+				Data:    data,
+				Mapping: map[string]string{"company": "Company", "nickname": "First Name"},
+			},
+			setupMocks: func() {},
+			wantCode:   http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name: "error - sequence not found",
+			req: &dto.ImportContactsRequest{
+				Data:       data,
+				Mapping:    map[string]string{"email": "Email"},
+				SequenceID: &sequenceID,
+			},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSequence(gomock.Any(), sequenceID).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: http.StatusNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error - sequence is archived",
+			req: &dto.ImportContactsRequest{
+				Data:       data,
+				Mapping:    map[string]string{"email": "Email"},
+				SequenceID: &sequenceID,
+			},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSequence(gomock.Any(), sequenceID).
+					Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusArchived}, nil)
+			},
+			wantCode: http.StatusConflict,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			}
+
+			tt.setupMocks()
+
+			_, err := u.ImportContacts(newMockCtx(gormDB), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImportContacts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("ImportContacts() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ProcessContactImport(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_contact.NewMockRepository(ctrl)
+	u := NewContactUsecase(mockRepo, gormDB)
+
+	sequenceID := uuid.New()
+	existingID := uuid.New()
+	data := []byte("Email,First Name,Company\n" +
+		"Lead@Example.com,Jane,Acme\n" +
+		"lead@example.com,Janet,Acme\n" +
+		"existing@example.com,Old,Acme\n" +
+		"not-an-email,Bad,Acme\n" +
+		"new@example.com\n")
+
+	t.Run("success - rows are deduped, imported and enrolled unless suppressed", func(t *testing.T) {
+		contactImport := &models.ContactImport{
+			ID:         uuid.New(),
+			Mapping:    models.ImportMapping{"email": "Email", "first_name": "First Name", "company": "Company"},
+			SequenceID: &sequenceID,
+			Status:     models.ContactImportStatusPending,
+			TotalRows:  5,
+			Data:       data,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		mockRepo.EXPECT().
+			ClaimContactImport(gomock.Any(), gomock.Any()).
+			Return(contactImport, nil)
+		mockRepo.EXPECT().
+			UpdateContactImport(gomock.Any(), contactImport).
+			Return(nil).
+			Times(3)
+		mockRepo.EXPECT().
+			GetSequence(gomock.Any(), sequenceID).
+			Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusActive}, nil)
+		mockRepo.EXPECT().
+			GetLatestVersion(gomock.Any(), sequenceID).
+			Return(&models.SequenceVersion{SequenceID: sequenceID, Version: 2, Steps: models.VersionSteps{{StepOrder: 1}}}, nil)
+		mockRepo.EXPECT().
+			GetContactsByEmails(gomock.Any(), []string{"lead@example.com", "existing@example.com", "new@example.com"}).
+			Return([]models.Contact{{ID: existingID, Email: "Existing@example.com", Status: models.ContactStatusActive}}, nil)
+		mockRepo.EXPECT().
+			CreateContacts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gorm.DB, contacts []models.Contact) error {
+				if len(contacts) != 2 || contacts[0].Email != "lead@example.com" || contacts[0].FirstName != "Jane" {
+					t.Errorf("unexpected contacts: %+v", contacts)
+				}
+				for i := range contacts {
+					contacts[i].ID = uuid.New()
+				}
+				return nil
+			})
+		mockRepo.EXPECT().
+			GetSuppressedContactIDs(gomock.Any(), gomock.Len(3)).
+			Return([]uuid.UUID{existingID}, nil)
+		mockRepo.EXPECT().
+			EnrollContacts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *gorm.DB, sequenceContacts []models.SequenceContact) (int64, error) {
+				if len(sequenceContacts) != 2 || sequenceContacts[0].ContactID == existingID || sequenceContacts[0].Version != 2 {
+					t.Errorf("unexpected enrollments: %+v", sequenceContacts)
+				}
+				return 2, nil
+			})
+
+		got, err := u.ProcessContactImport(context.Background())
+		if err != nil {
+			t.Fatalf("ProcessContactImport() unexpected error: %v", err)
+		}
+
+		if got.Status != models.ContactImportStatusCompleted || got.ProcessedRows != 5 ||
+			got.Imported != 2 || got.Skipped != 2 || got.Failed != 1 || got.Enrolled != 2 {
+			t.Errorf("unexpected import result: %+v", got)
+		}
+
+		wantIssues := []struct {
+			row     int
+			outcome models.ImportRowOutcome
+		}{
+			{3, models.ImportRowOutcomeSkipped},
+			{5, models.ImportRowOutcomeFailed},
+			{4, models.ImportRowOutcomeSkipped},
+		}
+		if len(got.Issues) != len(wantIssues) {
+			t.Fatalf("expected %d issues, got: %+v", len(wantIssues), got.Issues)
+		}
+		for i, want := range wantIssues {
+			if got.Issues[i].Row != want.row || got.Issues[i].Outcome != want.outcome {
+				t.Errorf("issue %d = %+v, want row %d %s", i, got.Issues[i], want.row, want.outcome)
+			}
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet SQL expectations: %v", err)
+		}
+	})
+
+	t.Run("failed - sequence archived after upload", func(t *testing.T) {
+		contactImport := &models.ContactImport{
+			ID:         uuid.New(),
+			Mapping:    models.ImportMapping{"email": "Email"},
+			SequenceID: &sequenceID,
+			Status:     models.ContactImportStatusPending,
+			Data:       data,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		mockRepo.EXPECT().
+			ClaimContactImport(gomock.Any(), gomock.Any()).
+			Return(contactImport, nil)
+		mockRepo.EXPECT().
+			UpdateContactImport(gomock.Any(), contactImport).
+			Return(nil).
+			Times(2)
+		mockRepo.EXPECT().
+			GetSequence(gomock.Any(), sequenceID).
+			Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusArchived}, nil)
+
+		got, err := u.ProcessContactImport(context.Background())
+		if err != nil {
+			t.Fatalf("ProcessContactImport() unexpected error: %v", err)
+		}
+		if got.Status != models.ContactImportStatusFailed || got.Error != "sequence is archived" {
+			t.Errorf("unexpected import result: %+v", got)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet SQL expectations: %v", err)
+		}
+	})
+
+	t.Run("success - no queued import", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		mockRepo.EXPECT().
+			ClaimContactImport(gomock.Any(), gomock.Any()).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		got, err := u.ProcessContactImport(context.Background())
+		if err != nil || got != nil {
+			t.Errorf("ProcessContactImport() = %+v, %v, want nil, nil", got, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet SQL expectations: %v", err)
+		}
+	})
+}
+
+func Test_ExportContacts(t *testing.T) {
+	sqlDB, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_contact.NewMockRepository(ctrl)
+	u := NewContactUsecase(mockRepo, gormDB)
+
+	contactID := uuid.MustParse("0b6c1d52-4f3e-4f55-9a8e-4c1e0e5b7f01")
+	sequenceID := uuid.MustParse("5d0f7b8e-1c2a-4e3b-8f4d-6a7b8c9d0e1f")
+	step, status := 2, "in_progress"
+	startedAt := time.Date(2025, 10, 1, 9, 30, 0, 0, time.UTC)
+
+	rows := []dto.ContactExportRow{
+		{
+			ContactID:     contactID,
+			Email:         "lead@example.com",
+			FirstName:     "Jane",
+			Company:       "Acme, Inc.",
+			ContactStatus: "active",
+			SequenceID:    &sequenceID,
+			CurrentStep:   &step,
+			Status:        &status,
+			StartedAt:     &startedAt,
+		},
+		{
+			ContactID:     contactID,
+			Email:         "other@example.com",
+			ContactStatus: "unsubscribed",
+		},
+	}
+	streamRows := func(_ *gorm.DB, _ *uuid.UUID, _ []string, fn func(row *dto.ContactExportRow) error) error {
+		for i := range rows {
+			if err := fn(&rows[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		req        *dto.ExportContactsRequest
+		setupMocks func()
+		want       string
+		wantCode   int
+		wantErr    bool
+	}{
+		{
+			name: "success - csv with and without enrollment",
+			req:  &dto.ExportContactsRequest{Format: dto.ExportFormatCSV},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ExportContacts(gomock.Any(), nil, nil, gomock.Any()).
+					DoAndReturn(streamRows)
+			},
+			want: "contact_id,email,first_name,last_name,company,phone,timezone,contact_status,sequence_id,current_step,status,started_at,completed_at\n" +
+				"0b6c1d52-4f3e-4f55-9a8e-4c1e0e5b7f01,lead@example.com,Jane,,\"Acme, Inc.\",,,active,5d0f7b8e-1c2a-4e3b-8f4d-6a7b8c9d0e1f,2,in_progress,2025-10-01T09:30:00Z,\n" +
+				"0b6c1d52-4f3e-4f55-9a8e-4c1e0e5b7f01,other@example.com,,,,,,unsubscribed,,,,,\n",
+		},
+		{
+			name: "success - csv cells that spreadsheets evaluate as formulas are escaped",
+			req:  &dto.ExportContactsRequest{Format: dto.ExportFormatCSV},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ExportContacts(gomock.Any(), nil, nil, gomock.Any()).
+					DoAndReturn(func(db *gorm.DB, id *uuid.UUID, statuses []string, fn func(row *dto.ContactExportRow) error) error {
+						return fn(&dto.ContactExportRow{
+							ContactID:     contactID,
+							Email:         "@lead@example.com",
+							FirstName:     "=HYPERLINK(\"https://evil.example\")",
+							LastName:      "-2+3",
+							Company:       "\tAcme",
+							Phone:         "+1 555 0100",
+							Timezone:      "\rUTC",
+							ContactStatus: "active",
+						})
+					})
+			},
+			want: "contact_id,email,first_name,last_name,company,phone,timezone,contact_status,sequence_id,current_step,status,started_at,completed_at\n" +
+				"0b6c1d52-4f3e-4f55-9a8e-4c1e0e5b7f01,'@lead@example.com,\"'=HYPERLINK(\"\"https://evil.example\"\")\",'-2+3,'\tAcme,+1 555 0100,\"'\rUTC\",active,,,,,\n",
+		},
+		{
+			name: "success - csv phone numbers and plain numbers are not escaped",
+			req:  &dto.ExportContactsRequest{Format: dto.ExportFormatCSV},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ExportContacts(gomock.Any(), nil, nil, gomock.Any()).
+					DoAndReturn(func(db *gorm.DB, id *uuid.UUID, statuses []string, fn func(row *dto.ContactExportRow) error) error {
+						return fn(&dto.ContactExportRow{
+							ContactID:     contactID,
+							Email:         "lead@example.com",
+							LastName:      "-42",
+							Company:       "+1 (555) 010-0100",
+							Phone:         "+14155550100",
+							ContactStatus: "active",
+						})
+					})
+			},
+			want: "contact_id,email,first_name,last_name,company,phone,timezone,contact_status,sequence_id,current_step,status,started_at,completed_at\n" +
+				"0b6c1d52-4f3e-4f55-9a8e-4c1e0e5b7f01,lead@example.com,,-42,+1 (555) 010-0100,+14155550100,,active,,,,,\n",
+		},
+		{
+			name: "success - ndjson filtered by sequence and status",
+			req:  &dto.ExportContactsRequest{Format: dto.ExportFormatNDJSON, SequenceID: &sequenceID, Status: []string{"in_progress"}},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSequence(gomock.Any(), sequenceID).
+					Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().
+					ExportContacts(gomock.Any(), &sequenceID, []string{"in_progress"}, gomock.Any()).
+					DoAndReturn(func(db *gorm.DB, id *uuid.UUID, statuses []string, fn func(row *dto.ContactExportRow) error) error {
+						return fn(&rows[0])
+					})
+			},
+			want: `{"contact_id":"0b6c1d52-4f3e-4f55-9a8e-4c1e0e5b7f01","email":"lead@example.com","first_name":"Jane","last_name":"","company":"Acme, Inc.","phone":"","timezone":"","contact_status":"active","sequence_id":"5d0f7b8e-1c2a-4e3b-8f4d-6a7b8c9d0e1f","current_step":2,"status":"in_progress","started_at":"2025-10-01T09:30:00Z","completed_at":null}` + "\n",
+		},
+		{
+			name: "success - csv header when nothing matches",
+			req:  &dto.ExportContactsRequest{Format: dto.ExportFormatCSV, Status: []string{"bounced"}},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ExportContacts(gomock.Any(), nil, []string{"bounced"}, gomock.Any()).
+					Return(nil)
+			},
+			want: "contact_id,email,first_name,last_name,company,phone,timezone,contact_status,sequence_id,current_step,status,started_at,completed_at\n",
+		},
+		{
+			name: "error - sequence not found",
+			req:  &dto.ExportContactsRequest{Format: dto.ExportFormatCSV, SequenceID: &sequenceID},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSequence(gomock.Any(), sequenceID).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: http.StatusNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error - repository fails before any row",
+			req:  &dto.ExportContactsRequest{Format: dto.ExportFormatCSV},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ExportContacts(gomock.Any(), nil, nil, gomock.Any()).
+					Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			var out bytes.Buffer
+			err := u.ExportContacts(newMockCtx(gormDB), tt.req, &out)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExportContacts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("ExportContacts() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if !tt.wantErr && out.String() != tt.want {
+				t.Errorf("ExportContacts() output =\n%s\nwant\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package https
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/module/email"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+)
+
+type emailHandler struct {
+	usecase email.Usecase
+}
+
+func NewEmailHandler(e *echo.Echo, usecase email.Usecase) {
+	h := &emailHandler{
+		usecase: usecase,
+	}
+
+	api := e.Group("/api/v1")
+
+	api.POST("/admin/emails/requeue", h.RequeueFailedEmails)
+}
+
+// RequeueFailedEmails godoc
+// @Summary      Requeue failed emails
+// @Description  Schedule failed emails to be sent again with a fresh retry budget. Emails that are not failed or whose mailbox is at capacity today are skipped.
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        emails  body      dto.RequeueEmailsRequest  true  "Emails to requeue"
+// @Success      200  {object}  dto.ResponsePattern{data=dto.RequeueEmailsResponse}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /admin/emails/requeue [post]
+func (h *emailHandler) RequeueFailedEmails(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.RequeueEmailsRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("RequeueFailedEmails - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	resp, err := h.usecase.RequeueFailedEmails(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("RequeueFailedEmails - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Emails requeued successfully", resp, "", "", http.StatusOK, nil)
+}
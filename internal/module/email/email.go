@@ -0,0 +1,21 @@
+package email
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"gorm.io/gorm"
+)
+
+type Usecase interface {
+	RequeueFailedEmails(c echo.Context, req *dto.RequeueEmailsRequest) (*dto.RequeueEmailsResponse, error)
+}
+
+type Repository interface {
+	GetFailedEmails(tx *gorm.DB, emailQueueIDs []uuid.UUID) ([]models.EmailQueue, error)
+	ReserveMailboxCapacity(tx *gorm.DB, mailboxID uuid.UUID, day time.Time) (bool, error)
+	RequeueEmail(tx *gorm.DB, emailQueueID uuid.UUID, scheduledFor time.Time) error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/email"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/capacity"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/suppression"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type emailRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailRepository(db *gorm.DB) email.Repository {
+	return &emailRepository{
+		db: db,
+	}
+}
+
+// GetFailedEmails locks the given emails that are in the failed state and may still be sent: their
+// enrollment has not ended other than by the scheduler queueing its last step, their sequence and
+// contact are not deleted, and the contact is neither unsubscribed nor suppressed.
+func (r *emailRepository) GetFailedEmails(tx *gorm.DB, emailQueueIDs []uuid.UUID) ([]models.EmailQueue, error) {
+	var emailQueues []models.EmailQueue
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "eq"}}).
+		Table("email_queues AS eq").
+		Select("eq.*").
+		Joins("JOIN sequence_contacts sc ON sc.id = eq.sequence_contact_id").
+		Joins("JOIN sequences seq ON seq.id = sc.sequence_id AND seq.deleted_at IS NULL").
+		Joins("JOIN contacts c ON c.id = sc.contact_id AND c.deleted_at IS NULL AND c.status = ?", models.ContactStatusActive).
+		Where("eq.id IN ? AND eq.status = ?", emailQueueIDs, models.EmailQueueStatusFailed).
+		Where("sc.status IN ? OR (sc.status = ? AND sc.completed_reason IS NULL)",
+			[]models.SequenceContactStatus{models.SequenceContactStatusPending, models.SequenceContactStatusInProgress},
+			models.SequenceContactStatusCompleted)
+	if err := suppression.ExcludeContacts(query).Find(&emailQueues).Error; err != nil {
+		return nil, err
+	}
+	return emailQueues, nil
+}
+
+// ReserveMailboxCapacity atomically takes one slot of the mailbox's daily capacity for the given UTC day.
+// It reports false when the cap is already reached.
+func (r *emailRepository) ReserveMailboxCapacity(tx *gorm.DB, mailboxID uuid.UUID, day time.Time) (bool, error) {
+	return capacity.Reserve(tx, mailboxID, day)
+}
+
+// RequeueEmail schedules a failed email again with a fresh retry budget.
+func (r *emailRepository) RequeueEmail(tx *gorm.DB, emailQueueID uuid.UUID, scheduledFor time.Time) error {
+	return tx.Model(&models.EmailQueue{}).
+		Where("id = ?", emailQueueID).
+		Updates(map[string]any{
+			"status":        models.EmailQueueStatusScheduled,
+			"scheduled_for": scheduledFor,
+			"retry_count":   0,
+			"error_message": nil,
+		}).Error
+}
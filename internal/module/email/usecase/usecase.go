@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/module/email"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+)
+
+type emailUsecase struct {
+	repository email.Repository
+}
+
+func NewEmailUsecase(repository email.Repository) email.Usecase {
+	return &emailUsecase{
+		repository: repository,
+	}
+}
+
+// RequeueFailedEmails moves failed emails that may still be sent back to scheduled so the scheduler
+// dispatches them again. Each email takes a slot of its mailbox's capacity for today; emails whose
+// mailbox is full are skipped.
+func (u *emailUsecase) RequeueFailedEmails(c echo.Context, req *dto.RequeueEmailsRequest) (*dto.RequeueEmailsResponse, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	now := time.Now()
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	emailQueues, err := u.repository.GetFailedEmails(tx, req.EmailQueueIDs)
+	if err != nil {
+		ac.AppLoger.Errorf("RequeueFailedEmails - failed to fetch failed emails: %v", err)
+		return nil, err
+	}
+
+	requeued := make(map[uuid.UUID]struct{}, len(emailQueues))
+	for _, emailQueue := range emailQueues {
+		if emailQueue.MailboxID != nil {
+			reserved, err := u.repository.ReserveMailboxCapacity(tx, *emailQueue.MailboxID, today)
+			if err != nil {
+				ac.AppLoger.Errorf("RequeueFailedEmails - failed to reserve capacity for email %s: %v", emailQueue.ID, err)
+				return nil, err
+			}
+			if !reserved {
+				ac.AppLoger.Warnf("RequeueFailedEmails - mailbox %s is at capacity, skipping email %s", emailQueue.MailboxID, emailQueue.ID)
+				continue
+			}
+		}
+
+		if err := u.repository.RequeueEmail(tx, emailQueue.ID, now); err != nil {
+			ac.AppLoger.Errorf("RequeueFailedEmails - failed to requeue email %s: %v", emailQueue.ID, err)
+			return nil, err
+		}
+		requeued[emailQueue.ID] = struct{}{}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("RequeueFailedEmails - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	resp := &dto.RequeueEmailsResponse{
+		Requeued: len(requeued),
+		Skipped:  []uuid.UUID{},
+	}
+	for _, emailQueueID := range req.EmailQueueIDs {
+		if _, ok := requeued[emailQueueID]; !ok {
+			resp.Skipped = append(resp.Skipped, emailQueueID)
+		}
+	}
+
+	ac.AppLoger.Infof("RequeueFailedEmails - requeued: %d, skipped: %d", resp.Requeued, len(resp.Skipped))
+	return resp, nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	mock_email "github.com/rohanchauhan02/sequence-service/files/mocks/email"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockCtx(db *gorm.DB) echo.Context {
+	e := echo.New()
+	return &ctx.CustomApplicationContext{
+		Context:  e.NewContext(nil, nil),
+		Postgres: db,
+		AppLoger: logger.NewLogger(),
+	}
+}
+
+func Test_RequeueFailedEmails(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_email.NewMockRepository(ctrl)
+	u := NewEmailUsecase(mockRepo)
+
+	requeuedID, fullMailboxID, notFailedID := uuid.New(), uuid.New(), uuid.New()
+	mailboxID, fullMailbox := uuid.New(), uuid.New()
+	req := &dto.RequeueEmailsRequest{EmailQueueIDs: []uuid.UUID{requeuedID, fullMailboxID, notFailedID}}
+
+	tests := []struct {
+		name         string
+		setupMocks   func()
+		wantErr      bool
+		wantRequeued int
+		wantSkipped  []uuid.UUID
+	}{
+		{
+			name: "success - requeue failed emails with capacity left",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetFailedEmails(gomock.Any(), req.EmailQueueIDs).
+					Return([]models.EmailQueue{
+						{ID: requeuedID, MailboxID: &mailboxID},
+						{ID: fullMailboxID, MailboxID: &fullMailbox},
+					}, nil)
+				mockRepo.EXPECT().ReserveMailboxCapacity(gomock.Any(), mailboxID, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().ReserveMailboxCapacity(gomock.Any(), fullMailbox, gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RequeueEmail(gomock.Any(), requeuedID, gomock.Any()).Return(nil)
+			},
+			wantRequeued: 1,
+			wantSkipped:  []uuid.UUID{fullMailboxID, notFailedID},
+		},
+		{
+			name: "error - repository fails to fetch failed emails",
+			setupMocks: func() {
+				mockRepo.EXPECT().GetFailedEmails(gomock.Any(), req.EmailQueueIDs).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if !tt.wantErr {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			tt.setupMocks()
+
+			resp, err := u.RequeueFailedEmails(newMockCtx(gormDB), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RequeueFailedEmails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if resp != nil {
+				if resp.Requeued != tt.wantRequeued || len(resp.Skipped) != len(tt.wantSkipped) {
+					t.Fatalf("RequeueFailedEmails() = %+v, want requeued %d skipped %v", resp, tt.wantRequeued, tt.wantSkipped)
+				}
+				for i := range tt.wantSkipped {
+					if resp.Skipped[i] != tt.wantSkipped[i] {
+						t.Errorf("RequeueFailedEmails() skipped = %v, want %v", resp.Skipped, tt.wantSkipped)
+					}
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package https
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/enrollment"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+)
+
+type enrollmentHandler struct {
+	usecase enrollment.Usecase
+}
+
+func NewEnrollmentHandler(e *echo.Echo, usecase enrollment.Usecase) {
+	h := &enrollmentHandler{
+		usecase: usecase,
+	}
+
+	api := e.Group("/api/v1")
+
+	api.POST("/sequence/:id/contacts", h.EnrollContacts)
+	api.GET("/sequence/:id/contacts", h.ListEnrollments)
+	api.POST("/sequence/:id/contacts/:contactId/pause", h.PauseEnrollment)
+	api.POST("/sequence/:id/contacts/:contactId/resume", h.ResumeEnrollment)
+	api.POST("/sequence/:id/contacts/:contactId/cancel", h.CancelEnrollment)
+}
+
+// EnrollContacts godoc
+// @Summary      Enroll contacts into a sequence
+// @Description  Enroll one or many contacts into a sequence
+// @Tags         Enrollments
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                     true  "Sequence ID"
+// @Param        contacts  body      dto.EnrollContactsRequest  true  "Contacts to enroll"
+// @Success      201  {object}  dto.ResponsePattern{data=dto.EnrollContactsResponse}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/contacts [post]
+func (h *enrollmentHandler) EnrollContacts(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceID := c.Param("id")
+	sequenceUUID, err := uuid.Parse(sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.EnrollContactsRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	resp, err := h.usecase.EnrollContacts(c, sequenceUUID, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	ac.AppLoger.Infof("EnrollContacts - %d contacts enrolled into sequence ID: %s", resp.Enrolled, sequenceID)
+	return ac.CustomResponse("Contacts enrolled successfully", resp, "", "", http.StatusCreated, nil)
+}
+
+// ListEnrollments godoc
+// @Summary      List sequence enrollments
+// @Description  List the contacts enrolled into a sequence, optionally filtered by status
+// @Tags         Enrollments
+// @Produce      json
+// @Param        id      path      string    true   "Sequence ID"
+// @Param        page    query     int       false  "Page number"
+// @Param        limit   query     int       false  "Page size"
+// @Param        status  query     []string  false  "Enrollment status"  collectionFormat(multi)
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.SequenceContact,meta=dto.PaginationMeta}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/contacts [get]
+func (h *enrollmentHandler) ListEnrollments(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceID := c.Param("id")
+	sequenceUUID, err := uuid.Parse(sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("ListEnrollments - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.ListEnrollmentsRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("ListEnrollments - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	sequenceContacts, meta, err := h.usecase.ListEnrollments(c, sequenceUUID, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("ListEnrollments - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Enrollments retrieved successfully", sequenceContacts, "", "", http.StatusOK, meta)
+}
+
+// PauseEnrollment godoc
+// @Summary      Pause an enrollment
+// @Description  Stop sending further steps to a contact until the enrollment is resumed
+// @Tags         Enrollments
+// @Produce      json
+// @Param        id         path      string  true  "Sequence ID"
+// @Param        contactId  path      string  true  "Contact ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.SequenceContact}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/contacts/{contactId}/pause [post]
+func (h *enrollmentHandler) PauseEnrollment(c echo.Context) error {
+	return h.changeStatus(c, "PauseEnrollment", "Enrollment paused successfully", h.usecase.PauseEnrollment)
+}
+
+// ResumeEnrollment godoc
+// @Summary      Resume an enrollment
+// @Description  Resume a paused enrollment
+// @Tags         Enrollments
+// @Produce      json
+// @Param        id         path      string  true  "Sequence ID"
+// @Param        contactId  path      string  true  "Contact ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.SequenceContact}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/contacts/{contactId}/resume [post]
+func (h *enrollmentHandler) ResumeEnrollment(c echo.Context) error {
+	return h.changeStatus(c, "ResumeEnrollment", "Enrollment resumed successfully", h.usecase.ResumeEnrollment)
+}
+
+// CancelEnrollment godoc
+// @Summary      Cancel an enrollment
+// @Description  Cancel an enrollment and any emails scheduled for it
+// @Tags         Enrollments
+// @Produce      json
+// @Param        id         path      string  true  "Sequence ID"
+// @Param        contactId  path      string  true  "Contact ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.SequenceContact}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/contacts/{contactId}/cancel [post]
+func (h *enrollmentHandler) CancelEnrollment(c echo.Context) error {
+	return h.changeStatus(c, "CancelEnrollment", "Enrollment cancelled successfully", h.usecase.CancelEnrollment)
+}
+
+func (h *enrollmentHandler) changeStatus(c echo.Context, action, message string, fn func(echo.Context, uuid.UUID, uuid.UUID) (*models.SequenceContact, error)) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceID := c.Param("id")
+	contactID := c.Param("contactId")
+
+	sequenceUUID, err := uuid.Parse(sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("%s - invalid sequence ID: %v", action, err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	contactUUID, err := uuid.Parse(contactID)
+	if err != nil {
+		ac.AppLoger.Errorf("%s - invalid contact ID: %v", action, err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid contact ID", http.StatusBadRequest, nil)
+	}
+
+	sequenceContact, err := fn(c, sequenceUUID, contactUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("%s - usecase error: %v", action, err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	ac.AppLoger.Infof("%s - sequenceID: %s, contactID: %s, status: %s", action, sequenceID, contactID, sequenceContact.Status)
+	return ac.CustomResponse(message, sequenceContact, "", "", http.StatusOK, nil)
+}
@@ -0,0 +1,32 @@
+package enrollment
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"gorm.io/gorm"
+)
+
+type Usecase interface {
+	EnrollContacts(c echo.Context, sequenceID uuid.UUID, req *dto.EnrollContactsRequest) (*dto.EnrollContactsResponse, error)
+	ListEnrollments(c echo.Context, sequenceID uuid.UUID, req *dto.ListEnrollmentsRequest) ([]models.SequenceContact, *dto.PaginationMeta, error)
+	PauseEnrollment(c echo.Context, sequenceID uuid.UUID, contactID uuid.UUID) (*models.SequenceContact, error)
+	ResumeEnrollment(c echo.Context, sequenceID uuid.UUID, contactID uuid.UUID) (*models.SequenceContact, error)
+	CancelEnrollment(c echo.Context, sequenceID uuid.UUID, contactID uuid.UUID) (*models.SequenceContact, error)
+}
+
+type Repository interface {
+	GetSequence(sequenceID uuid.UUID) (*models.Sequence, error)
+	GetLatestVersion(sequenceID uuid.UUID) (*models.SequenceVersion, error)
+	GetContactsByIDs(contactIDs []uuid.UUID) ([]models.Contact, error)
+	GetSuppressedContactIDs(contactIDs []uuid.UUID) ([]uuid.UUID, error)
+
+	GetEnrolledContactIDs(sequenceID uuid.UUID, contactIDs []uuid.UUID) ([]uuid.UUID, error)
+	CreateSequenceContacts(tx *gorm.DB, sequenceContacts []models.SequenceContact) ([]models.SequenceContact, error)
+	ListSequenceContacts(sequenceID uuid.UUID, statuses []string, offset, limit int) ([]models.SequenceContact, int64, error)
+	GetSequenceContact(tx *gorm.DB, sequenceID uuid.UUID, contactID uuid.UUID) (*models.SequenceContact, error)
+	UpdateSequenceContact(tx *gorm.DB, sequenceContact *models.SequenceContact) error
+
+	CancelScheduledEmails(tx *gorm.DB, sequenceContactID uuid.UUID) error
+}
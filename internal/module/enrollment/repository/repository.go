@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/enrollment"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/capacity"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/suppression"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type enrollmentRepository struct {
+	db *gorm.DB
+}
+
+func NewEnrollmentRepository(db *gorm.DB) enrollment.Repository {
+	return &enrollmentRepository{
+		db: db,
+	}
+}
+
+func (r *enrollmentRepository) GetSequence(sequenceID uuid.UUID) (*models.Sequence, error) {
+	var sequence models.Sequence
+	if err := r.db.First(&sequence, "id = ?", sequenceID).Error; err != nil {
+		return nil, err
+	}
+	return &sequence, nil
+}
+
+func (r *enrollmentRepository) GetLatestVersion(sequenceID uuid.UUID) (*models.SequenceVersion, error) {
+	var version models.SequenceVersion
+	if err := r.db.Where("sequence_id = ?", sequenceID).Order("version DESC").First(&version).Error; err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (r *enrollmentRepository) GetContactsByIDs(contactIDs []uuid.UUID) ([]models.Contact, error) {
+	var contacts []models.Contact
+	if err := r.db.Where("id IN ?", contactIDs).Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// GetSuppressedContactIDs returns the contacts whose email, or the domain of it, is on the suppression list.
+func (r *enrollmentRepository) GetSuppressedContactIDs(contactIDs []uuid.UUID) ([]uuid.UUID, error) {
+	return suppression.ContactIDs(r.db, contactIDs)
+}
+
+func (r *enrollmentRepository) GetEnrolledContactIDs(sequenceID uuid.UUID, contactIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var enrolledIDs []uuid.UUID
+	if err := r.db.Model(&models.SequenceContact{}).
+		Where("sequence_id = ? AND contact_id IN ?", sequenceID, contactIDs).
+		Pluck("contact_id", &enrolledIDs).Error; err != nil {
+		return nil, err
+	}
+	return enrolledIDs, nil
+}
+
+func (r *enrollmentRepository) CreateSequenceContacts(tx *gorm.DB, sequenceContacts []models.SequenceContact) ([]models.SequenceContact, error) {
+	if err := tx.Create(&sequenceContacts).Error; err != nil {
+		return nil, err
+	}
+	return sequenceContacts, nil
+}
+
+func (r *enrollmentRepository) ListSequenceContacts(sequenceID uuid.UUID, statuses []string, offset, limit int) ([]models.SequenceContact, int64, error) {
+	query := r.db.Model(&models.SequenceContact{}).Where("sequence_id = ?", sequenceID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var sequenceContacts []models.SequenceContact
+	if err := query.Preload("Contact").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&sequenceContacts).Error; err != nil {
+		return nil, 0, err
+	}
+	return sequenceContacts, total, nil
+}
+
+// GetSequenceContact returns the enrollment of a contact in a sequence, locked until tx ends so the
+// scheduler cannot advance it while its status changes.
+func (r *enrollmentRepository) GetSequenceContact(tx *gorm.DB, sequenceID, contactID uuid.UUID) (*models.SequenceContact, error) {
+	var sequenceContact models.SequenceContact
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("sequence_id = ? AND contact_id = ?", sequenceID, contactID).First(&sequenceContact).Error; err != nil {
+		return nil, err
+	}
+	return &sequenceContact, nil
+}
+
+func (r *enrollmentRepository) UpdateSequenceContact(tx *gorm.DB, sequenceContact *models.SequenceContact) error {
+	return tx.Omit("Contact").Save(sequenceContact).Error
+}
+
+// CancelScheduledEmails cancels the emails of an enrollment that were not dispatched yet and
+// releases the mailbox capacity reserved for them.
+func (r *enrollmentRepository) CancelScheduledEmails(tx *gorm.DB, sequenceContactID uuid.UUID) error {
+	if err := capacity.Release(tx, "sequence_contact_id = ? AND status = ?", sequenceContactID, models.EmailQueueStatusScheduled); err != nil {
+		return err
+	}
+
+	return tx.Model(&models.EmailQueue{}).
+		Where("sequence_contact_id = ? AND status = ?", sequenceContactID, models.EmailQueueStatusScheduled).
+		Update("status", models.EmailQueueStatusCancelled).Error
+}
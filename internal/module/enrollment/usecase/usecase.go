@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/enrollment"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/database"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/suppression"
+	"gorm.io/gorm"
+)
+
+type enrollmentUsecase struct {
+	repository enrollment.Repository
+}
+
+func NewEnrollmentUsecase(repository enrollment.Repository) enrollment.Usecase {
+	return &enrollmentUsecase{
+		repository: repository,
+	}
+}
+
+// EnrollContacts starts the latest version of the sequence for every contact that is neither
+// unsubscribed nor suppressed. The first step is due after its delay.
+func (u *enrollmentUsecase) EnrollContacts(c echo.Context, sequenceID uuid.UUID, req *dto.EnrollContactsRequest) (*dto.EnrollContactsResponse, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequence, err := u.getSequence(sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - %v", err)
+		return nil, err
+	}
+	if sequence.Status == models.SequenceStatusArchived {
+		return nil, echo.NewHTTPError(http.StatusConflict, "Sequence is archived")
+	}
+
+	contactIDs := uniqueIDs(req.ContactIDs)
+	contacts, err := u.repository.GetContactsByIDs(contactIDs)
+	if err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - failed to fetch contacts: %v", err)
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]models.Contact, len(contacts))
+	for _, contact := range contacts {
+		found[contact.ID] = contact
+	}
+
+	var missing, unsubscribed []uuid.UUID
+	for _, contactID := range contactIDs {
+		contact, ok := found[contactID]
+		switch {
+		case !ok:
+			missing = append(missing, contactID)
+		case contact.Status == models.ContactStatusUnsubscribed:
+			unsubscribed = append(unsubscribed, contactID)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Contacts not found: "+joinIDs(missing))
+	}
+	resp := &dto.EnrollContactsResponse{
+		SequenceContactIDs:     []string{},
+		SuppressedContactIDs:   []string{},
+		UnsubscribedContactIDs: idStrings(unsubscribed),
+	}
+	contactIDs = suppression.Without(contactIDs, unsubscribed)
+	if len(contactIDs) == 0 {
+		ac.AppLoger.Infof("EnrollContacts - every contact is unsubscribed, nothing to enroll")
+		return resp, nil
+	}
+
+	suppressedIDs, err := u.repository.GetSuppressedContactIDs(contactIDs)
+	if err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - failed to fetch suppressed contacts: %v", err)
+		return nil, err
+	}
+	resp.SuppressedContactIDs = idStrings(suppressedIDs)
+	contactIDs = suppression.Without(contactIDs, suppressedIDs)
+	if len(contactIDs) == 0 {
+		ac.AppLoger.Infof("EnrollContacts - every contact is suppressed or unsubscribed, nothing to enroll")
+		return resp, nil
+	}
+
+	enrolledIDs, err := u.repository.GetEnrolledContactIDs(sequenceID, contactIDs)
+	if err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - failed to fetch existing enrollments: %v", err)
+		return nil, err
+	}
+	if len(enrolledIDs) > 0 {
+		return nil, echo.NewHTTPError(http.StatusConflict, "Contacts already enrolled in this sequence: "+joinIDs(enrolledIDs))
+	}
+
+	version, err := u.repository.GetLatestVersion(sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - failed to fetch latest version: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	nextSendAt := now
+	if len(version.Steps) > 0 {
+		nextSendAt = version.Steps[0].SendAfter(now)
+	}
+
+	sequenceContacts := make([]models.SequenceContact, len(contactIDs))
+	for i, contactID := range contactIDs {
+		sequenceContacts[i] = models.SequenceContact{
+			SequenceID: sequenceID,
+			ContactID:  contactID,
+			Version:    version.Version,
+			NextSendAt: &nextSendAt,
+			Status:     models.SequenceContactStatusInProgress,
+			StartedAt:  &now,
+		}
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	created, err := u.repository.CreateSequenceContacts(tx, sequenceContacts)
+	if database.IsUniqueViolation(err) {
+		ac.AppLoger.Errorf("EnrollContacts - concurrent enrollment: %v", err)
+		return nil, echo.NewHTTPError(http.StatusConflict, "Contacts already enrolled in this sequence")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - failed to create enrollments: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("EnrollContacts - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	resp.Enrolled = len(created)
+	for _, sequenceContact := range created {
+		resp.SequenceContactIDs = append(resp.SequenceContactIDs, sequenceContact.ID.String())
+	}
+	return resp, nil
+}
+
+func (u *enrollmentUsecase) ListEnrollments(c echo.Context, sequenceID uuid.UUID, req *dto.ListEnrollmentsRequest) ([]models.SequenceContact, *dto.PaginationMeta, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	if _, err := u.getSequence(sequenceID); err != nil {
+		ac.AppLoger.Errorf("ListEnrollments - %v", err)
+		return nil, nil, err
+	}
+
+	page, limit := req.Page, req.Limit
+	if page == 0 {
+		page = dto.DefaultPage
+	}
+	if limit == 0 {
+		limit = dto.DefaultLimit
+	}
+
+	sequenceContacts, total, err := u.repository.ListSequenceContacts(sequenceID, req.Status, (page-1)*limit, limit)
+	if err != nil {
+		ac.AppLoger.Errorf("ListEnrollments - failed to list enrollments: %v", err)
+		return nil, nil, err
+	}
+
+	return sequenceContacts, dto.NewPaginationMeta(page, limit, total), nil
+}
+
+func (u *enrollmentUsecase) PauseEnrollment(c echo.Context, sequenceID uuid.UUID, contactID uuid.UUID) (*models.SequenceContact, error) {
+	return u.transition(c, sequenceID, contactID, models.SequenceContactStatusPaused,
+		models.SequenceContactStatusPending, models.SequenceContactStatusInProgress)
+}
+
+func (u *enrollmentUsecase) ResumeEnrollment(c echo.Context, sequenceID uuid.UUID, contactID uuid.UUID) (*models.SequenceContact, error) {
+	return u.transition(c, sequenceID, contactID, models.SequenceContactStatusInProgress,
+		models.SequenceContactStatusPaused)
+}
+
+func (u *enrollmentUsecase) CancelEnrollment(c echo.Context, sequenceID uuid.UUID, contactID uuid.UUID) (*models.SequenceContact, error) {
+	return u.transition(c, sequenceID, contactID, models.SequenceContactStatusCancelled,
+		models.SequenceContactStatusPending, models.SequenceContactStatusInProgress, models.SequenceContactStatusPaused)
+}
+
+// transition moves an enrollment to status when its current status is one of from. The enrollment is
+// read and written under a row lock, so a concurrent scheduler run cannot overwrite the change.
+// Cancelling also cancels emails that are scheduled but not yet dispatched.
+func (u *enrollmentUsecase) transition(c echo.Context, sequenceID, contactID uuid.UUID, status models.SequenceContactStatus, from ...models.SequenceContactStatus) (*models.SequenceContact, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	sequenceContact, err := u.repository.GetSequenceContact(tx, sequenceID, contactID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Error("transition - enrollment not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Enrollment not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("transition - failed to fetch enrollment: %v", err)
+		return nil, err
+	}
+
+	allowed := false
+	for _, s := range from {
+		if sequenceContact.Status == s {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("Enrollment cannot move from %s to %s", sequenceContact.Status, status))
+	}
+
+	sequenceContact.Status = status
+	if status == models.SequenceContactStatusCancelled {
+		now := time.Now()
+		sequenceContact.NextSendAt = nil
+		sequenceContact.CompletedAt = &now
+	}
+
+	if err := u.repository.UpdateSequenceContact(tx, sequenceContact); err != nil {
+		ac.AppLoger.Errorf("transition - failed to update enrollment: %v", err)
+		return nil, err
+	}
+
+	if status == models.SequenceContactStatusCancelled {
+		if err := u.repository.CancelScheduledEmails(tx, sequenceContact.ID); err != nil {
+			ac.AppLoger.Errorf("transition - failed to cancel scheduled emails: %v", err)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("transition - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("transition - enrollment %s moved to %s", sequenceContact.ID.String(), status)
+
+	return sequenceContact, nil
+}
+
+func (u *enrollmentUsecase) getSequence(sequenceID uuid.UUID) (*models.Sequence, error) {
+	sequence, err := u.repository.GetSequence(sequenceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+	}
+	return sequence, err
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func joinIDs(ids []uuid.UUID) string {
+	return strings.Join(idStrings(ids), ", ")
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = id.String()
+	}
+	return parts
+}
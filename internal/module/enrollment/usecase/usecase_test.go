@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	mock_enrollment "github.com/rohanchauhan02/sequence-service/files/mocks/enrollment"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockCtx(db *gorm.DB) echo.Context {
+	e := echo.New()
+	return &ctx.CustomApplicationContext{
+		Context:  e.NewContext(nil, nil),
+		Postgres: db,
+		AppLoger: logger.NewLogger(),
+	}
+}
+
+func Test_EnrollContacts(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_enrollment.NewMockRepository(ctrl)
+	u := NewEnrollmentUsecase(mockRepo)
+
+	sequenceID := uuid.New()
+	contactID := uuid.New()
+	req := &dto.EnrollContactsRequest{ContactIDs: []uuid.UUID{contactID, contactID}}
+
+	expectValidContacts := func() {
+		mockRepo.EXPECT().GetSequence(sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+		mockRepo.EXPECT().
+			GetContactsByIDs([]uuid.UUID{contactID}).
+			Return([]models.Contact{{ID: contactID, Status: models.ContactStatusActive}}, nil)
+		mockRepo.EXPECT().GetSuppressedContactIDs([]uuid.UUID{contactID}).Return(nil, nil)
+	}
+
+	tests := []struct {
+		name             string
+		setupMocks       func()
+		expectTx         bool
+		wantSuppressed   []string
+		wantUnsubscribed []string
+		wantCode         int
+		wantErr          bool
+	}{
+		{
+			name: "success - enroll deduplicated contacts",
+			setupMocks: func() {
+				expectValidContacts()
+				mockRepo.EXPECT().GetEnrolledContactIDs(sequenceID, []uuid.UUID{contactID}).Return(nil, nil)
+				mockRepo.EXPECT().GetLatestVersion(sequenceID).Return(&models.SequenceVersion{Version: 3, Steps: models.VersionSteps{{StepOrder: 1, WaitDays: 0}}}, nil)
+				mockRepo.EXPECT().
+					CreateSequenceContacts(gomock.Any(), gomock.Len(1)).
+					DoAndReturn(func(_ *gorm.DB, sequenceContacts []models.SequenceContact) ([]models.SequenceContact, error) {
+						if sequenceContacts[0].Status != models.SequenceContactStatusInProgress || sequenceContacts[0].NextSendAt == nil || sequenceContacts[0].Version != 3 {
+							t.Errorf("unexpected enrollment: %+v", sequenceContacts[0])
+						}
+						return sequenceContacts, nil
+					})
+			},
+			expectTx: true,
+		},
+		{
+			name: "success - suppressed contact is skipped",
+			setupMocks: func() {
+				mockRepo.EXPECT().GetSequence(sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs([]uuid.UUID{contactID}).
+					Return([]models.Contact{{ID: contactID, Status: models.ContactStatusActive}}, nil)
+				mockRepo.EXPECT().GetSuppressedContactIDs([]uuid.UUID{contactID}).Return([]uuid.UUID{contactID}, nil)
+			},
+			wantSuppressed: []string{contactID.String()},
+		},
+		{
+			name: "success - unsubscribed contact is skipped",
+			setupMocks: func() {
+				mockRepo.EXPECT().GetSequence(sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs([]uuid.UUID{contactID}).
+					Return([]models.Contact{{ID: contactID, Status: models.ContactStatusUnsubscribed}}, nil)
+			},
+			wantUnsubscribed: []string{contactID.String()},
+		},
+		{
+			name: "error - sequence not found",
+			setupMocks: func() {
+				mockRepo.EXPECT().GetSequence(sequenceID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: http.StatusNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error - sequence archived",
+			setupMocks: func() {
+				mockRepo.EXPECT().GetSequence(sequenceID).Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusArchived}, nil)
+			},
+			wantCode: http.StatusConflict,
+			wantErr:  true,
+		},
+		{
+			name: "error - contact already enrolled",
+			setupMocks: func() {
+				expectValidContacts()
+				mockRepo.EXPECT().GetEnrolledContactIDs(sequenceID, []uuid.UUID{contactID}).Return([]uuid.UUID{contactID}, nil)
+			},
+			wantCode: http.StatusConflict,
+			wantErr:  true,
+		},
+		{
+			name: "error - unique constraint hit by concurrent enrollment",
+			setupMocks: func() {
+				expectValidContacts()
+				mockRepo.EXPECT().GetEnrolledContactIDs(sequenceID, []uuid.UUID{contactID}).Return(nil, nil)
+				mockRepo.EXPECT().GetLatestVersion(sequenceID).Return(&models.SequenceVersion{Version: 1}, nil)
+				mockRepo.EXPECT().
+					CreateSequenceContacts(gomock.Any(), gomock.Any()).
+					Return(nil, &pgconn.PgError{Code: "23505"})
+			},
+			expectTx: true,
+			wantCode: http.StatusConflict,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if !tt.wantErr {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			tt.setupMocks()
+
+			resp, err := u.EnrollContacts(newMockCtx(gormDB), sequenceID, req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EnrollContacts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantSuppressed != nil && (resp == nil || resp.Enrolled != 0 || !reflect.DeepEqual(resp.SuppressedContactIDs, tt.wantSuppressed)) {
+				t.Errorf("EnrollContacts() = %+v, want suppressed %v", resp, tt.wantSuppressed)
+			}
+			if tt.wantUnsubscribed != nil && (resp == nil || resp.Enrolled != 0 || !reflect.DeepEqual(resp.UnsubscribedContactIDs, tt.wantUnsubscribed)) {
+				t.Errorf("EnrollContacts() = %+v, want unsubscribed %v", resp, tt.wantUnsubscribed)
+			}
+
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("EnrollContacts() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_PauseEnrollment(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_enrollment.NewMockRepository(ctrl)
+	u := NewEnrollmentUsecase(mockRepo)
+
+	sequenceID, contactID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		setupMocks   func()
+		expectCommit bool
+		wantCode     int
+	}{
+		{
+			name: "success - pause an enrollment in progress",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSequenceContact(gomock.Any(), sequenceID, contactID).
+					Return(&models.SequenceContact{Status: models.SequenceContactStatusInProgress}, nil)
+				mockRepo.EXPECT().
+					UpdateSequenceContact(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, sequenceContact *models.SequenceContact) error {
+						if sequenceContact.Status != models.SequenceContactStatusPaused {
+							t.Errorf("UpdateSequenceContact() status = %s, want %s", sequenceContact.Status, models.SequenceContactStatusPaused)
+						}
+						return nil
+					})
+			},
+			expectCommit: true,
+		},
+		{
+			name: "error - enrollment already completed",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSequenceContact(gomock.Any(), sequenceID, contactID).
+					Return(&models.SequenceContact{Status: models.SequenceContactStatusCompleted}, nil)
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "error - enrollment not found",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSequenceContact(gomock.Any(), sequenceID, contactID).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.expectCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+			tt.setupMocks()
+
+			_, err := u.PauseEnrollment(newMockCtx(gormDB), sequenceID, contactID)
+			if tt.wantCode == 0 && err != nil {
+				t.Errorf("PauseEnrollment() unexpected error: %v", err)
+			}
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("PauseEnrollment() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
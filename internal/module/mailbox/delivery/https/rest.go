@@ -0,0 +1,276 @@
+package https
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/mailbox"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+)
+
+type mailboxHandler struct {
+	usecase mailbox.Usecase
+}
+
+func NewMailboxHandler(e *echo.Echo, usecase mailbox.Usecase) {
+	h := &mailboxHandler{
+		usecase: usecase,
+	}
+
+	api := e.Group("/api/v1")
+
+	api.POST("/mailbox", h.CreateMailbox)
+	api.GET("/mailboxes", h.ListMailboxes)
+	api.GET("/mailbox/:id", h.GetMailbox)
+	api.PUT("/mailbox/:id", h.UpdateMailbox)
+	api.POST("/mailbox/:id/suspend", h.SuspendMailbox)
+	api.POST("/mailbox/:id/activate", h.ActivateMailbox)
+	api.GET("/mailboxes/usage", h.ListMailboxUsage)
+	api.GET("/mailbox/:id/usage", h.GetMailboxUsage)
+}
+
+// CreateMailbox godoc
+// @Summary      Create a new mailbox
+// @Description  Register a sender account. The SMTP password is stored encrypted and never returned.
+// @Tags         Mailboxes
+// @Accept       json
+// @Produce      json
+// @Param        mailbox  body      dto.CreateMailboxRequest  true  "Mailbox details"
+// @Success      201  {object}  dto.ResponsePattern{data=dto.CreateMailboxResponse}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /mailbox [post]
+func (h *mailboxHandler) CreateMailbox(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.CreateMailboxRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("CreateMailbox - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	resp, err := h.usecase.CreateMailbox(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("CreateMailbox - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	ac.AppLoger.Infof("CreateMailbox - mailbox created with ID: %s", resp.ID)
+	return ac.CustomResponse("Mailbox created successfully", resp, "", "", http.StatusCreated, nil)
+}
+
+// ListMailboxes godoc
+// @Summary      List mailboxes
+// @Description  List mailboxes with pagination, optionally filtered by status
+// @Tags         Mailboxes
+// @Produce      json
+// @Param        page    query     int     false  "Page number"
+// @Param        limit   query     int     false  "Page size"
+// @Param        status  query     string  false  "Mailbox status"  Enums(active, inactive, suspended)
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.Mailbox,meta=dto.PaginationMeta}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /mailboxes [get]
+func (h *mailboxHandler) ListMailboxes(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.ListMailboxesRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("ListMailboxes - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	mailboxes, meta, err := h.usecase.ListMailboxes(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("ListMailboxes - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Mailboxes retrieved successfully", mailboxes, "", "", http.StatusOK, meta)
+}
+
+// GetMailbox godoc
+// @Summary      Get mailbox details
+// @Description  Retrieve a mailbox by its ID
+// @Tags         Mailboxes
+// @Produce      json
+// @Param        id   path      string  true  "Mailbox ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Mailbox}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /mailbox/{id} [get]
+func (h *mailboxHandler) GetMailbox(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	mailboxUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("GetMailbox - invalid mailbox ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid mailbox ID", http.StatusBadRequest, nil)
+	}
+
+	mailboxDetails, err := h.usecase.GetMailbox(c, mailboxUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("GetMailbox - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Mailbox details retrieved successfully", mailboxDetails, "", "", http.StatusOK, nil)
+}
+
+// UpdateMailbox godoc
+// @Summary      Update a mailbox
+// @Description  Update mailbox settings or rotate its SMTP credentials
+// @Tags         Mailboxes
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Mailbox ID"
+// @Param        mailbox  body      dto.UpdateMailboxRequest  true  "Mailbox details to update"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Mailbox}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /mailbox/{id} [put]
+func (h *mailboxHandler) UpdateMailbox(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	mailboxID := c.Param("id")
+	mailboxUUID, err := uuid.Parse(mailboxID)
+	if err != nil {
+		ac.AppLoger.Errorf("UpdateMailbox - invalid mailbox ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid mailbox ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.UpdateMailboxRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("UpdateMailbox - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	mailboxDetails, err := h.usecase.UpdateMailbox(c, mailboxUUID, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("UpdateMailbox - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	ac.AppLoger.Infof("UpdateMailbox - mailbox updated for ID: %s", mailboxID)
+	return ac.CustomResponse("Mailbox updated successfully", mailboxDetails, "", "", http.StatusOK, nil)
+}
+
+// SuspendMailbox godoc
+// @Summary      Suspend a mailbox
+// @Description  Take a mailbox out of sending rotation
+// @Tags         Mailboxes
+// @Produce      json
+// @Param        id   path      string  true  "Mailbox ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Mailbox}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /mailbox/{id}/suspend [post]
+func (h *mailboxHandler) SuspendMailbox(c echo.Context) error {
+	return h.updateStatus(c, "SuspendMailbox", "Mailbox suspended successfully", models.MailboxStatusSuspended)
+}
+
+// ActivateMailbox godoc
+// @Summary      Activate a mailbox
+// @Description  Put a suspended or inactive mailbox back into sending rotation
+// @Tags         Mailboxes
+// @Produce      json
+// @Param        id   path      string  true  "Mailbox ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Mailbox}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /mailbox/{id}/activate [post]
+func (h *mailboxHandler) ActivateMailbox(c echo.Context) error {
+	return h.updateStatus(c, "ActivateMailbox", "Mailbox activated successfully", models.MailboxStatusActive)
+}
+
+func (h *mailboxHandler) updateStatus(c echo.Context, action, message string, status models.MailboxStatus) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	mailboxUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("%s - invalid mailbox ID: %v", action, err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid mailbox ID", http.StatusBadRequest, nil)
+	}
+
+	mailboxDetails, err := h.usecase.UpdateMailboxStatus(c, mailboxUUID, status)
+	if err != nil {
+		ac.AppLoger.Errorf("%s - usecase error: %v", action, err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse(message, mailboxDetails, "", "", http.StatusOK, nil)
+}
+
+// ListMailboxUsage godoc
+// @Summary      List mailbox capacity usage
+// @Description  Daily capacity usage of every mailbox for a UTC day, today by default
+// @Tags         Mailboxes
+// @Produce      json
+// @Param        page   query     int     false  "Page number"
+// @Param        limit  query     int     false  "Page size"
+// @Param        date   query     string  false  "UTC day (YYYY-MM-DD)"
+// @Success      200  {object}  dto.ResponsePattern{data=[]dto.MailboxUsage,meta=dto.PaginationMeta}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /mailboxes/usage [get]
+func (h *mailboxHandler) ListMailboxUsage(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.MailboxUsageRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("ListMailboxUsage - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	usage, meta, err := h.usecase.ListMailboxUsage(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("ListMailboxUsage - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Mailbox usage retrieved successfully", usage, "", "", http.StatusOK, meta)
+}
+
+// GetMailboxUsage godoc
+// @Summary      Get mailbox capacity usage
+// @Description  Daily capacity usage of a mailbox for a UTC day, today by default
+// @Tags         Mailboxes
+// @Produce      json
+// @Param        id    path      string  true   "Mailbox ID"
+// @Param        date  query     string  false  "UTC day (YYYY-MM-DD)"
+// @Success      200  {object}  dto.ResponsePattern{data=dto.MailboxUsage}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /mailbox/{id}/usage [get]
+func (h *mailboxHandler) GetMailboxUsage(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	mailboxUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("GetMailboxUsage - invalid mailbox ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid mailbox ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.MailboxUsageRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("GetMailboxUsage - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	usage, err := h.usecase.GetMailboxUsage(c, mailboxUUID, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("GetMailboxUsage - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Mailbox usage retrieved successfully", usage, "", "", http.StatusOK, nil)
+}
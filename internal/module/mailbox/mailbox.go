@@ -0,0 +1,29 @@
+package mailbox
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"gorm.io/gorm"
+)
+
+type Usecase interface {
+	CreateMailbox(c echo.Context, req *dto.CreateMailboxRequest) (*dto.CreateMailboxResponse, error)
+	GetMailbox(c echo.Context, mailboxID uuid.UUID) (*models.Mailbox, error)
+	ListMailboxes(c echo.Context, req *dto.ListMailboxesRequest) ([]models.Mailbox, *dto.PaginationMeta, error)
+	UpdateMailbox(c echo.Context, mailboxID uuid.UUID, req *dto.UpdateMailboxRequest) (*models.Mailbox, error)
+	UpdateMailboxStatus(c echo.Context, mailboxID uuid.UUID, status models.MailboxStatus) (*models.Mailbox, error)
+	GetMailboxUsage(c echo.Context, mailboxID uuid.UUID, req *dto.MailboxUsageRequest) (*dto.MailboxUsage, error)
+	ListMailboxUsage(c echo.Context, req *dto.MailboxUsageRequest) ([]dto.MailboxUsage, *dto.PaginationMeta, error)
+}
+
+type Repository interface {
+	CreateMailbox(tx *gorm.DB, mailbox *models.Mailbox) (*models.Mailbox, error)
+	GetMailbox(mailboxID uuid.UUID) (*models.Mailbox, error)
+	ListMailboxes(status string, offset, limit int) ([]models.Mailbox, int64, error)
+	UpdateMailbox(tx *gorm.DB, mailbox *models.Mailbox) error
+	ListMailboxUsage(mailboxID *uuid.UUID, day time.Time, offset, limit int) ([]dto.MailboxUsage, int64, error)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/mailbox"
+	"gorm.io/gorm"
+)
+
+type mailboxRepository struct {
+	db *gorm.DB
+}
+
+func NewMailboxRepository(db *gorm.DB) mailbox.Repository {
+	return &mailboxRepository{
+		db: db,
+	}
+}
+
+func (r *mailboxRepository) CreateMailbox(tx *gorm.DB, mailbox *models.Mailbox) (*models.Mailbox, error) {
+	if err := tx.Create(mailbox).Error; err != nil {
+		return nil, err
+	}
+	return mailbox, nil
+}
+
+func (r *mailboxRepository) GetMailbox(mailboxID uuid.UUID) (*models.Mailbox, error) {
+	var mailbox models.Mailbox
+	if err := r.db.First(&mailbox, "id = ?", mailboxID).Error; err != nil {
+		return nil, err
+	}
+	return &mailbox, nil
+}
+
+func (r *mailboxRepository) ListMailboxes(status string, offset, limit int) ([]models.Mailbox, int64, error) {
+	query := r.db.Model(&models.Mailbox{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var mailboxes []models.Mailbox
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&mailboxes).Error; err != nil {
+		return nil, 0, err
+	}
+	return mailboxes, total, nil
+}
+
+func (r *mailboxRepository) UpdateMailbox(tx *gorm.DB, mailbox *models.Mailbox) error {
+	return tx.Save(mailbox).Error
+}
+
+// ListMailboxUsage returns the capacity usage of mailboxes for a UTC day, optionally narrowed to a single mailbox.
+func (r *mailboxRepository) ListMailboxUsage(mailboxID *uuid.UUID, day time.Time, offset, limit int) ([]dto.MailboxUsage, int64, error) {
+	query := r.db.Model(&models.Mailbox{})
+	if mailboxID != nil {
+		query = query.Where("mailboxes.id = ?", *mailboxID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var usage []dto.MailboxUsage
+	if err := query.
+		Select("mailboxes.id AS mailbox_id, mailboxes.email, mailboxes.daily_capacity, COALESCE(mdc.sent_count, 0) AS sent_count, COALESCE(mdc.failed_count, 0) AS failed_count, "+
+			"COALESCE(mdc.hard_bounce_count, 0) AS hard_bounce_count, COALESCE(mdc.soft_bounce_count, 0) AS soft_bounce_count").
+		Joins("LEFT JOIN mailbox_daily_counts mdc ON mdc.mailbox_id = mailboxes.id AND mdc.date = ?", day.Format(time.DateOnly)).
+		Order("mailboxes.created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&usage).Error; err != nil {
+		return nil, 0, err
+	}
+	return usage, total, nil
+}
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/mailbox"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/database"
+	"gorm.io/gorm"
+)
+
+type mailboxUsecase struct {
+	repository mailbox.Repository
+	cipher     crypto.Cipher
+}
+
+func NewMailboxUsecase(repository mailbox.Repository, cipher crypto.Cipher) mailbox.Usecase {
+	return &mailboxUsecase{
+		repository: repository,
+		cipher:     cipher,
+	}
+}
+
+func (u *mailboxUsecase) CreateMailbox(c echo.Context, req *dto.CreateMailboxRequest) (*dto.CreateMailboxResponse, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	encryptedPassword, err := u.cipher.Encrypt([]byte(req.SMTPPassword))
+	if err != nil {
+		ac.AppLoger.Errorf("CreateMailbox - failed to encrypt SMTP password: %v", err)
+		return nil, err
+	}
+
+	mailboxData := &models.Mailbox{
+		Email:                 strings.ToLower(strings.TrimSpace(req.Email)),
+		DailyCapacity:         req.DailyCapacity,
+		Status:                models.MailboxStatusActive,
+		Provider:              req.Provider,
+		SMTPHost:              req.SMTPHost,
+		SMTPPort:              req.SMTPPort,
+		SMTPUsername:          req.SMTPUsername,
+		EncryptedSMTPPassword: encryptedPassword,
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	resp, err := u.repository.CreateMailbox(tx, mailboxData)
+	if database.IsUniqueViolation(err) {
+		ac.AppLoger.Errorf("CreateMailbox - duplicate mailbox: %v", err)
+		return nil, echo.NewHTTPError(http.StatusConflict, "Mailbox with this email already exists")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("CreateMailbox - failed to create mailbox: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("CreateMailbox - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	return &dto.CreateMailboxResponse{
+		ID: resp.ID.String(),
+	}, nil
+}
+
+func (u *mailboxUsecase) GetMailbox(c echo.Context, mailboxID uuid.UUID) (*models.Mailbox, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	mailboxData, err := u.repository.GetMailbox(mailboxID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Error("GetMailbox - mailbox not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Mailbox not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("GetMailbox - failed to fetch mailbox: %v", err)
+		return nil, err
+	}
+	return mailboxData, nil
+}
+
+func (u *mailboxUsecase) ListMailboxes(c echo.Context, req *dto.ListMailboxesRequest) ([]models.Mailbox, *dto.PaginationMeta, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	page, limit := req.Page, req.Limit
+	if page == 0 {
+		page = dto.DefaultPage
+	}
+	if limit == 0 {
+		limit = dto.DefaultLimit
+	}
+
+	mailboxes, total, err := u.repository.ListMailboxes(req.Status, (page-1)*limit, limit)
+	if err != nil {
+		ac.AppLoger.Errorf("ListMailboxes - failed to list mailboxes: %v", err)
+		return nil, nil, err
+	}
+
+	return mailboxes, dto.NewPaginationMeta(page, limit, total), nil
+}
+
+func (u *mailboxUsecase) UpdateMailbox(c echo.Context, mailboxID uuid.UUID, req *dto.UpdateMailboxRequest) (*models.Mailbox, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	existingMailbox, err := u.GetMailbox(c, mailboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DailyCapacity != nil {
+		existingMailbox.DailyCapacity = *req.DailyCapacity
+	}
+
+	if req.Provider != nil {
+		existingMailbox.Provider = *req.Provider
+	}
+
+	if req.SMTPHost != nil {
+		existingMailbox.SMTPHost = *req.SMTPHost
+	}
+
+	if req.SMTPPort != nil {
+		existingMailbox.SMTPPort = *req.SMTPPort
+	}
+
+	if req.SMTPUsername != nil {
+		existingMailbox.SMTPUsername = *req.SMTPUsername
+	}
+
+	if req.SMTPPassword != nil {
+		encryptedPassword, err := u.cipher.Encrypt([]byte(*req.SMTPPassword))
+		if err != nil {
+			ac.AppLoger.Errorf("UpdateMailbox - failed to encrypt SMTP password: %v", err)
+			return nil, err
+		}
+		existingMailbox.EncryptedSMTPPassword = encryptedPassword
+	}
+
+	if err := u.save(c, "UpdateMailbox", existingMailbox); err != nil {
+		return nil, err
+	}
+	return existingMailbox, nil
+}
+
+// UpdateMailboxStatus is used to suspend a sender account or to bring it back into rotation.
+func (u *mailboxUsecase) UpdateMailboxStatus(c echo.Context, mailboxID uuid.UUID, status models.MailboxStatus) (*models.Mailbox, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	existingMailbox, err := u.GetMailbox(c, mailboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingMailbox.Status == status {
+		return existingMailbox, nil
+	}
+	existingMailbox.Status = status
+
+	if err := u.save(c, "UpdateMailboxStatus", existingMailbox); err != nil {
+		return nil, err
+	}
+	ac.AppLoger.Infof("UpdateMailboxStatus - mailbox %s is now %s", mailboxID.String(), status)
+
+	return existingMailbox, nil
+}
+
+func (u *mailboxUsecase) save(c echo.Context, action string, mailboxData *models.Mailbox) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	if err := u.repository.UpdateMailbox(tx, mailboxData); err != nil {
+		ac.AppLoger.Errorf("%s - failed to update mailbox: %v", action, err)
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("%s - failed to commit transaction: %v", action, err)
+		return err
+	}
+	return nil
+}
+
+// GetMailboxUsage returns how much of the mailbox's daily capacity is used on the requested UTC day, today by default.
+func (u *mailboxUsecase) GetMailboxUsage(c echo.Context, mailboxID uuid.UUID, req *dto.MailboxUsageRequest) (*dto.MailboxUsage, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	day, err := parseUsageDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, _, err := u.repository.ListMailboxUsage(&mailboxID, day, 0, 1)
+	if err != nil {
+		ac.AppLoger.Errorf("GetMailboxUsage - failed to fetch mailbox usage: %v", err)
+		return nil, err
+	}
+	if len(usage) == 0 {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Mailbox not found")
+	}
+
+	return withRemaining(&usage[0], day), nil
+}
+
+// ListMailboxUsage returns the capacity usage of every mailbox on the requested UTC day, today by default.
+func (u *mailboxUsecase) ListMailboxUsage(c echo.Context, req *dto.MailboxUsageRequest) ([]dto.MailboxUsage, *dto.PaginationMeta, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	day, err := parseUsageDate(req.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page, limit := req.Page, req.Limit
+	if page == 0 {
+		page = dto.DefaultPage
+	}
+	if limit == 0 {
+		limit = dto.DefaultLimit
+	}
+
+	usage, total, err := u.repository.ListMailboxUsage(nil, day, (page-1)*limit, limit)
+	if err != nil {
+		ac.AppLoger.Errorf("ListMailboxUsage - failed to list mailbox usage: %v", err)
+		return nil, nil, err
+	}
+
+	for i := range usage {
+		withRemaining(&usage[i], day)
+	}
+
+	return usage, dto.NewPaginationMeta(page, limit, total), nil
+}
+
+func parseUsageDate(date string) (time.Time, error) {
+	if date == "" {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return time.Time{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+	}
+	return day, nil
+}
+
+func withRemaining(usage *dto.MailboxUsage, day time.Time) *dto.MailboxUsage {
+	usage.Date = day.Format(time.DateOnly)
+	usage.Remaining = max(usage.DailyCapacity-usage.SentCount, 0)
+	return usage
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	mock_mailbox "github.com/rohanchauhan02/sequence-service/files/mocks/mailbox"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const testKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+func newMockCtx(db *gorm.DB) echo.Context {
+	e := echo.New()
+	return &ctx.CustomApplicationContext{
+		Context:  e.NewContext(nil, nil),
+		Postgres: db,
+		AppLoger: logger.NewLogger(),
+	}
+}
+
+func Test_CreateMailbox(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cipher, err := crypto.NewAESCipher(testKey)
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+
+	mockRepo := mock_mailbox.NewMockRepository(ctrl)
+	u := NewMailboxUsecase(mockRepo, cipher)
+
+	req := &dto.CreateMailboxRequest{
+		Email:        "Sender@Example.com",
+		SMTPHost:     "smtp.example.com",
+		SMTPPort:     587,
+		SMTPUsername: "sender",
+		SMTPPassword: "smtp-secret",
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func()
+		wantCode   int
+		wantErr    bool
+	}{
+		{
+			name: "success - password is stored encrypted",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					CreateMailbox(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, mailbox *models.Mailbox) (*models.Mailbox, error) {
+						plaintext, err := cipher.Decrypt(mailbox.EncryptedSMTPPassword)
+						if err != nil || string(plaintext) != "smtp-secret" {
+							t.Errorf("unexpected encrypted password, decrypted %q: %v", plaintext, err)
+						}
+						if mailbox.Email != "sender@example.com" {
+							t.Errorf("unexpected email: %s", mailbox.Email)
+						}
+
+						body, _ := json.Marshal(mailbox)
+						if strings.Contains(string(body), "password") {
+							t.Errorf("mailbox JSON exposes the password: %s", body)
+						}
+
+						mailbox.ID = uuid.New()
+						return mailbox, nil
+					})
+			},
+		},
+		{
+			name: "error - mailbox email already exists",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					CreateMailbox(gomock.Any(), gomock.Any()).
+					Return(nil, &pgconn.PgError{Code: "23505"})
+			},
+			wantCode: http.StatusConflict,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if !tt.wantErr {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			tt.setupMocks()
+
+			_, err := u.CreateMailbox(newMockCtx(gormDB), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateMailbox() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("CreateMailbox() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_GetMailboxUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_mailbox.NewMockRepository(ctrl)
+	cipher, err := crypto.NewAESCipher(testKey)
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	u := NewMailboxUsecase(mockRepo, cipher)
+
+	mailboxID := uuid.New()
+
+	tests := []struct {
+		name          string
+		req           *dto.MailboxUsageRequest
+		setupMocks    func()
+		wantRemaining int
+		wantStatus    int
+	}{
+		{
+			name: "success - remaining capacity for the requested day",
+			req:  &dto.MailboxUsageRequest{Date: "2025-10-05"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ListMailboxUsage(&mailboxID, gomock.Any(), 0, 1).
+					DoAndReturn(func(_ *uuid.UUID, day time.Time, _, _ int) ([]dto.MailboxUsage, int64, error) {
+						if day.Format(time.DateOnly) != "2025-10-05" {
+							t.Errorf("unexpected usage day: %v", day)
+						}
+						return []dto.MailboxUsage{{MailboxID: mailboxID, DailyCapacity: 30, SentCount: 12, FailedCount: 1}}, 1, nil
+					})
+			},
+			wantRemaining: 18,
+		},
+		{
+			name:       "error - invalid date",
+			req:        &dto.MailboxUsageRequest{Date: "05/10/2025"},
+			setupMocks: func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - mailbox not found",
+			req:  &dto.MailboxUsageRequest{},
+			setupMocks: func() {
+				mockRepo.EXPECT().ListMailboxUsage(&mailboxID, gomock.Any(), 0, 1).Return(nil, int64(0), nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			usage, err := u.GetMailboxUsage(newMockCtx(nil), mailboxID, tt.req)
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("GetMailboxUsage() error = %v, want status %d", err, tt.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetMailboxUsage() unexpected error = %v", err)
+			}
+			if usage.Remaining != tt.wantRemaining || usage.Date != tt.req.Date {
+				t.Errorf("GetMailboxUsage() = %+v, want remaining %d", usage, tt.wantRemaining)
+			}
+		})
+	}
+}
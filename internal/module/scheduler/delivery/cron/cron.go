@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/module/scheduler"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+)
+
+const defaultInterval = time.Minute
+
+var log = logger.NewLogger("SCHEDULER-CRON")
+
+type schedulerCron struct {
+	usecase  scheduler.Usecase
+	interval time.Duration
+}
+
+// NewSchedulerCron runs the scheduler on a fixed interval until ctx is cancelled.
+func NewSchedulerCron(ctx context.Context, conf config.ImmutableConfig, usecase scheduler.Usecase) {
+	interval := time.Duration(conf.GetSchedulerConf().IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	c := &schedulerCron{
+		usecase:  usecase,
+		interval: interval,
+	}
+
+	go c.run(ctx)
+}
+
+func (c *schedulerCron) run(ctx context.Context) {
+	log.Infof("Scheduler started with interval %s", c.interval)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Scheduler stopped")
+			return
+		case <-ticker.C:
+			c.tick(ctx)
+		}
+	}
+}
+
+func (c *schedulerCron) tick(ctx context.Context) {
+	if _, err := c.usecase.ScheduleDueContacts(ctx); err != nil {
+		log.Errorf("ScheduleDueContacts failed: %v", err)
+	}
+
+	// Recovered emails are scheduled again, so they are dispatched in the same tick.
+	if _, err := c.usecase.RecoverStaleEmails(ctx); err != nil {
+		log.Errorf("RecoverStaleEmails failed: %v", err)
+	}
+
+	if _, err := c.usecase.DispatchDueEmails(ctx); err != nil {
+		log.Errorf("DispatchDueEmails failed: %v", err)
+	}
+}
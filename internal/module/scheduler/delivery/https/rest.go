@@ -1,8 +1,11 @@
 package https
 
 import (
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 	"github.com/rohanchauhan02/sequence-service/internal/module/scheduler"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
 )
 
 type schedulerHandler struct {
@@ -10,8 +13,54 @@ type schedulerHandler struct {
 }
 
 func NewSchedulerHandler(e *echo.Echo, usecase scheduler.Usecase) {
-	h := schedulerHandler{
+	h := &schedulerHandler{
 		usecase: usecase,
 	}
-	_ = h
+
+	api := e.Group("/api/v1")
+
+	api.POST("/scheduler/run", h.Run)
+	api.POST("/scheduler/dispatch", h.Dispatch)
+}
+
+// Run godoc
+// @Summary      Run the scheduler
+// @Description  Queue the next step for every enrollment whose next send time has passed
+// @Tags         Scheduler
+// @Produce      json
+// @Success      200  {object}  dto.ResponsePattern{data=dto.ScheduleDueContactsResponse}
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /scheduler/run [post]
+func (h *schedulerHandler) Run(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	resp, err := h.usecase.ScheduleDueContacts(c.Request().Context())
+	if err != nil {
+		ac.AppLoger.Errorf("Run - usecase error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusInternalServerError), nil, "", err.Error(), http.StatusInternalServerError, nil)
+	}
+
+	ac.AppLoger.Infof("Run - scheduled: %d, completed: %d", resp.Scheduled, resp.Completed)
+	return ac.CustomResponse("Scheduler run completed", resp, "", "", http.StatusOK, nil)
+}
+
+// Dispatch godoc
+// @Summary      Dispatch due emails
+// @Description  Publish scheduled emails that are due to the email-jobs topic
+// @Tags         Scheduler
+// @Produce      json
+// @Success      200  {object}  dto.ResponsePattern{data=dto.DispatchDueEmailsResponse}
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /scheduler/dispatch [post]
+func (h *schedulerHandler) Dispatch(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	resp, err := h.usecase.DispatchDueEmails(c.Request().Context())
+	if err != nil {
+		ac.AppLoger.Errorf("Dispatch - usecase error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusInternalServerError), nil, "", err.Error(), http.StatusInternalServerError, nil)
+	}
+
+	ac.AppLoger.Infof("Dispatch - dispatched: %d, batches: %d", resp.Dispatched, resp.Batches)
+	return ac.CustomResponse("Dispatch completed", resp, "", "", http.StatusOK, nil)
 }
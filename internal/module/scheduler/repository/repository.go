@@ -1,8 +1,16 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
 	"github.com/rohanchauhan02/sequence-service/internal/module/scheduler"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/capacity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type schedulerRepository struct {
@@ -14,3 +22,145 @@ func NewSchedulerRepository(db *gorm.DB) scheduler.Repository {
 		db: db,
 	}
 }
+
+// GetDueSequenceContacts locks up to limit in-progress enrollments of active sequences whose next_send_at
+// has passed. Rows already locked by another scheduler instance are skipped.
+func (r *schedulerRepository) GetDueSequenceContacts(tx *gorm.DB, now time.Time, limit int) ([]models.SequenceContact, error) {
+	var sequenceContacts []models.SequenceContact
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_send_at <= ?", models.SequenceContactStatusInProgress, now).
+		Where("sequence_id IN (SELECT id FROM sequences WHERE status = ? AND deleted_at IS NULL)", models.SequenceStatusActive).
+		Order("next_send_at ASC").
+		Limit(limit).
+		Find(&sequenceContacts).Error; err != nil {
+		return nil, err
+	}
+	return sequenceContacts, nil
+}
+
+// GetContactsByIDs returns the contacts to render merge fields against, including soft deleted ones.
+func (r *schedulerRepository) GetContactsByIDs(tx *gorm.DB, contactIDs []uuid.UUID) ([]models.Contact, error) {
+	var contacts []models.Contact
+	if len(contactIDs) == 0 {
+		return contacts, nil
+	}
+	if err := tx.Unscoped().Where("id IN ?", contactIDs).Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// GetNextSteps returns up to limit steps after afterStepOrder from the given version of a sequence, so an
+// enrollment keeps receiving the steps it started with. A missing version has no steps.
+func (r *schedulerRepository) GetNextSteps(tx *gorm.DB, sequenceID uuid.UUID, version int, afterStepOrder int, limit int) ([]models.Step, error) {
+	var sequenceVersion models.SequenceVersion
+	err := tx.Where("sequence_id = ? AND version = ?", sequenceID, version).First(&sequenceVersion).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sequenceVersion.NextSteps(afterStepOrder, limit), nil
+}
+
+func (r *schedulerRepository) CreateEmailQueue(tx *gorm.DB, emailQueue *models.EmailQueue) error {
+	return tx.Create(emailQueue).Error
+}
+
+func (r *schedulerRepository) UpdateSequenceContact(tx *gorm.DB, sequenceContact *models.SequenceContact) error {
+	return tx.Save(sequenceContact).Error
+}
+
+// GetSequenceMailboxLoads returns the active mailboxes assigned to a sequence together with the number
+// of emails already reserved on each of them for the given UTC day.
+func (r *schedulerRepository) GetSequenceMailboxLoads(tx *gorm.DB, sequenceID uuid.UUID, day time.Time) ([]dto.MailboxLoad, error) {
+	var loads []dto.MailboxLoad
+	if err := tx.Table("sequence_mailboxes AS sm").
+		Select("m.id AS mailbox_id, m.email, m.daily_capacity, COALESCE(mdc.sent_count, 0) AS queued").
+		Joins("JOIN mailboxes m ON m.id = sm.mailbox_id").
+		Joins("LEFT JOIN mailbox_daily_counts mdc ON mdc.mailbox_id = m.id AND mdc.date = ?", day.Format(time.DateOnly)).
+		Where("sm.sequence_id = ? AND m.status = ? AND m.deleted_at IS NULL", sequenceID, models.MailboxStatusActive).
+		Order("sm.created_at ASC").
+		Scan(&loads).Error; err != nil {
+		return nil, err
+	}
+	return loads, nil
+}
+
+// ReserveMailboxCapacity atomically takes one slot of the mailbox's capacity for the given UTC day.
+// It reports false when the cap is already reached.
+func (r *schedulerRepository) ReserveMailboxCapacity(tx *gorm.DB, mailboxID uuid.UUID, day time.Time) (bool, error) {
+	return capacity.Reserve(tx, mailboxID, day)
+}
+
+// GetDueEmailJobs locks up to limit scheduled emails of active, not deleted sequences that are due and
+// joins in the sender and recipient addresses and the tracking flags of the sequence. Emails of paused
+// enrollments are left scheduled until the enrollment is resumed.
+func (r *schedulerRepository) GetDueEmailJobs(tx *gorm.DB, now time.Time, limit int) ([]dto.EmailJob, error) {
+	var jobs []dto.EmailJob
+	if err := tx.Table("email_queues AS eq").
+		Select(`eq.id AS email_queue_id, eq.sequence_contact_id, eq.mailbox_id, m.email AS "from", c.email AS "to", eq.subject, eq.content, s.open_tracking_enabled, s.click_tracking_enabled`).
+		Joins("JOIN sequence_contacts sc ON sc.id = eq.sequence_contact_id").
+		Joins("JOIN contacts c ON c.id = sc.contact_id").
+		Joins("JOIN sequences s ON s.id = sc.sequence_id").
+		Joins("LEFT JOIN mailboxes m ON m.id = eq.mailbox_id").
+		Where("eq.status = ? AND eq.scheduled_for <= ?", models.EmailQueueStatusScheduled, now).
+		Where("s.status = ? AND s.deleted_at IS NULL", models.SequenceStatusActive).
+		Where("sc.status IN ?", []models.SequenceContactStatus{models.SequenceContactStatusPending, models.SequenceContactStatusInProgress}).
+		Order("eq.scheduled_for ASC").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "eq"}, Options: "SKIP LOCKED"}).
+		Scan(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *schedulerRepository) MarkEmailsQueued(tx *gorm.DB, emailQueueIDs []uuid.UUID) error {
+	return tx.Model(&models.EmailQueue{}).
+		Where("id IN ?", emailQueueIDs).
+		Update("status", models.EmailQueueStatusQueued).Error
+}
+
+// MarkEmailsScheduled puts queued emails whose batch could not be published back to scheduled.
+func (r *schedulerRepository) MarkEmailsScheduled(tx *gorm.DB, emailQueueIDs []uuid.UUID) error {
+	return tx.Model(&models.EmailQueue{}).
+		Where("id IN ? AND status = ?", emailQueueIDs, models.EmailQueueStatusQueued).
+		Update("status", models.EmailQueueStatusScheduled).Error
+}
+
+// RequeueStaleEmails puts emails that were queued before staleBefore and never claimed by the sender
+// back to scheduled, e.g. when the process stopped between committing and publishing their batch.
+func (r *schedulerRepository) RequeueStaleEmails(tx *gorm.DB, staleBefore time.Time) (int64, error) {
+	result := tx.Model(&models.EmailQueue{}).
+		Where("status = ? AND updated_at < ?", models.EmailQueueStatusQueued, staleBefore).
+		Update("status", models.EmailQueueStatusScheduled)
+	return result.RowsAffected, result.Error
+}
+
+// RetryStaleSendingEmails puts emails claimed for sending before staleBefore, whose outcome was never
+// recorded, back to scheduled as a failed attempt, as long as max_retries is not exhausted.
+func (r *schedulerRepository) RetryStaleSendingEmails(tx *gorm.DB, staleBefore time.Time, errorMessage string) (int64, error) {
+	result := tx.Exec(`UPDATE email_queues
+		SET status = ?, retry_count = retry_count + 1, error_message = ?, updated_at = NOW()
+		WHERE status = ? AND last_attempt_at < ? AND retry_count < max_retries`,
+		models.EmailQueueStatusScheduled, errorMessage, models.EmailQueueStatusSending, staleBefore)
+	return result.RowsAffected, result.Error
+}
+
+// FailStaleSendingEmails marks emails claimed for sending before staleBefore whose outcome was never
+// recorded failed once max_retries is exhausted. Their reserved capacity is given back and the failure
+// is counted against their mailbox.
+func (r *schedulerRepository) FailStaleSendingEmails(tx *gorm.DB, staleBefore time.Time, errorMessage string) (int64, error) {
+	if err := capacity.ReleaseFailed(tx, "status = ? AND last_attempt_at < ? AND retry_count >= max_retries",
+		models.EmailQueueStatusSending, staleBefore); err != nil {
+		return 0, err
+	}
+
+	result := tx.Exec(`UPDATE email_queues
+		SET status = ?, error_message = ?, updated_at = NOW()
+		WHERE status = ? AND last_attempt_at < ? AND retry_count >= max_retries`,
+		models.EmailQueueStatusFailed, errorMessage, models.EmailQueueStatusSending, staleBefore)
+	return result.RowsAffected, result.Error
+}
@@ -1,7 +1,35 @@
 package scheduler
 
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"gorm.io/gorm"
+)
+
 type Usecase interface {
+	ScheduleDueContacts(ctx context.Context) (*dto.ScheduleDueContactsResponse, error)
+	DispatchDueEmails(ctx context.Context) (*dto.DispatchDueEmailsResponse, error)
+	RecoverStaleEmails(ctx context.Context) (*dto.RecoverStaleEmailsResponse, error)
 }
 
 type Repository interface {
+	GetDueSequenceContacts(tx *gorm.DB, now time.Time, limit int) ([]models.SequenceContact, error)
+	GetContactsByIDs(tx *gorm.DB, contactIDs []uuid.UUID) ([]models.Contact, error)
+	GetNextSteps(tx *gorm.DB, sequenceID uuid.UUID, version int, afterStepOrder int, limit int) ([]models.Step, error)
+	CreateEmailQueue(tx *gorm.DB, emailQueue *models.EmailQueue) error
+	UpdateSequenceContact(tx *gorm.DB, sequenceContact *models.SequenceContact) error
+	GetSequenceMailboxLoads(tx *gorm.DB, sequenceID uuid.UUID, day time.Time) ([]dto.MailboxLoad, error)
+	ReserveMailboxCapacity(tx *gorm.DB, mailboxID uuid.UUID, day time.Time) (bool, error)
+
+	GetDueEmailJobs(tx *gorm.DB, now time.Time, limit int) ([]dto.EmailJob, error)
+	MarkEmailsQueued(tx *gorm.DB, emailQueueIDs []uuid.UUID) error
+	MarkEmailsScheduled(tx *gorm.DB, emailQueueIDs []uuid.UUID) error
+
+	RequeueStaleEmails(tx *gorm.DB, staleBefore time.Time) (int64, error)
+	RetryStaleSendingEmails(tx *gorm.DB, staleBefore time.Time, errorMessage string) (int64, error)
+	FailStaleSendingEmails(tx *gorm.DB, staleBefore time.Time, errorMessage string) (int64, error)
 }
@@ -1,13 +1,495 @@
 package usecase
 
-import "github.com/rohanchauhan02/sequence-service/internal/module/scheduler"
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/scheduler"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/merge"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/sendwindow"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/tracking"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/kafka"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultBatchSize = 100
+	// maxDeferralDays bounds how far ahead an email is pushed when every mailbox of its sequence is at capacity.
+	maxDeferralDays = 7
+	// emailJobBatchSize is the number of emails packed into a single email-jobs message.
+	emailJobBatchSize = 50
+	// staleEmailAfter is how long an email may stay queued or sending before it is considered abandoned.
+	staleEmailAfter = time.Hour
+	// staleSendingMessage is recorded on emails whose send outcome was lost.
+	staleSendingMessage = "send outcome was not recorded"
+)
+
+var log = logger.NewLogger("SCHEDULER")
 
 type schedulerUsecase struct {
-	repo scheduler.Repository
+	repo      scheduler.Repository
+	db        *gorm.DB
+	kafka     kafka.KafkaClient
+	tracker   tracking.Tracker
+	topics    config.Topic
+	batchSize int
 }
 
-func NewSchedulerUsecase(repo scheduler.Repository) scheduler.Usecase {
+func NewSchedulerUsecase(repo scheduler.Repository, db *gorm.DB, conf config.ImmutableConfig, kafkaClient kafka.KafkaClient, tracker tracking.Tracker) scheduler.Usecase {
+	batchSize := conf.GetSchedulerConf().BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
 	return &schedulerUsecase{
-		repo: repo,
+		repo:      repo,
+		db:        db,
+		kafka:     kafkaClient,
+		tracker:   tracker,
+		topics:    conf.GetKafkaConf().Topics,
+		batchSize: batchSize,
+	}
+}
+
+// ScheduleDueContacts materializes the next step of every due enrollment into an email_queues row
+// and advances the enrollment to the step after it. Enrollments without further steps are completed.
+func (u *schedulerUsecase) ScheduleDueContacts(ctx context.Context) (*dto.ScheduleDueContactsResponse, error) {
+	now := time.Now()
+	resp := &dto.ScheduleDueContactsResponse{}
+
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	sequenceContacts, err := u.repo.GetDueSequenceContacts(tx, now, u.batchSize)
+	if err != nil {
+		log.Errorf("ScheduleDueContacts - failed to fetch due sequence contacts: %v", err)
+		return nil, err
+	}
+
+	contacts, err := u.getContacts(tx, sequenceContacts)
+	if err != nil {
+		log.Errorf("ScheduleDueContacts - failed to fetch contacts: %v", err)
+		return nil, err
+	}
+
+	senders := newSenderRotation(u.repo, tx)
+
+	for i := range sequenceContacts {
+		sequenceContact := &sequenceContacts[i]
+
+		// The step to send now and the one after it, which decides the next send time.
+		steps, err := u.repo.GetNextSteps(tx, sequenceContact.SequenceID, sequenceContact.Version, sequenceContact.CurrentStep, 2)
+		if err != nil {
+			log.Errorf("ScheduleDueContacts - failed to fetch steps for sequence contact %s: %v", sequenceContact.ID, err)
+			return nil, err
+		}
+
+		contact := contacts[sequenceContact.ContactID]
+		loc := contactLocation(contact)
+
+		sendAt := now
+		if len(steps) > 0 {
+			step := steps[0]
+			window := stepWindow(&step)
+			if window != nil {
+				if opensAt := window.Next(now, loc); opensAt.After(now) {
+					if err := u.reschedule(tx, sequenceContact, opensAt); err != nil {
+						return nil, err
+					}
+					resp.Rescheduled++
+					continue
+				}
+			}
+
+			mailboxID, scheduledFor, err := senders.next(sequenceContact.SequenceID, now, window, loc)
+			if err != nil {
+				log.Errorf("ScheduleDueContacts - failed to pick a mailbox for sequence %s: %v", sequenceContact.SequenceID, err)
+				return nil, err
+			}
+			if scheduledFor == nil {
+				// Keep the enrollment from being picked again on every run and starving the others.
+				retryAt := truncateToDay(now).AddDate(0, 0, maxDeferralDays+1)
+				log.Warnf("ScheduleDueContacts - all mailboxes of sequence %s are at capacity for the next %d days, moving sequence contact %s to %v", sequenceContact.SequenceID, maxDeferralDays, sequenceContact.ID, retryAt)
+				if err := u.reschedule(tx, sequenceContact, retryAt); err != nil {
+					return nil, err
+				}
+				resp.Rescheduled++
+				continue
+			}
+			sendAt = *scheduledFor
+
+			emailQueue := &models.EmailQueue{
+				SequenceContactID: sequenceContact.ID,
+				MailboxID:         mailboxID,
+				StepOrder:         step.StepOrder,
+				Subject:           renderTemplate(step.Subject, contact, nil),
+				Content:           renderTemplate(step.Content, contact, html.EscapeString),
+				ScheduledFor:      sendAt,
+				Status:            models.EmailQueueStatusScheduled,
+			}
+			if err := u.repo.CreateEmailQueue(tx, emailQueue); err != nil {
+				log.Errorf("ScheduleDueContacts - failed to create email queue for sequence contact %s: %v", sequenceContact.ID, err)
+				return nil, err
+			}
+			sequenceContact.CurrentStep = step.StepOrder
+			resp.Scheduled++
+			if sendAt.After(now) {
+				resp.Deferred++
+			}
+		}
+
+		if len(steps) > 1 {
+			nextSendAt := steps[1].SendAfter(sendAt)
+			if window := stepWindow(&steps[1]); window != nil {
+				nextSendAt = window.Next(nextSendAt, loc)
+			}
+			sequenceContact.NextSendAt = &nextSendAt
+		} else {
+			sequenceContact.Status = models.SequenceContactStatusCompleted
+			sequenceContact.NextSendAt = nil
+			sequenceContact.CompletedAt = &now
+			resp.Completed++
+		}
+
+		if err := u.repo.UpdateSequenceContact(tx, sequenceContact); err != nil {
+			log.Errorf("ScheduleDueContacts - failed to update sequence contact %s: %v", sequenceContact.ID, err)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Errorf("ScheduleDueContacts - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	if len(sequenceContacts) > 0 {
+		log.Infof("ScheduleDueContacts - scheduled: %d, deferred: %d, completed: %d, rescheduled: %d", resp.Scheduled, resp.Deferred, resp.Completed, resp.Rescheduled)
+	}
+	return resp, nil
+}
+
+// reschedule moves an enrollment to a later next_send_at without sending its step.
+func (u *schedulerUsecase) reschedule(tx *gorm.DB, sequenceContact *models.SequenceContact, nextSendAt time.Time) error {
+	sequenceContact.NextSendAt = &nextSendAt
+	if err := u.repo.UpdateSequenceContact(tx, sequenceContact); err != nil {
+		log.Errorf("ScheduleDueContacts - failed to update sequence contact %s: %v", sequenceContact.ID, err)
+		return err
+	}
+	return nil
+}
+
+// getContacts fetches the contacts of the enrollments keyed by ID.
+func (u *schedulerUsecase) getContacts(tx *gorm.DB, sequenceContacts []models.SequenceContact) (map[uuid.UUID]*models.Contact, error) {
+	contactIDs := make([]uuid.UUID, 0, len(sequenceContacts))
+	for _, sequenceContact := range sequenceContacts {
+		contactIDs = append(contactIDs, sequenceContact.ContactID)
+	}
+
+	contacts, err := u.repo.GetContactsByIDs(tx, contactIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*models.Contact, len(contacts))
+	for i := range contacts {
+		byID[contacts[i].ID] = &contacts[i]
+	}
+	return byID, nil
+}
+
+// renderTemplate fills in the merge fields of a step subject or content for a contact. Templates
+// saved before merge fields were validated are sent as they are when they fail to parse.
+func renderTemplate(text string, contact *models.Contact, escape func(string) string) string {
+	tmpl, err := merge.Parse(text)
+	if err != nil {
+		log.Warnf("renderTemplate - invalid template, sending it unrendered: %v", err)
+		return text
 	}
+
+	values := map[string]string{}
+	if contact != nil {
+		values = map[string]string{
+			"first_name": contact.FirstName,
+			"last_name":  contact.LastName,
+			"email":      contact.Email,
+			"company":    contact.Company,
+			"phone":      contact.Phone,
+		}
+	}
+	return tmpl.Render(values, escape)
+}
+
+// contactLocation returns the timezone of a contact, UTC when it is not set or unknown.
+func contactLocation(contact *models.Contact) *time.Location {
+	if contact == nil || contact.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(contact.Timezone)
+	if err != nil {
+		log.Warnf("contactLocation - unknown timezone %q of contact %s, using UTC: %v", contact.Timezone, contact.ID, err)
+		return time.UTC
+	}
+	return loc
+}
+
+// stepWindow returns the send window of a step, nil when it has none or it cannot be parsed.
+func stepWindow(step *models.Step) *sendwindow.Window {
+	if step.SendWindowStart == nil || step.SendWindowEnd == nil {
+		return nil
+	}
+	window, err := sendwindow.New(*step.SendWindowStart, *step.SendWindowEnd, step.SendWindowDays)
+	if err != nil {
+		log.Warnf("stepWindow - ignoring invalid send window of step %s: %v", step.ID, err)
+		return nil
+	}
+	return window
+}
+
+// DispatchDueEmails publishes due scheduled emails to the email-jobs topic in batches and marks them queued.
+// Every email gets a signed unsubscribe link, and open and click tracking is added to the content
+// according to the flags of each sequence.
+// The rows stay scheduled if publishing fails, so they are picked up again on the next run.
+func (u *schedulerUsecase) DispatchDueEmails(ctx context.Context) (*dto.DispatchDueEmailsResponse, error) {
+	resp := &dto.DispatchDueEmailsResponse{}
+
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	jobs, err := u.repo.GetDueEmailJobs(tx, time.Now(), u.batchSize)
+	if err != nil {
+		log.Errorf("DispatchDueEmails - failed to fetch due emails: %v", err)
+		return nil, err
+	}
+
+	if len(jobs) == 0 {
+		return resp, nil
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		unsubscribeURL, err := u.tracker.UnsubscribeURL(job.EmailQueueID)
+		if err != nil {
+			log.Errorf("DispatchDueEmails - failed to sign unsubscribe link of email %s: %v", job.EmailQueueID, err)
+			return nil, err
+		}
+		job.UnsubscribeURL = unsubscribeURL
+
+		content := tracking.AddUnsubscribeLink(job.Content, unsubscribeURL)
+		content, err = u.tracker.Instrument(job.EmailQueueID, content, job.OpenTrackingEnabled, job.ClickTrackingEnabled)
+		if err != nil {
+			log.Errorf("DispatchDueEmails - failed to add tracking to email %s: %v", job.EmailQueueID, err)
+			return nil, err
+		}
+		job.Content = content
+	}
+
+	var messages [][]byte
+	emailQueueIDs := make([]uuid.UUID, 0, len(jobs))
+	for start := 0; start < len(jobs); start += emailJobBatchSize {
+		end := min(start+emailJobBatchSize, len(jobs))
+
+		batch := &dto.EmailJobBatch{
+			BatchID:    uuid.New(),
+			Emails:     jobs[start:end],
+			ProducedAt: time.Now(),
+		}
+		message, err := json.Marshal(batch)
+		if err != nil {
+			log.Errorf("DispatchDueEmails - failed to marshal batch %s: %v", batch.BatchID, err)
+			return nil, err
+		}
+		messages = append(messages, message)
+
+		for _, job := range batch.Emails {
+			emailQueueIDs = append(emailQueueIDs, job.EmailQueueID)
+		}
+	}
+
+	// The emails are committed as queued before they are published: the sender only claims queued
+	// emails, so a batch consumed before the commit would be skipped and never sent.
+	if err := u.repo.MarkEmailsQueued(tx, emailQueueIDs); err != nil {
+		log.Errorf("DispatchDueEmails - failed to mark emails queued: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Errorf("DispatchDueEmails - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	for i, message := range messages {
+		if err := u.kafka.Publish(u.topics.EmailJobs, message); err != nil {
+			log.Errorf("DispatchDueEmails - failed to publish batch %d of %d: %v", i+1, len(messages), err)
+			u.unqueue(ctx, emailQueueIDs[resp.Dispatched:])
+			return nil, err
+		}
+		resp.Batches++
+		resp.Dispatched = min((i+1)*emailJobBatchSize, len(emailQueueIDs))
+	}
+
+	log.Infof("DispatchDueEmails - dispatched: %d, batches: %d", resp.Dispatched, resp.Batches)
+	return resp, nil
+}
+
+// unqueue puts emails whose batch was not published back to scheduled so the next run dispatches them.
+// When that fails too they are recovered by RecoverStaleEmails.
+func (u *schedulerUsecase) unqueue(ctx context.Context, emailQueueIDs []uuid.UUID) {
+	if err := u.repo.MarkEmailsScheduled(u.db.WithContext(ctx), emailQueueIDs); err != nil {
+		log.Errorf("DispatchDueEmails - failed to put %d unpublished emails back to scheduled: %v", len(emailQueueIDs), err)
+	}
+}
+
+// RecoverStaleEmails picks up emails a dispatcher or sender left behind when it stopped midway. Emails
+// queued for longer than staleEmailAfter are dispatched again; the sender claims each email once, so a
+// batch still waiting in Kafka does not send it twice. Emails claimed for sending longer ago whose
+// outcome was lost count as a failed attempt: they are retried, which delivers them twice if the mail
+// server had accepted them, or marked failed once max_retries is exhausted.
+func (u *schedulerUsecase) RecoverStaleEmails(ctx context.Context) (*dto.RecoverStaleEmailsResponse, error) {
+	staleBefore := time.Now().Add(-staleEmailAfter)
+	resp := &dto.RecoverStaleEmailsResponse{}
+
+	tx := u.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	var err error
+	if resp.Requeued, err = u.repo.RequeueStaleEmails(tx, staleBefore); err != nil {
+		log.Errorf("RecoverStaleEmails - failed to requeue stale queued emails: %v", err)
+		return nil, err
+	}
+
+	// Exhausted emails are failed before the others are retried, which uses up a retry.
+	if resp.Failed, err = u.repo.FailStaleSendingEmails(tx, staleBefore, staleSendingMessage); err != nil {
+		log.Errorf("RecoverStaleEmails - failed to fail stale sending emails: %v", err)
+		return nil, err
+	}
+	if resp.Retried, err = u.repo.RetryStaleSendingEmails(tx, staleBefore, staleSendingMessage); err != nil {
+		log.Errorf("RecoverStaleEmails - failed to retry stale sending emails: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Errorf("RecoverStaleEmails - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	if resp.Requeued+resp.Retried+resp.Failed > 0 {
+		log.Warnf("RecoverStaleEmails - requeued: %d, retried: %d, failed: %d", resp.Requeued, resp.Retried, resp.Failed)
+	}
+	return resp, nil
+}
+
+// senderRotation spreads the emails of a scheduling run across the active mailboxes of each sequence.
+// Loads are fetched once per sequence and day, and the least loaded mailbox with spare capacity is
+// picked for every email. Capacity is reserved in mailbox_daily_counts, which is the source of truth
+// when several schedulers run concurrently.
+type senderRotation struct {
+	repo  scheduler.Repository
+	tx    *gorm.DB
+	loads map[rotationKey][]dto.MailboxLoad
+}
+
+type rotationKey struct {
+	sequenceID uuid.UUID
+	day        string
+}
+
+func newSenderRotation(repo scheduler.Repository, tx *gorm.DB) *senderRotation {
+	return &senderRotation{
+		repo:  repo,
+		tx:    tx,
+		loads: make(map[rotationKey][]dto.MailboxLoad),
+	}
+}
+
+// next returns the mailbox to send the next email of the sequence from and when to send it.
+// The email goes out at now unless every mailbox is at capacity, in which case it is deferred to the
+// first opening of the send window, if any, on a later UTC day with spare capacity. Capacity is
+// reserved for the UTC day of the final send time. Without assigned mailboxes it returns a nil mailbox
+// and now. Both are nil when no capacity is left within maxDeferralDays.
+func (r *senderRotation) next(sequenceID uuid.UUID, now time.Time, window *sendwindow.Window, loc *time.Location) (*uuid.UUID, *time.Time, error) {
+	horizon := truncateToDay(now).AddDate(0, 0, maxDeferralDays)
+	candidate := now
+	for {
+		sendAt := candidate
+		if window != nil {
+			sendAt = window.Next(candidate, loc)
+		}
+		day := truncateToDay(sendAt)
+		if day.After(horizon) {
+			return nil, nil, nil
+		}
+
+		loads, err := r.dayLoads(sequenceID, day)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(loads) == 0 {
+			return nil, &sendAt, nil
+		}
+
+		mailboxID, err := r.reserve(loads, day)
+		if err != nil {
+			return nil, nil, err
+		}
+		if mailboxID != nil {
+			return mailboxID, &sendAt, nil
+		}
+		candidate = day.AddDate(0, 0, 1)
+	}
+}
+
+func (r *senderRotation) dayLoads(sequenceID uuid.UUID, day time.Time) ([]dto.MailboxLoad, error) {
+	key := rotationKey{sequenceID: sequenceID, day: day.Format(time.DateOnly)}
+	if loads, ok := r.loads[key]; ok {
+		return loads, nil
+	}
+	loads, err := r.repo.GetSequenceMailboxLoads(r.tx, sequenceID, day)
+	if err != nil {
+		return nil, err
+	}
+	r.loads[key] = loads
+	return loads, nil
+}
+
+// reserve tries the mailboxes from least to most loaded until one has capacity left for the day.
+func (r *senderRotation) reserve(loads []dto.MailboxLoad, day time.Time) (*uuid.UUID, error) {
+	for {
+		picked := -1
+		for i := range loads {
+			if loads[i].Queued >= loads[i].DailyCapacity {
+				continue
+			}
+			if picked == -1 || loads[i].Queued < loads[picked].Queued {
+				picked = i
+			}
+		}
+		if picked == -1 {
+			return nil, nil
+		}
+
+		reserved, err := r.repo.ReserveMailboxCapacity(r.tx, loads[picked].MailboxID, day)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved {
+			// Another scheduler used up the remaining capacity.
+			loads[picked].Queued = loads[picked].DailyCapacity
+			continue
+		}
+
+		loads[picked].Queued++
+		mailboxID := loads[picked].MailboxID
+		return &mailboxID, nil
+	}
+}
+
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 }
@@ -0,0 +1,664 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_config "github.com/rohanchauhan02/sequence-service/files/mocks/config"
+	mock_kafka "github.com/rohanchauhan02/sequence-service/files/mocks/kafka"
+	mock_scheduler "github.com/rohanchauhan02/sequence-service/files/mocks/scheduler"
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/sendwindow"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/tracking"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newTestTracker(t *testing.T) tracking.Tracker {
+	signer, err := crypto.NewHMACSigner("c2VxdWVuY2Utc2VydmljZS1zaWduaW5nLWtleS0zMmI=")
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	return tracking.NewTracker("https://track.example.com", signer)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func Test_ScheduleDueContacts(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	mockConf.EXPECT().GetSchedulerConf().Return(config.Scheduler{BatchSize: 10})
+	mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailJobs: "email-jobs"}})
+
+	mockRepo := mock_scheduler.NewMockRepository(ctrl)
+	u := NewSchedulerUsecase(mockRepo, gormDB, mockConf, mock_kafka.NewMockKafkaClient(ctrl), newTestTracker(t))
+
+	sequenceID := uuid.New()
+	busyMailbox, idleMailbox := uuid.New(), uuid.New()
+	contactID := uuid.New()
+
+	tests := []struct {
+		name            string
+		setupMocks      func()
+		wantErr         bool
+		wantScheduled   int
+		wantDeferred    int
+		wantCompleted   int
+		wantRescheduled int
+	}{
+		{
+			name: "success - queue step and advance to next step",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetDueSequenceContacts(gomock.Any(), gomock.Any(), 10).
+					Return([]models.SequenceContact{{ID: uuid.New(), SequenceID: sequenceID, ContactID: contactID, Version: 2, CurrentStep: 1, Status: models.SequenceContactStatusInProgress}}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs(gomock.Any(), []uuid.UUID{contactID}).
+					Return([]models.Contact{{ID: contactID, FirstName: "Ada", Company: "R&D"}}, nil)
+				mockRepo.EXPECT().
+					GetNextSteps(gomock.Any(), sequenceID, 2, 1, 2).
+					Return([]models.Step{{StepOrder: 2, Subject: "Hi {{first_name}}", Content: `<p>{{company}} {{phone | "n/a"}}</p>`}, {StepOrder: 3, WaitDays: 2}}, nil)
+				mockRepo.EXPECT().
+					GetSequenceMailboxLoads(gomock.Any(), sequenceID, gomock.Any()).
+					Return([]dto.MailboxLoad{{MailboxID: busyMailbox, DailyCapacity: 30, Queued: 5}, {MailboxID: idleMailbox, DailyCapacity: 30, Queued: 1}}, nil)
+				mockRepo.EXPECT().
+					ReserveMailboxCapacity(gomock.Any(), idleMailbox, gomock.Any()).
+					Return(true, nil)
+				mockRepo.EXPECT().
+					CreateEmailQueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailQueue *models.EmailQueue) error {
+						if emailQueue.Subject != "Hi Ada" || emailQueue.Content != "<p>R&amp;D n/a</p>" {
+							t.Errorf("unexpected rendered email: %q / %q", emailQueue.Subject, emailQueue.Content)
+						}
+						if emailQueue.StepOrder != 2 || emailQueue.MailboxID == nil || *emailQueue.MailboxID != idleMailbox {
+							t.Errorf("unexpected email queue: %+v", emailQueue)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().
+					UpdateSequenceContact(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, sequenceContact *models.SequenceContact) error {
+						if sequenceContact.CurrentStep != 2 || sequenceContact.NextSendAt == nil {
+							t.Errorf("unexpected sequence contact: %+v", sequenceContact)
+						}
+						return nil
+					})
+			},
+			wantScheduled: 1,
+		},
+		{
+			name: "success - last step completes the enrollment",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetDueSequenceContacts(gomock.Any(), gomock.Any(), 10).
+					Return([]models.SequenceContact{{ID: uuid.New(), SequenceID: sequenceID, Version: 1, CurrentStep: 2, Status: models.SequenceContactStatusInProgress}}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				mockRepo.EXPECT().
+					GetNextSteps(gomock.Any(), sequenceID, 1, 2, 2).
+					Return([]models.Step{{StepOrder: 3, Subject: "Subj", Content: "Cont"}}, nil)
+				mockRepo.EXPECT().
+					GetSequenceMailboxLoads(gomock.Any(), sequenceID, gomock.Any()).
+					Return(nil, nil)
+				mockRepo.EXPECT().
+					CreateEmailQueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailQueue *models.EmailQueue) error {
+						if emailQueue.MailboxID != nil {
+							t.Errorf("expected no mailbox without assigned senders, got: %v", emailQueue.MailboxID)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().
+					UpdateSequenceContact(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, sequenceContact *models.SequenceContact) error {
+						if sequenceContact.Status != models.SequenceContactStatusCompleted || sequenceContact.CompletedAt == nil {
+							t.Errorf("expected completed sequence contact, got: %+v", sequenceContact)
+						}
+						return nil
+					})
+			},
+			wantScheduled: 1,
+			wantCompleted: 1,
+		},
+		{
+			name: "success - rotate senders across due contacts of a sequence",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetDueSequenceContacts(gomock.Any(), gomock.Any(), 10).
+					Return([]models.SequenceContact{
+						{ID: uuid.New(), SequenceID: sequenceID, Version: 1, CurrentStep: 1, Status: models.SequenceContactStatusInProgress},
+						{ID: uuid.New(), SequenceID: sequenceID, Version: 1, CurrentStep: 1, Status: models.SequenceContactStatusInProgress},
+						{ID: uuid.New(), SequenceID: sequenceID, Version: 1, CurrentStep: 1, Status: models.SequenceContactStatusInProgress},
+					}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				mockRepo.EXPECT().
+					GetNextSteps(gomock.Any(), sequenceID, 1, 1, 2).
+					Return([]models.Step{{StepOrder: 2}, {StepOrder: 3, WaitDays: 1}}, nil).
+					Times(3)
+				mockRepo.EXPECT().
+					GetSequenceMailboxLoads(gomock.Any(), sequenceID, gomock.Any()).
+					Return([]dto.MailboxLoad{{MailboxID: busyMailbox, DailyCapacity: 30, Queued: 1}, {MailboxID: idleMailbox, DailyCapacity: 30, Queued: 0}}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					ReserveMailboxCapacity(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(true, nil).
+					Times(3)
+
+				var senders []uuid.UUID
+				mockRepo.EXPECT().
+					CreateEmailQueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailQueue *models.EmailQueue) error {
+						senders = append(senders, *emailQueue.MailboxID)
+						if len(senders) == 3 && (senders[0] != idleMailbox || senders[1] != busyMailbox || senders[2] != idleMailbox) {
+							t.Errorf("unexpected sender rotation: %v", senders)
+						}
+						return nil
+					}).
+					Times(3)
+				mockRepo.EXPECT().
+					UpdateSequenceContact(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(3)
+			},
+			wantScheduled: 3,
+		},
+		{
+			name: "success - defer to the next day when every mailbox is at capacity",
+			setupMocks: func() {
+				now := time.Now()
+				mockRepo.EXPECT().
+					GetDueSequenceContacts(gomock.Any(), gomock.Any(), 10).
+					Return([]models.SequenceContact{{ID: uuid.New(), SequenceID: sequenceID, Version: 1, CurrentStep: 1, Status: models.SequenceContactStatusInProgress}}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				mockRepo.EXPECT().
+					GetNextSteps(gomock.Any(), sequenceID, 1, 1, 2).
+					Return([]models.Step{{StepOrder: 2}, {StepOrder: 3, WaitDays: 1}}, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().
+						GetSequenceMailboxLoads(gomock.Any(), sequenceID, gomock.Any()).
+						Return([]dto.MailboxLoad{{MailboxID: busyMailbox, DailyCapacity: 30, Queued: 29}}, nil),
+					// Another scheduler took the last slot of today.
+					mockRepo.EXPECT().
+						ReserveMailboxCapacity(gomock.Any(), busyMailbox, gomock.Any()).
+						Return(false, nil),
+					mockRepo.EXPECT().
+						GetSequenceMailboxLoads(gomock.Any(), sequenceID, gomock.Any()).
+						Return([]dto.MailboxLoad{{MailboxID: busyMailbox, DailyCapacity: 30}}, nil),
+					mockRepo.EXPECT().
+						ReserveMailboxCapacity(gomock.Any(), busyMailbox, gomock.Any()).
+						Return(true, nil),
+				)
+				mockRepo.EXPECT().
+					CreateEmailQueue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailQueue *models.EmailQueue) error {
+						if !emailQueue.ScheduledFor.After(now) || emailQueue.ScheduledFor.Hour() != 0 {
+							t.Errorf("expected email deferred to the start of the next day, got: %v", emailQueue.ScheduledFor)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().
+					UpdateSequenceContact(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantScheduled: 1,
+			wantDeferred:  1,
+		},
+		{
+			name: "success - enrollment is moved past the deferral horizon when every mailbox stays full",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetDueSequenceContacts(gomock.Any(), gomock.Any(), 10).
+					Return([]models.SequenceContact{{ID: uuid.New(), SequenceID: sequenceID, Version: 1, CurrentStep: 1, Status: models.SequenceContactStatusInProgress}}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				mockRepo.EXPECT().
+					GetNextSteps(gomock.Any(), sequenceID, 1, 1, 2).
+					Return([]models.Step{{StepOrder: 2}}, nil)
+				mockRepo.EXPECT().
+					GetSequenceMailboxLoads(gomock.Any(), sequenceID, gomock.Any()).
+					Return([]dto.MailboxLoad{{MailboxID: busyMailbox, DailyCapacity: 30, Queued: 30}}, nil).
+					Times(maxDeferralDays + 1)
+				mockRepo.EXPECT().
+					UpdateSequenceContact(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, sequenceContact *models.SequenceContact) error {
+						want := truncateToDay(time.Now()).AddDate(0, 0, maxDeferralDays+1)
+						if sequenceContact.CurrentStep != 1 || sequenceContact.NextSendAt == nil || !sequenceContact.NextSendAt.Equal(want) {
+							t.Errorf("expected enrollment moved to %v, got: %+v", want, sequenceContact)
+						}
+						return nil
+					})
+			},
+			wantRescheduled: 1,
+		},
+		{
+			name: "success - outside the send window moves the enrollment to the next opening",
+			setupMocks: func() {
+				today := time.Now().UTC().Weekday()
+				var otherDays models.Weekdays
+				for i, day := range sendwindow.Days {
+					if time.Weekday(i) != today {
+						otherDays = append(otherDays, day)
+					}
+				}
+				mockRepo.EXPECT().
+					GetDueSequenceContacts(gomock.Any(), gomock.Any(), 10).
+					Return([]models.SequenceContact{{ID: uuid.New(), SequenceID: sequenceID, ContactID: contactID, Version: 1, CurrentStep: 1, Status: models.SequenceContactStatusInProgress}}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs(gomock.Any(), gomock.Any()).
+					Return([]models.Contact{{ID: contactID, Timezone: "UTC"}}, nil)
+				mockRepo.EXPECT().
+					GetNextSteps(gomock.Any(), sequenceID, 1, 1, 2).
+					Return([]models.Step{{StepOrder: 2, SendWindowStart: ptr("00:00"), SendWindowEnd: ptr("23:59"), SendWindowDays: otherDays}}, nil)
+				mockRepo.EXPECT().
+					UpdateSequenceContact(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, sequenceContact *models.SequenceContact) error {
+						tomorrow := truncateToDay(time.Now()).AddDate(0, 0, 1)
+						if sequenceContact.CurrentStep != 1 || sequenceContact.NextSendAt == nil || !sequenceContact.NextSendAt.Equal(tomorrow) {
+							t.Errorf("expected enrollment moved to %v, got: %+v", tomorrow, sequenceContact)
+						}
+						return nil
+					})
+			},
+			wantRescheduled: 1,
+		},
+		{
+			name: "success - next send time honors the send window of the next step",
+			setupMocks: func() {
+				windowDay := time.Now().UTC().AddDate(0, 0, 3)
+				mockRepo.EXPECT().
+					GetDueSequenceContacts(gomock.Any(), gomock.Any(), 10).
+					Return([]models.SequenceContact{{ID: uuid.New(), SequenceID: sequenceID, ContactID: contactID, Version: 1, CurrentStep: 1, Status: models.SequenceContactStatusInProgress}}, nil)
+				mockRepo.EXPECT().
+					GetContactsByIDs(gomock.Any(), gomock.Any()).
+					Return([]models.Contact{{ID: contactID}}, nil)
+				mockRepo.EXPECT().
+					GetNextSteps(gomock.Any(), sequenceID, 1, 1, 2).
+					Return([]models.Step{
+						{StepOrder: 2},
+						{StepOrder: 3, WaitHours: 1, SendWindowStart: ptr("09:00"), SendWindowEnd: ptr("17:00"), SendWindowDays: models.Weekdays{sendwindow.Days[windowDay.Weekday()]}},
+					}, nil)
+				mockRepo.EXPECT().
+					GetSequenceMailboxLoads(gomock.Any(), sequenceID, gomock.Any()).
+					Return(nil, nil)
+				mockRepo.EXPECT().
+					CreateEmailQueue(gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockRepo.EXPECT().
+					UpdateSequenceContact(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, sequenceContact *models.SequenceContact) error {
+						want := truncateToDay(windowDay).Add(9 * time.Hour)
+						if sequenceContact.NextSendAt == nil || !sequenceContact.NextSendAt.Equal(want) {
+							t.Errorf("expected next send at %v, got: %v", want, sequenceContact.NextSendAt)
+						}
+						return nil
+					})
+			},
+			wantScheduled: 1,
+		},
+		{
+			name: "error - repository fails to fetch due contacts",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetDueSequenceContacts(gomock.Any(), gomock.Any(), 10).
+					Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if !tt.wantErr {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			tt.setupMocks()
+
+			resp, err := u.ScheduleDueContacts(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ScheduleDueContacts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if resp != nil && (resp.Scheduled != tt.wantScheduled || resp.Deferred != tt.wantDeferred || resp.Completed != tt.wantCompleted || resp.Rescheduled != tt.wantRescheduled) {
+				t.Errorf("ScheduleDueContacts() = %+v, want scheduled %d deferred %d completed %d rescheduled %d", resp, tt.wantScheduled, tt.wantDeferred, tt.wantCompleted, tt.wantRescheduled)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_DispatchDueEmails(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	mockConf.EXPECT().GetSchedulerConf().Return(config.Scheduler{BatchSize: 100})
+	mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailJobs: "email-jobs"}})
+
+	mockRepo := mock_scheduler.NewMockRepository(ctrl)
+	mockKafka := mock_kafka.NewMockKafkaClient(ctrl)
+	u := NewSchedulerUsecase(mockRepo, gormDB, mockConf, mockKafka, newTestTracker(t))
+
+	jobs := make([]dto.EmailJob, 60)
+	for i := range jobs {
+		jobs[i] = dto.EmailJob{EmailQueueID: uuid.New(), To: "lead@example.com"}
+	}
+
+	tests := []struct {
+		name           string
+		setupMocks     func()
+		expectCommit   bool
+		wantErr        bool
+		wantDispatched int
+		wantBatches    int
+	}{
+		{
+			name: "success - publish due emails in batches",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetDueEmailJobs(gomock.Any(), gomock.Any(), 100).
+					Return(jobs, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().
+						MarkEmailsQueued(gomock.Any(), gomock.Len(60)).
+						Return(nil),
+					mockKafka.EXPECT().
+						Publish("email-jobs", gomock.Any()).
+						Return(nil).
+						Times(2),
+				)
+			},
+			wantDispatched: 60,
+			wantBatches:    2,
+		},
+		{
+			name: "success - unsubscribe link is always added, tracking only when the sequence flags are on",
+			setupMocks: func() {
+				content := `<p><a href="https://example.com">link</a></p>`
+				mockRepo.EXPECT().
+					GetDueEmailJobs(gomock.Any(), gomock.Any(), 100).
+					Return([]dto.EmailJob{
+						{EmailQueueID: uuid.New(), Content: content, OpenTrackingEnabled: true, ClickTrackingEnabled: true},
+						{EmailQueueID: uuid.New(), Content: content},
+					}, nil)
+				mockKafka.EXPECT().
+					Publish("email-jobs", gomock.Any()).
+					DoAndReturn(func(_ string, message []byte) error {
+						batch := new(dto.EmailJobBatch)
+						if err := json.Unmarshal(message, batch); err != nil {
+							t.Fatalf("invalid batch payload: %v", err)
+						}
+						tracked, untracked := batch.Emails[0].Content, batch.Emails[1].Content
+						if !strings.Contains(tracked, "/api/v1/track/click/") || !strings.Contains(tracked, "/api/v1/track/open/") {
+							t.Errorf("expected tracked content, got: %s", tracked)
+						}
+						if strings.Contains(untracked, "/api/v1/track/") {
+							t.Errorf("expected untracked content, got: %s", untracked)
+						}
+						for _, email := range batch.Emails {
+							if !strings.HasPrefix(email.UnsubscribeURL, "https://track.example.com/api/v1/unsubscribe/") ||
+								!strings.Contains(email.Content, `href="`+email.UnsubscribeURL+`"`) {
+								t.Errorf("expected unsubscribe link, got: %q / %s", email.UnsubscribeURL, email.Content)
+							}
+						}
+						return nil
+					})
+				mockRepo.EXPECT().
+					MarkEmailsQueued(gomock.Any(), gomock.Len(2)).
+					Return(nil)
+			},
+			wantDispatched: 2,
+			wantBatches:    1,
+		},
+		{
+			name: "error - publish fails and the unpublished emails are put back to scheduled",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetDueEmailJobs(gomock.Any(), gomock.Any(), 100).
+					Return(jobs, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().
+						MarkEmailsQueued(gomock.Any(), gomock.Len(60)).
+						Return(nil),
+					mockKafka.EXPECT().
+						Publish("email-jobs", gomock.Any()).
+						Return(nil),
+					mockKafka.EXPECT().
+						Publish("email-jobs", gomock.Any()).
+						Return(errors.New("broker down")),
+					mockRepo.EXPECT().
+						MarkEmailsScheduled(gomock.Any(), gomock.Len(10)).
+						DoAndReturn(func(_ *gorm.DB, emailQueueIDs []uuid.UUID) error {
+							if emailQueueIDs[0] != jobs[50].EmailQueueID {
+								t.Errorf("expected the second batch to be put back, got: %v", emailQueueIDs)
+							}
+							return nil
+						}),
+				)
+			},
+			expectCommit: true,
+			wantErr:      true,
+		},
+		{
+			name: "error - emails cannot be marked queued so nothing is published",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetDueEmailJobs(gomock.Any(), gomock.Any(), 100).
+					Return(jobs, nil)
+				mockRepo.EXPECT().
+					MarkEmailsQueued(gomock.Any(), gomock.Len(60)).
+					Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if !tt.wantErr || tt.expectCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			tt.setupMocks()
+
+			resp, err := u.DispatchDueEmails(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DispatchDueEmails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if resp != nil && (resp.Dispatched != tt.wantDispatched || resp.Batches != tt.wantBatches) {
+				t.Errorf("DispatchDueEmails() = %+v, want dispatched %d batches %d", resp, tt.wantDispatched, tt.wantBatches)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_RecoverStaleEmails(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	mockConf.EXPECT().GetSchedulerConf().Return(config.Scheduler{BatchSize: 100})
+	mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailJobs: "email-jobs"}})
+
+	mockRepo := mock_scheduler.NewMockRepository(ctrl)
+	u := NewSchedulerUsecase(mockRepo, gormDB, mockConf, mock_kafka.NewMockKafkaClient(ctrl), newTestTracker(t))
+
+	staleBefore := gomock.AssignableToTypeOf(time.Time{})
+
+	tests := []struct {
+		name       string
+		setupMocks func()
+		wantErr    bool
+		want       *dto.RecoverStaleEmailsResponse
+	}{
+		{
+			name: "success - exhausted emails are failed before the others are retried",
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					RequeueStaleEmails(gomock.Any(), staleBefore).
+					DoAndReturn(func(_ *gorm.DB, before time.Time) (int64, error) {
+						if age := time.Since(before); age < staleEmailAfter || age > staleEmailAfter+time.Minute {
+							t.Errorf("unexpected stale cutoff: %v ago", age)
+						}
+						return 3, nil
+					})
+				gomock.InOrder(
+					mockRepo.EXPECT().FailStaleSendingEmails(gomock.Any(), staleBefore, staleSendingMessage).Return(int64(1), nil),
+					mockRepo.EXPECT().RetryStaleSendingEmails(gomock.Any(), staleBefore, staleSendingMessage).Return(int64(2), nil),
+				)
+			},
+			want: &dto.RecoverStaleEmailsResponse{Requeued: 3, Retried: 2, Failed: 1},
+		},
+		{
+			name: "error - nothing is recovered when a sending email cannot be retried",
+			setupMocks: func() {
+				mockRepo.EXPECT().RequeueStaleEmails(gomock.Any(), staleBefore).Return(int64(3), nil)
+				mockRepo.EXPECT().FailStaleSendingEmails(gomock.Any(), staleBefore, staleSendingMessage).Return(int64(0), nil)
+				mockRepo.EXPECT().RetryStaleSendingEmails(gomock.Any(), staleBefore, staleSendingMessage).Return(int64(0), errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.wantErr {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			tt.setupMocks()
+
+			resp, err := u.RecoverStaleEmails(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecoverStaleEmails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && (resp == nil || *resp != *tt.want) {
+				t.Errorf("RecoverStaleEmails() = %+v, want %+v", resp, tt.want)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_senderRotation_next(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_scheduler.NewMockRepository(ctrl)
+	sequenceID, mailboxID := uuid.New(), uuid.New()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+	window, err := sendwindow.New("09:00", "17:00", []string{"mon", "tue", "wed", "thu", "fri"})
+	if err != nil {
+		t.Fatalf("failed to create window: %v", err)
+	}
+
+	// Friday 14:00 in New York, inside the window, with every mailbox full for the rest of the day.
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	friday := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	gomock.InOrder(
+		mockRepo.EXPECT().
+			GetSequenceMailboxLoads(gomock.Any(), sequenceID, friday).
+			Return([]dto.MailboxLoad{{MailboxID: mailboxID, DailyCapacity: 30, Queued: 30}}, nil),
+		mockRepo.EXPECT().
+			GetSequenceMailboxLoads(gomock.Any(), sequenceID, monday).
+			Return([]dto.MailboxLoad{{MailboxID: mailboxID, DailyCapacity: 30}}, nil),
+		mockRepo.EXPECT().
+			ReserveMailboxCapacity(gomock.Any(), mailboxID, monday).
+			Return(true, nil),
+	)
+
+	gotMailbox, sendAt, err := newSenderRotation(mockRepo, nil).next(sequenceID, now, window, newYork)
+	if err != nil {
+		t.Fatalf("next() error = %v", err)
+	}
+
+	// The weekend is skipped and the email goes out when the window opens on Monday in New York.
+	want := time.Date(2026, 10, 19, 9, 0, 0, 0, newYork)
+	if gotMailbox == nil || *gotMailbox != mailboxID || sendAt == nil || !sendAt.Equal(want) {
+		t.Errorf("next() = %v, %v, want %v, %v", gotMailbox, sendAt, mailboxID, want)
+	}
+}
@@ -0,0 +1,71 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/IBM/sarama"
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/sender"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/kafka"
+)
+
+var log = logger.NewLogger("SENDER-CONSUMER")
+
+type senderConsumer struct {
+	usecase sender.Usecase
+	topics  config.Topic
+}
+
+// NewSenderConsumer consumes the email-jobs and email-retries topics and blocks until ctx is cancelled.
+func NewSenderConsumer(ctx context.Context, conf config.ImmutableConfig, consumer kafka.KafkaConsumer, usecase sender.Usecase) error {
+	h := &senderConsumer{
+		usecase: usecase,
+		topics:  conf.GetKafkaConf().Topics,
+	}
+
+	return consumer.Consume(ctx, []string{h.topics.EmailJobs, h.topics.EmailRetries}, h.HandleMessage)
+}
+
+func (h *senderConsumer) HandleMessage(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	if msg.Topic == h.topics.EmailRetries {
+		return h.HandleEmailRetries(ctx, msg)
+	}
+	return h.HandleEmailJobs(ctx, msg)
+}
+
+func (h *senderConsumer) HandleEmailJobs(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	batch := new(dto.EmailJobBatch)
+	if err := json.Unmarshal(msg.Value, batch); err != nil {
+		// A malformed message can never be processed, so skip it instead of blocking the partition.
+		log.Errorf("HandleEmailJobs - invalid payload at %s [partition=%d offset=%d]: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		return nil
+	}
+
+	kafkaBatch := &models.KafkaBatch{
+		Topic:       msg.Topic,
+		Partition:   msg.Partition,
+		KafkaOffset: msg.Offset,
+	}
+
+	return h.usecase.ProcessEmailBatch(ctx, kafkaBatch, batch)
+}
+
+func (h *senderConsumer) HandleEmailRetries(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	retry := new(dto.EmailRetry)
+	if err := json.Unmarshal(msg.Value, retry); err != nil {
+		log.Errorf("HandleEmailRetries - invalid payload at %s [partition=%d offset=%d]: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		return nil
+	}
+
+	kafkaBatch := &models.KafkaBatch{
+		Topic:       msg.Topic,
+		Partition:   msg.Partition,
+		KafkaOffset: msg.Offset,
+	}
+
+	return h.usecase.ProcessEmailRetry(ctx, kafkaBatch, retry)
+}
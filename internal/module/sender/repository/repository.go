@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/sender"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/capacity"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/suppression"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/unenroll"
+	"gorm.io/gorm"
+)
+
+type senderRepository struct {
+	db *gorm.DB
+}
+
+func NewSenderRepository(db *gorm.DB) sender.Repository {
+	return &senderRepository{
+		db: db,
+	}
+}
+
+// MarkEmailSending moves a queued email to sending and stores the Message-ID it is sent with, which
+// replies are matched on. It reports false when the email is no longer queued, e.g. because a
+// redelivered message was already processed or the email was cancelled.
+func (r *senderRepository) MarkEmailSending(tx *gorm.DB, emailQueueID uuid.UUID, attemptAt time.Time, messageID string) (bool, error) {
+	result := tx.Model(&models.EmailQueue{}).
+		Where("id = ? AND status = ?", emailQueueID, models.EmailQueueStatusQueued).
+		Updates(map[string]any{
+			"status":          models.EmailQueueStatusSending,
+			"last_attempt_at": attemptAt,
+			"message_id":      messageID,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *senderRepository) MarkEmailSent(tx *gorm.DB, emailQueueID uuid.UUID, sentAt time.Time) error {
+	return tx.Model(&models.EmailQueue{}).
+		Where("id = ?", emailQueueID).
+		Updates(map[string]any{
+			"status":        models.EmailQueueStatusSent,
+			"sent_at":       sentAt,
+			"error_message": nil,
+		}).Error
+}
+
+func (r *senderRepository) MarkEmailFailed(tx *gorm.DB, emailQueueID uuid.UUID, errorMessage string) error {
+	return tx.Model(&models.EmailQueue{}).
+		Where("id = ?", emailQueueID).
+		Updates(map[string]any{
+			"status":        models.EmailQueueStatusFailed,
+			"error_message": errorMessage,
+		}).Error
+}
+
+// MarkEmailRetrying puts a failed email back to queued and increments its retry_count, as long as
+// max_retries is not exhausted. It reports whether a retry is due and the attempt number.
+func (r *senderRepository) MarkEmailRetrying(tx *gorm.DB, emailQueueID uuid.UUID, errorMessage string) (bool, int, error) {
+	var retryCounts []int
+	if err := tx.Raw(`UPDATE email_queues
+		SET status = ?, retry_count = retry_count + 1, error_message = ?, updated_at = NOW()
+		WHERE id = ? AND retry_count < max_retries
+		RETURNING retry_count`,
+		models.EmailQueueStatusQueued, errorMessage, emailQueueID).Scan(&retryCounts).Error; err != nil {
+		return false, 0, err
+	}
+	if len(retryCounts) == 0 {
+		return false, 0, nil
+	}
+	return true, retryCounts[0], nil
+}
+
+// RescheduleEmail hands a queued email back to the dispatcher by moving it to scheduled at scheduledFor.
+// When that falls on another UTC day, the capacity reserved on its mailbox moves along, even when the
+// new day is already full since the email holds its slot. It reports false when the email is no longer
+// queued.
+func (r *senderRepository) RescheduleEmail(tx *gorm.DB, emailQueueID uuid.UUID, scheduledFor time.Time) (bool, error) {
+	if err := capacity.Move(tx, scheduledFor, "id = ? AND status = ?", emailQueueID, models.EmailQueueStatusQueued); err != nil {
+		return false, err
+	}
+
+	result := tx.Model(&models.EmailQueue{}).
+		Where("id = ? AND status = ?", emailQueueID, models.EmailQueueStatusQueued).
+		Updates(map[string]any{
+			"status":        models.EmailQueueStatusScheduled,
+			"scheduled_for": scheduledFor,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseMailboxCapacity gives back the daily capacity reserved for an email that could not be sent
+// and counts the failure against its mailbox.
+func (r *senderRepository) ReleaseMailboxCapacity(tx *gorm.DB, emailQueueID uuid.UUID) error {
+	return capacity.ReleaseFailed(tx, "id = ?", emailQueueID)
+}
+
+// GetMailbox returns a mailbox that is not deleted, with its encrypted SMTP password.
+func (r *senderRepository) GetMailbox(tx *gorm.DB, mailboxID uuid.UUID) (*models.Mailbox, error) {
+	var mailbox models.Mailbox
+	if err := tx.First(&mailbox, "id = ?", mailboxID).Error; err != nil {
+		return nil, err
+	}
+	return &mailbox, nil
+}
+
+// GetSuppression returns the suppression of an email address, or of its domain when the address itself
+// is not suppressed.
+func (r *senderRepository) GetSuppression(tx *gorm.DB, email string) (*models.Suppression, error) {
+	return suppression.Find(tx, email)
+}
+
+// CancelSuppressedEmail cancels a queued email whose recipient was suppressed after it was queued. The
+// capacity reserved on its mailbox is given back without counting a failure, and the enrollment is
+// cancelled so no further steps are scheduled. It reports false when the email is no longer queued.
+func (r *senderRepository) CancelSuppressedEmail(tx *gorm.DB, emailQueueID uuid.UUID, errorMessage string) (bool, error) {
+	result := tx.Model(&models.EmailQueue{}).
+		Where("id = ? AND status = ?", emailQueueID, models.EmailQueueStatusQueued).
+		Updates(map[string]any{
+			"status":        models.EmailQueueStatusCancelled,
+			"error_message": errorMessage,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	if err := capacity.Release(tx, "id = ?", emailQueueID); err != nil {
+		return false, err
+	}
+
+	if err := tx.Exec(`UPDATE sequence_contacts
+		SET status = ?, next_send_at = NULL, completed_at = NOW(), updated_at = NOW()
+		WHERE id = (SELECT sequence_contact_id FROM email_queues WHERE id = ?) AND status IN ?`,
+		models.SequenceContactStatusCancelled, emailQueueID, unenroll.Unfinished).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RecordBounce counts a bounce of an email against its mailbox. A hard bounce also ends the enrollment,
+// cancels its pending emails and suppresses the recipient.
+func (r *senderRepository) RecordBounce(tx *gorm.DB, emailQueueID, sequenceContactID uuid.UUID, recipient string, kind bounce.Kind) error {
+	return unenroll.Bounce(tx, emailQueueID, sequenceContactID, recipient, kind)
+}
+
+func (r *senderRepository) CreateEmailEvent(tx *gorm.DB, emailEvent *models.EmailEvent) error {
+	return tx.Create(emailEvent).Error
+}
+
+func (r *senderRepository) CreateKafkaBatch(tx *gorm.DB, kafkaBatch *models.KafkaBatch) error {
+	return tx.Create(kafkaBatch).Error
+}
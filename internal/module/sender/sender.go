@@ -0,0 +1,33 @@
+package sender
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"gorm.io/gorm"
+)
+
+type Usecase interface {
+	ProcessEmailBatch(ctx context.Context, kafkaBatch *models.KafkaBatch, batch *dto.EmailJobBatch) error
+	ProcessEmailRetry(ctx context.Context, kafkaBatch *models.KafkaBatch, retry *dto.EmailRetry) error
+}
+
+type Repository interface {
+	MarkEmailSending(tx *gorm.DB, emailQueueID uuid.UUID, attemptAt time.Time, messageID string) (bool, error)
+	MarkEmailSent(tx *gorm.DB, emailQueueID uuid.UUID, sentAt time.Time) error
+	MarkEmailFailed(tx *gorm.DB, emailQueueID uuid.UUID, errorMessage string) error
+	MarkEmailRetrying(tx *gorm.DB, emailQueueID uuid.UUID, errorMessage string) (bool, int, error)
+	RescheduleEmail(tx *gorm.DB, emailQueueID uuid.UUID, scheduledFor time.Time) (bool, error)
+	ReleaseMailboxCapacity(tx *gorm.DB, emailQueueID uuid.UUID) error
+	GetMailbox(tx *gorm.DB, mailboxID uuid.UUID) (*models.Mailbox, error)
+	GetSuppression(tx *gorm.DB, email string) (*models.Suppression, error)
+	CancelSuppressedEmail(tx *gorm.DB, emailQueueID uuid.UUID, errorMessage string) (bool, error)
+	RecordBounce(tx *gorm.DB, emailQueueID, sequenceContactID uuid.UUID, recipient string, kind bounce.Kind) error
+	CreateEmailEvent(tx *gorm.DB, emailEvent *models.EmailEvent) error
+
+	CreateKafkaBatch(tx *gorm.DB, kafkaBatch *models.KafkaBatch) error
+}
@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/sender"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/kafka"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/mail"
+	"gorm.io/gorm"
+)
+
+const (
+	// retryBaseDelay is the wait before the first retry; it doubles with every further attempt.
+	retryBaseDelay = time.Minute
+	retryMaxDelay  = time.Hour
+)
+
+var log = logger.NewLogger("SENDER")
+
+type senderUsecase struct {
+	repo   sender.Repository
+	db     *gorm.DB
+	mailer mail.Sender
+	cipher crypto.Cipher
+	kafka  kafka.KafkaClient
+	topics config.Topic
+}
+
+func NewSenderUsecase(repo sender.Repository, db *gorm.DB, mailer mail.Sender, cipher crypto.Cipher, kafkaClient kafka.KafkaClient, conf config.ImmutableConfig) sender.Usecase {
+	return &senderUsecase{
+		repo:   repo,
+		db:     db,
+		mailer: mailer,
+		cipher: cipher,
+		kafka:  kafkaClient,
+		topics: conf.GetKafkaConf().Topics,
+	}
+}
+
+// ProcessEmailBatch sends every email of a batch and records the batch in kafka_batches.
+// A returned error means a database write failed and the message must not be committed. An email
+// claimed before the failure stays sending until the scheduler recovers it.
+func (u *senderUsecase) ProcessEmailBatch(ctx context.Context, kafkaBatch *models.KafkaBatch, batch *dto.EmailJobBatch) error {
+	db := u.db.WithContext(ctx)
+
+	sentCount := 0
+	for _, job := range batch.Emails {
+		sent, err := u.send(ctx, db, &job)
+		if err != nil {
+			return err
+		}
+		if sent {
+			sentCount++
+		}
+	}
+
+	kafkaBatch.ProcessedAt = time.Now()
+	kafkaBatch.EmailCount = sentCount
+	kafkaBatch.BatchSize = len(batch.Emails)
+	if err := u.repo.CreateKafkaBatch(db, kafkaBatch); err != nil {
+		log.Errorf("ProcessEmailBatch - failed to record batch %s: %v", batch.BatchID, err)
+		return err
+	}
+
+	log.Infof("ProcessEmailBatch - batch %s processed, sent: %d/%d", batch.BatchID, sentCount, len(batch.Emails))
+	return nil
+}
+
+// ProcessEmailRetry sends an email again when its backoff has elapsed and records it in kafka_batches.
+// A retry that is not due yet is handed back to the dispatcher, so the partition is not held up until it is.
+func (u *senderUsecase) ProcessEmailRetry(ctx context.Context, kafkaBatch *models.KafkaBatch, retry *dto.EmailRetry) error {
+	if time.Now().Before(retry.RetryAt) {
+		return u.rescheduleRetry(u.db.WithContext(ctx), retry)
+	}
+
+	log.Infof("ProcessEmailRetry - retrying email %s, attempt %d", retry.Email.EmailQueueID, retry.Attempt)
+	return u.ProcessEmailBatch(ctx, kafkaBatch, newRetryBatch(retry))
+}
+
+// rescheduleRetry puts an email back to scheduled at its retry time, where the dispatcher picks it up.
+func (u *senderUsecase) rescheduleRetry(db *gorm.DB, retry *dto.EmailRetry) error {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	rescheduled, err := u.repo.RescheduleEmail(tx, retry.Email.EmailQueueID, retry.RetryAt)
+	if err != nil {
+		log.Errorf("ProcessEmailRetry - failed to reschedule email %s: %v", retry.Email.EmailQueueID, err)
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Errorf("ProcessEmailRetry - failed to commit rescheduling of email %s: %v", retry.Email.EmailQueueID, err)
+		return err
+	}
+	if !rescheduled {
+		log.Warnf("ProcessEmailRetry - email %s is no longer queued, skipping", retry.Email.EmailQueueID)
+		return nil
+	}
+	log.Infof("ProcessEmailRetry - email %s scheduled for attempt %d at %v", retry.Email.EmailQueueID, retry.Attempt, retry.RetryAt)
+	return nil
+}
+
+// send claims a queued email and hands it to the mail transport. It reports whether the email was sent.
+// Emails to a recipient suppressed after queuing are cancelled instead.
+func (u *senderUsecase) send(ctx context.Context, db *gorm.DB, job *dto.EmailJob) (bool, error) {
+	suppression, err := u.repo.GetSuppression(db, job.To)
+	if err == nil {
+		return false, u.cancelSuppressed(db, job, suppression)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorf("ProcessEmailBatch - failed to check suppression of email %s: %v", job.EmailQueueID, err)
+		return false, err
+	}
+
+	messageID := mail.NewMessageID(job.EmailQueueID, job.From)
+	claimed, err := u.repo.MarkEmailSending(db, job.EmailQueueID, time.Now(), messageID)
+	if err != nil {
+		log.Errorf("ProcessEmailBatch - failed to mark email %s sending: %v", job.EmailQueueID, err)
+		return false, err
+	}
+	if !claimed {
+		log.Warnf("ProcessEmailBatch - email %s is no longer queued, skipping", job.EmailQueueID)
+		return false, nil
+	}
+
+	server, err := u.mailServer(db, job)
+	if err != nil && !mail.IsPermanent(err) {
+		log.Errorf("ProcessEmailBatch - failed to get mailbox of email %s: %v", job.EmailQueueID, err)
+		return false, err
+	}
+	if err == nil {
+		msg := &mail.Message{
+			From:    job.From,
+			To:      job.To,
+			Subject: job.Subject,
+			HTML:    job.Content,
+			Headers: emailHeaders(messageID, job.UnsubscribeURL),
+			Server:  server,
+		}
+		err = u.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		log.Errorf("ProcessEmailBatch - failed to send email %s: %v", job.EmailQueueID, err)
+		return false, u.handleFailure(db, job, err)
+	}
+
+	if err := u.repo.MarkEmailSent(db, job.EmailQueueID, time.Now()); err != nil {
+		log.Errorf("ProcessEmailBatch - failed to mark email %s sent: %v", job.EmailQueueID, err)
+		return false, err
+	}
+	return true, nil
+}
+
+// mailServer returns the SMTP server of the mailbox an email is sent from, nil for emails without a
+// mailbox. A deleted mailbox or an undecryptable password is a permanent send failure.
+func (u *senderUsecase) mailServer(db *gorm.DB, job *dto.EmailJob) (*mail.Server, error) {
+	if job.MailboxID == nil {
+		return nil, nil
+	}
+
+	mailbox, err := u.repo.GetMailbox(db, *job.MailboxID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, mail.Permanent(fmt.Errorf("mailbox %s not found", *job.MailboxID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	server := &mail.Server{
+		MailboxID: mailbox.ID,
+		Host:      mailbox.SMTPHost,
+		Port:      mailbox.SMTPPort,
+		Username:  mailbox.SMTPUsername,
+	}
+	if len(mailbox.EncryptedSMTPPassword) > 0 {
+		password, err := u.cipher.Decrypt(mailbox.EncryptedSMTPPassword)
+		if err != nil {
+			return nil, mail.Permanent(fmt.Errorf("failed to decrypt SMTP password of mailbox %s: %w", mailbox.ID, err))
+		}
+		server.Password = string(password)
+	}
+	return server, nil
+}
+
+func (u *senderUsecase) cancelSuppressed(db *gorm.DB, job *dto.EmailJob, suppression *models.Suppression) error {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	errorMessage := fmt.Sprintf("recipient %s is suppressed: %s", suppression.Type, suppression.Reason)
+	cancelled, err := u.repo.CancelSuppressedEmail(tx, job.EmailQueueID, errorMessage)
+	if err != nil {
+		log.Errorf("ProcessEmailBatch - failed to cancel suppressed email %s: %v", job.EmailQueueID, err)
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Errorf("ProcessEmailBatch - failed to commit cancellation of email %s: %v", job.EmailQueueID, err)
+		return err
+	}
+	if cancelled {
+		log.Warnf("ProcessEmailBatch - email %s cancelled, %s", job.EmailQueueID, errorMessage)
+	}
+	return nil
+}
+
+// handleFailure schedules a retry of a failed send with exponential backoff. Once max_retries is
+// exhausted, or the retry cannot be published, the email is marked failed and the capacity reserved
+// on its mailbox is released. Bounces are recorded first; hard bounces are never retried, soft bounces
+// are. Other failures are retried unless the transport reports them as permanent.
+func (u *senderUsecase) handleFailure(db *gorm.DB, job *dto.EmailJob, sendErr error) error {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	errorMessage := sendErr.Error()
+	retryable := !mail.IsPermanent(sendErr)
+
+	var emailEvent *models.EmailEvent
+	if b := bounce.Classify(sendErr); b != nil {
+		var err error
+		if emailEvent, err = u.recordBounce(tx, job, b); err != nil {
+			return err
+		}
+		retryable = b.Kind != bounce.KindHard
+	}
+
+	if retryable {
+		retrying, attempt, err := u.repo.MarkEmailRetrying(tx, job.EmailQueueID, errorMessage)
+		if err != nil {
+			log.Errorf("ProcessEmailBatch - failed to mark email %s retrying: %v", job.EmailQueueID, err)
+			return err
+		}
+
+		if retrying {
+			err := u.publishRetry(job, attempt)
+			if err == nil {
+				log.Infof("ProcessEmailBatch - email %s scheduled for retry %d", job.EmailQueueID, attempt)
+				return u.commit(tx, job, emailEvent)
+			}
+			log.Errorf("ProcessEmailBatch - failed to publish retry of email %s: %v", job.EmailQueueID, err)
+		}
+	}
+
+	if err := u.repo.MarkEmailFailed(tx, job.EmailQueueID, errorMessage); err != nil {
+		log.Errorf("ProcessEmailBatch - failed to mark email %s failed: %v", job.EmailQueueID, err)
+		return err
+	}
+
+	if job.MailboxID != nil {
+		if err := u.repo.ReleaseMailboxCapacity(tx, job.EmailQueueID); err != nil {
+			log.Errorf("ProcessEmailBatch - failed to release mailbox capacity for email %s: %v", job.EmailQueueID, err)
+			return err
+		}
+	}
+
+	return u.commit(tx, job, emailEvent)
+}
+
+// recordBounce counts a bounce against the mailbox of the email and stores a bounced event. A hard
+// bounce also ends the enrollment, cancels its pending emails and suppresses the recipient.
+func (u *senderUsecase) recordBounce(tx *gorm.DB, job *dto.EmailJob, b *bounce.Bounce) (*models.EmailEvent, error) {
+	log.Warnf("ProcessEmailBatch - email %s %s bounced with %d %s", job.EmailQueueID, b.Kind, b.Code, b.Status)
+
+	if err := u.repo.RecordBounce(tx, job.EmailQueueID, job.SequenceContactID, job.To, b.Kind); err != nil {
+		log.Errorf("ProcessEmailBatch - failed to record bounce of email %s: %v", job.EmailQueueID, err)
+		return nil, err
+	}
+
+	emailEvent := &models.EmailEvent{
+		EmailQueueID: job.EmailQueueID,
+		EventType:    models.EmailEventTypeBounced,
+		EventData: models.EventData{
+			"source":      "smtp",
+			"bounce_type": b.Kind,
+			"code":        b.Code,
+			"status":      b.Status,
+			"diagnostic":  b.Diagnostic,
+		},
+	}
+	if err := u.repo.CreateEmailEvent(tx, emailEvent); err != nil {
+		log.Errorf("ProcessEmailBatch - failed to record bounced event of email %s: %v", job.EmailQueueID, err)
+		return nil, err
+	}
+	return emailEvent, nil
+}
+
+func (u *senderUsecase) publishRetry(job *dto.EmailJob, attempt int) error {
+	retry := &dto.EmailRetry{
+		Email:   *job,
+		Attempt: attempt,
+		RetryAt: time.Now().Add(retryDelay(attempt)),
+	}
+	message, err := json.Marshal(retry)
+	if err != nil {
+		return err
+	}
+	return u.kafka.Publish(u.topics.EmailRetries, message)
+}
+
+// commit commits the failure of an email and publishes its bounced event, if any, to the email-events
+// topic. A failed publish is only logged since the email_events row is the record of truth.
+func (u *senderUsecase) commit(tx *gorm.DB, job *dto.EmailJob, emailEvent *models.EmailEvent) error {
+	if err := tx.Commit().Error; err != nil {
+		log.Errorf("ProcessEmailBatch - failed to commit failure of email %s: %v", job.EmailQueueID, err)
+		return err
+	}
+	if emailEvent == nil {
+		return nil
+	}
+
+	message, err := json.Marshal(&dto.EmailEventMessage{
+		ID:           emailEvent.ID,
+		EmailQueueID: emailEvent.EmailQueueID,
+		EventType:    emailEvent.EventType,
+		EventData:    emailEvent.EventData,
+		OccurredAt:   emailEvent.CreatedAt,
+	})
+	if err == nil {
+		err = u.kafka.Publish(u.topics.EmailEvents, message)
+	}
+	if err != nil {
+		log.Errorf("ProcessEmailBatch - failed to publish bounced event %s: %v", emailEvent.ID, err)
+	}
+	return nil
+}
+
+// retryDelay returns the backoff before the given attempt: 1m, 2m, 4m, ... capped at retryMaxDelay.
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, retryMaxDelay)
+}
+
+// emailHeaders returns the Message-ID header of an email and the RFC 8058 one-click unsubscribe headers
+// for its unsubscribe URL, which emails dispatched before unsubscribe links existed do not have.
+func emailHeaders(messageID, unsubscribeURL string) map[string]string {
+	headers := map[string]string{"Message-ID": messageID}
+	if unsubscribeURL != "" {
+		headers["List-Unsubscribe"] = "<" + unsubscribeURL + ">"
+		headers["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+	}
+	return headers
+}
+
+// newRetryBatch wraps a retried email in a batch so it is audited in kafka_batches like regular sends.
+func newRetryBatch(retry *dto.EmailRetry) *dto.EmailJobBatch {
+	return &dto.EmailJobBatch{
+		BatchID:    uuid.New(),
+		Emails:     []dto.EmailJob{retry.Email},
+		ProducedAt: time.Now(),
+	}
+}
@@ -0,0 +1,455 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_config "github.com/rohanchauhan02/sequence-service/files/mocks/config"
+	mock_kafka "github.com/rohanchauhan02/sequence-service/files/mocks/kafka"
+	mock_mail "github.com/rohanchauhan02/sequence-service/files/mocks/mail"
+	mock_sender "github.com/rohanchauhan02/sequence-service/files/mocks/sender"
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/mail"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_ProcessEmailBatch(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_sender.NewMockRepository(ctrl)
+	mockMailer := mock_mail.NewMockSender(ctrl)
+	mockKafka := mock_kafka.NewMockKafkaClient(ctrl)
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailRetries: "email-retries", EmailEvents: "email-events"}})
+	cipher, err := crypto.NewAESCipher("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	u := NewSenderUsecase(mockRepo, gormDB, mockMailer, cipher, mockKafka, mockConf)
+
+	sentID, failedID, skippedID := uuid.New(), uuid.New(), uuid.New()
+	mailboxID := uuid.New()
+	encryptedPassword, err := cipher.Encrypt([]byte("smtp-secret"))
+	if err != nil {
+		t.Fatalf("failed to encrypt password: %v", err)
+	}
+	mailbox := &models.Mailbox{
+		ID:                    mailboxID,
+		Email:                 "sales@acme.example",
+		SMTPHost:              "smtp.acme.example",
+		SMTPPort:              587,
+		SMTPUsername:          "sales@acme.example",
+		EncryptedSMTPPassword: encryptedPassword,
+	}
+	batch := &dto.EmailJobBatch{
+		BatchID: uuid.New(),
+		Emails: []dto.EmailJob{
+			{EmailQueueID: sentID, To: "a@example.com", UnsubscribeURL: "https://track.example.com/api/v1/unsubscribe/abc"},
+			{EmailQueueID: failedID, MailboxID: &mailboxID, To: "b@example.com"},
+			{EmailQueueID: skippedID, To: "c@example.com"},
+		},
+	}
+	failedEnrollmentID := uuid.New()
+	retriedBatch := &dto.EmailJobBatch{
+		BatchID: uuid.New(),
+		Emails:  []dto.EmailJob{{EmailQueueID: failedID, SequenceContactID: failedEnrollmentID, MailboxID: &mailboxID, To: "b@example.com"}},
+	}
+
+	suppressedID := uuid.New()
+	suppressedBatch := &dto.EmailJobBatch{
+		BatchID: uuid.New(),
+		Emails:  []dto.EmailJob{{EmailQueueID: suppressedID, MailboxID: &mailboxID, To: "Lead@Blocked.example"}},
+	}
+
+	hardBounce := fmt.Errorf("smtp rcpt to: %w", bounce.Recipient(&textproto.Error{Code: 550, Msg: "5.1.1 User unknown"}))
+	softBounce := &textproto.Error{Code: 452, Msg: "4.2.2 Mailbox full"}
+
+	expectNotSuppressed := func(email string) {
+		mockRepo.EXPECT().GetSuppression(gomock.Any(), email).Return(nil, gorm.ErrRecordNotFound)
+	}
+	expectMailbox := func() {
+		mockRepo.EXPECT().GetMailbox(gomock.Any(), mailboxID).Return(mailbox, nil)
+	}
+
+	tests := []struct {
+		name       string
+		batch      *dto.EmailJobBatch
+		setupMocks func()
+		expectTx   bool
+		wantErr    bool
+	}{
+		{
+			name:  "success - sent, failed and skipped emails are recorded",
+			batch: batch,
+			setupMocks: func() {
+				expectNotSuppressed("a@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), sentID, gomock.Any(), gomock.Any()).Return(true, nil)
+				mockMailer.EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *mail.Message) error {
+						if msg.Headers["Message-ID"] != mail.NewMessageID(sentID, "") ||
+							msg.Headers["List-Unsubscribe"] != "<https://track.example.com/api/v1/unsubscribe/abc>" ||
+							msg.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+							t.Errorf("unexpected headers: %v", msg.Headers)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().MarkEmailSent(gomock.Any(), sentID, gomock.Any()).Return(nil)
+
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				expectMailbox()
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+				mockRepo.EXPECT().MarkEmailRetrying(gomock.Any(), failedID, "smtp down").Return(false, 0, nil)
+				mockRepo.EXPECT().MarkEmailFailed(gomock.Any(), failedID, "smtp down").Return(nil)
+				mockRepo.EXPECT().ReleaseMailboxCapacity(gomock.Any(), failedID).Return(nil)
+
+				expectNotSuppressed("c@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), skippedID, gomock.Any(), gomock.Any()).Return(false, nil)
+
+				mockRepo.EXPECT().
+					CreateKafkaBatch(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, kafkaBatch *models.KafkaBatch) error {
+						if kafkaBatch.EmailCount != 1 || kafkaBatch.BatchSize != 3 {
+							t.Errorf("unexpected kafka batch: %+v", kafkaBatch)
+						}
+						return nil
+					})
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - failed send is republished to the retries topic",
+			batch: retriedBatch,
+			setupMocks: func() {
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				expectMailbox()
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+				mockRepo.EXPECT().MarkEmailRetrying(gomock.Any(), failedID, "smtp down").Return(true, 2, nil)
+				mockKafka.EXPECT().
+					Publish("email-retries", gomock.Any()).
+					DoAndReturn(func(_ string, message []byte) error {
+						retry := new(dto.EmailRetry)
+						if err := json.Unmarshal(message, retry); err != nil {
+							t.Fatalf("invalid retry payload: %v", err)
+						}
+						if retry.Attempt != 2 || retry.Email.EmailQueueID != failedID || time.Until(retry.RetryAt) < time.Minute {
+							t.Errorf("unexpected retry: %+v", retry)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().CreateKafkaBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - email is failed when the retry cannot be published",
+			batch: retriedBatch,
+			setupMocks: func() {
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				expectMailbox()
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+				mockRepo.EXPECT().MarkEmailRetrying(gomock.Any(), failedID, "smtp down").Return(true, 1, nil)
+				mockKafka.EXPECT().Publish("email-retries", gomock.Any()).Return(errors.New("broker down"))
+				mockRepo.EXPECT().MarkEmailFailed(gomock.Any(), failedID, "smtp down").Return(nil)
+				mockRepo.EXPECT().ReleaseMailboxCapacity(gomock.Any(), failedID).Return(nil)
+				mockRepo.EXPECT().CreateKafkaBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - email to a recipient suppressed after queuing is cancelled",
+			batch: suppressedBatch,
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSuppression(gomock.Any(), "Lead@Blocked.example").
+					Return(&models.Suppression{Type: models.SuppressionTypeDomain, Value: "blocked.example", Reason: models.SuppressionReasonComplaint}, nil)
+				mockRepo.EXPECT().
+					CancelSuppressedEmail(gomock.Any(), suppressedID, "recipient domain is suppressed: complaint").
+					Return(true, nil)
+				mockRepo.EXPECT().
+					CreateKafkaBatch(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, kafkaBatch *models.KafkaBatch) error {
+						if kafkaBatch.EmailCount != 0 || kafkaBatch.BatchSize != 1 {
+							t.Errorf("unexpected kafka batch: %+v", kafkaBatch)
+						}
+						return nil
+					})
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - hard bounce suppresses the recipient and is not retried",
+			batch: retriedBatch,
+			setupMocks: func() {
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				expectMailbox()
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(hardBounce)
+				mockRepo.EXPECT().RecordBounce(gomock.Any(), failedID, failedEnrollmentID, "b@example.com", bounce.KindHard).Return(nil)
+				mockRepo.EXPECT().
+					CreateEmailEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailEvent *models.EmailEvent) error {
+						if emailEvent.EventType != models.EmailEventTypeBounced || emailEvent.EventData["bounce_type"] != bounce.KindHard || emailEvent.EventData["diagnostic"] != "550 5.1.1 User unknown" {
+							t.Errorf("unexpected email event: %+v", emailEvent)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().MarkEmailFailed(gomock.Any(), failedID, hardBounce.Error()).Return(nil)
+				mockRepo.EXPECT().ReleaseMailboxCapacity(gomock.Any(), failedID).Return(nil)
+				mockKafka.EXPECT().Publish("email-events", gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CreateKafkaBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - soft bounce is counted and retried",
+			batch: retriedBatch,
+			setupMocks: func() {
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				expectMailbox()
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(softBounce)
+				mockRepo.EXPECT().RecordBounce(gomock.Any(), failedID, failedEnrollmentID, "b@example.com", bounce.KindSoft).Return(nil)
+				mockRepo.EXPECT().CreateEmailEvent(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().MarkEmailRetrying(gomock.Any(), failedID, softBounce.Error()).Return(true, 1, nil)
+				mockKafka.EXPECT().Publish("email-retries", gomock.Any()).Return(nil)
+				mockKafka.EXPECT().Publish("email-events", gomock.Any()).Return(errors.New("broker down"))
+				mockRepo.EXPECT().CreateKafkaBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - email is sent through the SMTP server of its mailbox",
+			batch: retriedBatch,
+			setupMocks: func() {
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				expectMailbox()
+				mockMailer.EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *mail.Message) error {
+						want := mail.Server{MailboxID: mailboxID, Host: "smtp.acme.example", Port: 587, Username: "sales@acme.example", Password: "smtp-secret"}
+						if msg.Server == nil || *msg.Server != want {
+							t.Errorf("unexpected server: %+v", msg.Server)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().MarkEmailSent(gomock.Any(), failedID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CreateKafkaBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "success - permanent failure is not retried",
+			batch: retriedBatch,
+			setupMocks: func() {
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				expectMailbox()
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(mail.Permanent(errors.New("smtp auth rejected with 535 5.7.8 invalid credentials")))
+				mockRepo.EXPECT().MarkEmailFailed(gomock.Any(), failedID, "smtp auth rejected with 535 5.7.8 invalid credentials").Return(nil)
+				mockRepo.EXPECT().ReleaseMailboxCapacity(gomock.Any(), failedID).Return(nil)
+				mockRepo.EXPECT().CreateKafkaBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - email of a deleted mailbox fails without being sent",
+			batch: retriedBatch,
+			setupMocks: func() {
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().GetMailbox(gomock.Any(), mailboxID).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().MarkEmailFailed(gomock.Any(), failedID, "mailbox "+mailboxID.String()+" not found").Return(nil)
+				mockRepo.EXPECT().ReleaseMailboxCapacity(gomock.Any(), failedID).Return(nil)
+				mockRepo.EXPECT().CreateKafkaBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:  "error - mailbox cannot be read so the offset is not committed",
+			batch: retriedBatch,
+			setupMocks: func() {
+				expectNotSuppressed("b@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), failedID, gomock.Any(), gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().GetMailbox(gomock.Any(), mailboxID).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:  "error - database write fails so the offset is not committed",
+			batch: batch,
+			setupMocks: func() {
+				expectNotSuppressed("a@example.com")
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), sentID, gomock.Any(), gomock.Any()).Return(false, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			}
+
+			tt.setupMocks()
+
+			err := u.ProcessEmailBatch(context.Background(), &models.KafkaBatch{Topic: "email-jobs"}, tt.batch)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProcessEmailBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ProcessEmailRetry(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_sender.NewMockRepository(ctrl)
+	mockMailer := mock_mail.NewMockSender(ctrl)
+	mockKafka := mock_kafka.NewMockKafkaClient(ctrl)
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailRetries: "email-retries", EmailEvents: "email-events"}})
+	u := NewSenderUsecase(mockRepo, gormDB, mockMailer, nil, mockKafka, mockConf)
+
+	emailQueueID := uuid.New()
+	retryAt := time.Now().Add(time.Hour)
+	pending := &dto.EmailRetry{Email: dto.EmailJob{EmailQueueID: emailQueueID, To: "a@example.com"}, Attempt: 2, RetryAt: retryAt}
+	due := &dto.EmailRetry{Email: dto.EmailJob{EmailQueueID: emailQueueID, To: "a@example.com"}, Attempt: 2, RetryAt: time.Now().Add(-time.Second)}
+
+	tests := []struct {
+		name       string
+		retry      *dto.EmailRetry
+		setupMocks func()
+		expectTx   bool
+		wantErr    bool
+	}{
+		{
+			name:  "success - retry that is not due is handed back to the dispatcher",
+			retry: pending,
+			setupMocks: func() {
+				mockRepo.EXPECT().RescheduleEmail(gomock.Any(), emailQueueID, retryAt).Return(true, nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - retry of an email that is no longer queued is skipped",
+			retry: pending,
+			setupMocks: func() {
+				mockRepo.EXPECT().RescheduleEmail(gomock.Any(), emailQueueID, retryAt).Return(false, nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:  "success - due retry is sent",
+			retry: due,
+			setupMocks: func() {
+				mockRepo.EXPECT().GetSuppression(gomock.Any(), "a@example.com").Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().MarkEmailSending(gomock.Any(), emailQueueID, gomock.Any(), gomock.Any()).Return(true, nil)
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().MarkEmailSent(gomock.Any(), emailQueueID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CreateKafkaBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "error - rescheduling fails so the offset is not committed",
+			retry: pending,
+			setupMocks: func() {
+				mockRepo.EXPECT().RescheduleEmail(gomock.Any(), emailQueueID, retryAt).Return(false, errors.New("db error"))
+			},
+			expectTx: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if tt.wantErr {
+					mock.ExpectRollback()
+				} else {
+					mock.ExpectCommit()
+				}
+			}
+
+			tt.setupMocks()
+
+			err := u.ProcessEmailRetry(context.Background(), &models.KafkaBatch{Topic: "email-retries"}, tt.retry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProcessEmailRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_retryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Minute},
+		{attempt: 2, want: 2 * time.Minute},
+		{attempt: 4, want: 8 * time.Minute},
+		{attempt: 10, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package https
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/module/suppression"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+)
+
+type suppressionHandler struct {
+	usecase suppression.Usecase
+}
+
+func NewSuppressionHandler(e *echo.Echo, usecase suppression.Usecase) {
+	h := &suppressionHandler{
+		usecase: usecase,
+	}
+
+	api := e.Group("/api/v1")
+
+	api.POST("/suppression", h.CreateSuppression)
+	api.GET("/suppressions", h.ListSuppressions)
+	api.DELETE("/suppression/:id", h.DeleteSuppression)
+}
+
+// CreateSuppression godoc
+// @Summary      Suppress an email or domain
+// @Description  Add an email address or a whole domain to the suppression list. Suppressed contacts are skipped at enrollment and their queued emails are cancelled before sending.
+// @Tags         Suppressions
+// @Accept       json
+// @Produce      json
+// @Param        suppression  body      dto.CreateSuppressionRequest  true  "Email or domain to suppress"
+// @Success      201  {object}  dto.ResponsePattern{data=models.Suppression}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /suppression [post]
+func (h *suppressionHandler) CreateSuppression(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.CreateSuppressionRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("CreateSuppression - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	suppressionDetails, err := h.usecase.CreateSuppression(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("CreateSuppression - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Suppression created successfully", suppressionDetails, "", "", http.StatusCreated, nil)
+}
+
+// ListSuppressions godoc
+// @Summary      List suppressions
+// @Description  List suppressed emails and domains with pagination, optionally filtered by type, reason or a search on the value
+// @Tags         Suppressions
+// @Produce      json
+// @Param        page    query     int     false  "Page number"
+// @Param        limit   query     int     false  "Page size"
+// @Param        type    query     string  false  "Suppression type"    Enums(email, domain)
+// @Param        reason  query     string  false  "Suppression reason"  Enums(unsubscribed, bounced, complaint, manual)
+// @Param        search  query     string  false  "Part of the email or domain"
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.Suppression,meta=dto.PaginationMeta}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /suppressions [get]
+func (h *suppressionHandler) ListSuppressions(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.ListSuppressionsRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("ListSuppressions - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	suppressions, meta, err := h.usecase.ListSuppressions(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("ListSuppressions - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Suppressions retrieved successfully", suppressions, "", "", http.StatusOK, meta)
+}
+
+// DeleteSuppression godoc
+// @Summary      Delete a suppression
+// @Description  Remove an email or domain from the suppression list
+// @Tags         Suppressions
+// @Produce      json
+// @Param        id   path      string  true  "Suppression ID"
+// @Success      200  {object}  dto.ResponsePattern
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /suppression/{id} [delete]
+func (h *suppressionHandler) DeleteSuppression(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	suppressionID := c.Param("id")
+	suppressionUUID, err := uuid.Parse(suppressionID)
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteSuppression - invalid suppression ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid suppression ID", http.StatusBadRequest, nil)
+	}
+
+	if err := h.usecase.DeleteSuppression(c, suppressionUUID); err != nil {
+		ac.AppLoger.Errorf("DeleteSuppression - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Suppression deleted successfully", nil, "", "", http.StatusOK, nil)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/suppression"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/database"
+	"gorm.io/gorm"
+)
+
+type suppressionRepository struct {
+	db *gorm.DB
+}
+
+func NewSuppressionRepository(db *gorm.DB) suppression.Repository {
+	return &suppressionRepository{
+		db: db,
+	}
+}
+
+func (r *suppressionRepository) CreateSuppression(tx *gorm.DB, suppression *models.Suppression) error {
+	return tx.Create(suppression).Error
+}
+
+func (r *suppressionRepository) GetSuppression(suppressionID uuid.UUID) (*models.Suppression, error) {
+	var suppression models.Suppression
+	if err := r.db.First(&suppression, "id = ?", suppressionID).Error; err != nil {
+		return nil, err
+	}
+	return &suppression, nil
+}
+
+func (r *suppressionRepository) GetSuppressionByValue(suppressionType models.SuppressionType, value string) (*models.Suppression, error) {
+	var suppression models.Suppression
+	if err := r.db.Where("type = ? AND value = ?", suppressionType, value).First(&suppression).Error; err != nil {
+		return nil, err
+	}
+	return &suppression, nil
+}
+
+func (r *suppressionRepository) ListSuppressions(suppressionType, reason, search string, offset, limit int) ([]models.Suppression, int64, error) {
+	query := r.db.Model(&models.Suppression{})
+	if suppressionType != "" {
+		query = query.Where("type = ?", suppressionType)
+	}
+	if reason != "" {
+		query = query.Where("reason = ?", reason)
+	}
+	if search != "" {
+		query = query.Where("value LIKE ?", "%"+database.EscapeLike(strings.ToLower(search))+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var suppressions []models.Suppression
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&suppressions).Error; err != nil {
+		return nil, 0, err
+	}
+	return suppressions, total, nil
+}
+
+func (r *suppressionRepository) DeleteSuppression(tx *gorm.DB, suppressionID uuid.UUID) error {
+	return tx.Delete(&models.Suppression{}, "id = ?", suppressionID).Error
+}
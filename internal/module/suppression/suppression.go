@@ -0,0 +1,23 @@
+package suppression
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"gorm.io/gorm"
+)
+
+type Usecase interface {
+	CreateSuppression(c echo.Context, req *dto.CreateSuppressionRequest) (*models.Suppression, error)
+	ListSuppressions(c echo.Context, req *dto.ListSuppressionsRequest) ([]models.Suppression, *dto.PaginationMeta, error)
+	DeleteSuppression(c echo.Context, suppressionID uuid.UUID) error
+}
+
+type Repository interface {
+	CreateSuppression(tx *gorm.DB, suppression *models.Suppression) error
+	GetSuppression(suppressionID uuid.UUID) (*models.Suppression, error)
+	GetSuppressionByValue(suppressionType models.SuppressionType, value string) (*models.Suppression, error)
+	ListSuppressions(suppressionType, reason, search string, offset, limit int) ([]models.Suppression, int64, error)
+	DeleteSuppression(tx *gorm.DB, suppressionID uuid.UUID) error
+}
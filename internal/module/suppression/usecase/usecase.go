@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/suppression"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/database"
+	"gorm.io/gorm"
+)
+
+type suppressionUsecase struct {
+	repository suppression.Repository
+}
+
+func NewSuppressionUsecase(repository suppression.Repository) suppression.Usecase {
+	return &suppressionUsecase{
+		repository: repository,
+	}
+}
+
+// CreateSuppression adds an email or a domain to the suppression list. Suppressed contacts are skipped
+// at enrollment and their queued emails are cancelled instead of sent.
+func (u *suppressionUsecase) CreateSuppression(c echo.Context, req *dto.CreateSuppressionRequest) (*models.Suppression, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	suppressionData := &models.Suppression{
+		Type:   models.SuppressionTypeEmail,
+		Value:  strings.ToLower(strings.TrimSpace(req.Email)),
+		Reason: models.SuppressionReason(req.Reason),
+	}
+	if req.Domain != "" {
+		suppressionData.Type = models.SuppressionTypeDomain
+		suppressionData.Value = strings.ToLower(strings.TrimSpace(req.Domain))
+	}
+
+	_, err := u.repository.GetSuppressionByValue(suppressionData.Type, suppressionData.Value)
+	if err == nil {
+		return nil, echo.NewHTTPError(http.StatusConflict, "Suppression already exists")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Errorf("CreateSuppression - failed to fetch suppression: %v", err)
+		return nil, err
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	err = u.repository.CreateSuppression(tx, suppressionData)
+	if database.IsUniqueViolation(err) {
+		return nil, echo.NewHTTPError(http.StatusConflict, "Suppression already exists")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("CreateSuppression - failed to create suppression: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("CreateSuppression - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("CreateSuppression - %s %s suppressed as %s", suppressionData.Type, suppressionData.Value, suppressionData.Reason)
+
+	return suppressionData, nil
+}
+
+func (u *suppressionUsecase) ListSuppressions(c echo.Context, req *dto.ListSuppressionsRequest) ([]models.Suppression, *dto.PaginationMeta, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	page, limit := req.Page, req.Limit
+	if page == 0 {
+		page = dto.DefaultPage
+	}
+	if limit == 0 {
+		limit = dto.DefaultLimit
+	}
+
+	suppressions, total, err := u.repository.ListSuppressions(req.Type, req.Reason, req.Search, (page-1)*limit, limit)
+	if err != nil {
+		ac.AppLoger.Errorf("ListSuppressions - failed to list suppressions: %v", err)
+		return nil, nil, err
+	}
+
+	return suppressions, dto.NewPaginationMeta(page, limit, total), nil
+}
+
+// DeleteSuppression lifts a suppression. Enrollments and emails cancelled while it was in place stay cancelled.
+func (u *suppressionUsecase) DeleteSuppression(c echo.Context, suppressionID uuid.UUID) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	_, err := u.repository.GetSuppression(suppressionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Suppression not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteSuppression - failed to fetch suppression: %v", err)
+		return err
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	if err := u.repository.DeleteSuppression(tx, suppressionID); err != nil {
+		ac.AppLoger.Errorf("DeleteSuppression - failed to delete suppression: %v", err)
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("DeleteSuppression - failed to commit transaction: %v", err)
+		return err
+	}
+	ac.AppLoger.Infof("DeleteSuppression - suppression deleted for ID: %s", suppressionID.String())
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	mock_suppression "github.com/rohanchauhan02/sequence-service/files/mocks/suppression"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockCtx(db *gorm.DB) echo.Context {
+	e := echo.New()
+	return &ctx.CustomApplicationContext{
+		Context:  e.NewContext(nil, nil),
+		Postgres: db,
+		AppLoger: logger.NewLogger(),
+	}
+}
+
+func Test_CreateSuppression(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_suppression.NewMockRepository(ctrl)
+	u := NewSuppressionUsecase(mockRepo)
+
+	tests := []struct {
+		name       string
+		req        *dto.CreateSuppressionRequest
+		setupMocks func()
+		expectTx   bool
+		wantType   models.SuppressionType
+		wantValue  string
+		wantCode   int
+		wantErr    bool
+	}{
+		{
+			name: "success - email is stored in lowercase",
+			req:  &dto.CreateSuppressionRequest{Email: " Lead@Example.com ", Reason: "complaint"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSuppressionByValue(models.SuppressionTypeEmail, "lead@example.com").
+					Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().CreateSuppression(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectTx:  true,
+			wantType:  models.SuppressionTypeEmail,
+			wantValue: "lead@example.com",
+		},
+		{
+			name: "success - whole domain",
+			req:  &dto.CreateSuppressionRequest{Domain: "Competitor.example", Reason: "manual"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSuppressionByValue(models.SuppressionTypeDomain, "competitor.example").
+					Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().CreateSuppression(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectTx:  true,
+			wantType:  models.SuppressionTypeDomain,
+			wantValue: "competitor.example",
+		},
+		{
+			name: "error - already suppressed",
+			req:  &dto.CreateSuppressionRequest{Email: "lead@example.com", Reason: "bounced"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSuppressionByValue(models.SuppressionTypeEmail, "lead@example.com").
+					Return(&models.Suppression{ID: uuid.New()}, nil)
+			},
+			wantCode: http.StatusConflict,
+			wantErr:  true,
+		},
+		{
+			name: "error - unique constraint hit by concurrent create",
+			req:  &dto.CreateSuppressionRequest{Email: "lead@example.com", Reason: "bounced"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetSuppressionByValue(models.SuppressionTypeEmail, "lead@example.com").
+					Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().
+					CreateSuppression(gomock.Any(), gomock.Any()).
+					Return(&pgconn.PgError{Code: "23505"})
+			},
+			expectTx: true,
+			wantCode: http.StatusConflict,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if !tt.wantErr {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			tt.setupMocks()
+
+			got, err := u.CreateSuppression(newMockCtx(gormDB), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateSuppression() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got.Type != tt.wantType || got.Value != tt.wantValue) {
+				t.Errorf("CreateSuppression() = %+v, want %s %s", got, tt.wantType, tt.wantValue)
+			}
+
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("CreateSuppression() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_DeleteSuppression(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_suppression.NewMockRepository(ctrl)
+	u := NewSuppressionUsecase(mockRepo)
+
+	suppressionID := uuid.New()
+
+	t.Run("success - suppression is removed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		mockRepo.EXPECT().GetSuppression(suppressionID).Return(&models.Suppression{ID: suppressionID}, nil)
+		mockRepo.EXPECT().DeleteSuppression(gomock.Any(), suppressionID).Return(nil)
+
+		if err := u.DeleteSuppression(newMockCtx(gormDB), suppressionID); err != nil {
+			t.Errorf("DeleteSuppression() unexpected error: %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet SQL expectations: %v", err)
+		}
+	})
+
+	t.Run("error - suppression not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetSuppression(suppressionID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := u.DeleteSuppression(newMockCtx(gormDB), suppressionID)
+		var httpErr *echo.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != http.StatusNotFound {
+			t.Errorf("DeleteSuppression() error = %v, want status %d", err, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package https
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/module/tracking"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/middleware"
+)
+
+// pixel is a transparent 1x1 GIF.
+var pixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// maxInboundMessageSize caps the size of inbound messages, which may quote the whole original email.
+const maxInboundMessageSize = 10 << 20
+
+// unsubscribePage is shown for unsubscribe links opened in a browser. Unsubscribing needs a POST, so
+// link scanners that follow the link do not unsubscribe the recipient.
+const unsubscribePage = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Unsubscribe</title></head>
+<body><p>%s</p>%s</body></html>`
+
+type trackingHandler struct {
+	usecase tracking.Usecase
+}
+
+func NewTrackingHandler(e *echo.Echo, usecase tracking.Usecase) {
+	h := &trackingHandler{
+		usecase: usecase,
+	}
+
+	api := e.Group("/api/v1")
+
+	api.GET("/track/open/:token", h.TrackOpen)
+	api.GET("/track/click/:token", h.TrackClick)
+	api.GET("/unsubscribe/:token", h.UnsubscribeForm)
+	api.POST("/unsubscribe/:token", h.Unsubscribe)
+	api.POST("/inbound/replies", h.RecordReply, middleware.MiddlewareWebhookSecret())
+	api.POST("/inbound/bounces", h.RecordBounce, middleware.MiddlewareWebhookSecret())
+}
+
+// TrackOpen godoc
+// @Summary      Track an email open
+// @Description  Tracking pixel embedded in emails of sequences with open tracking enabled. Always responds with a 1x1 GIF.
+// @Tags         Tracking
+// @Produce      image/gif
+// @Param        token  path  string  true  "Signed tracking token"
+// @Success      200
+// @Router       /track/open/{token} [get]
+func (h *trackingHandler) TrackOpen(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	// Mail clients must always get an image back, so failures are only logged.
+	if err := h.usecase.TrackOpen(c, c.Param("token")); err != nil {
+		ac.AppLoger.Errorf("TrackOpen - usecase error: %v", err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store, no-cache, must-revalidate, max-age=0")
+	return c.Blob(http.StatusOK, "image/gif", pixel)
+}
+
+// TrackClick godoc
+// @Summary      Track a link click
+// @Description  Records the click and redirects to the original link of the email
+// @Tags         Tracking
+// @Param        token  path  string  true  "Signed tracking token"
+// @Success      302
+// @Failure      400  {object}  dto.ResponsePattern
+// @Router       /track/click/{token} [get]
+func (h *trackingHandler) TrackClick(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	url, err := h.usecase.TrackClick(c, c.Param("token"))
+	if err != nil {
+		ac.AppLoger.Errorf("TrackClick - usecase error: %v", err)
+		// The recipient still reaches the link when only recording the click failed.
+		if url == "" {
+			return ac.CustomErrorResponse(err)
+		}
+	}
+
+	return c.Redirect(http.StatusFound, url)
+}
+
+// UnsubscribeForm godoc
+// @Summary      Unsubscribe confirmation page
+// @Description  Page behind the unsubscribe link of emails, asks the recipient to confirm with a POST
+// @Tags         Tracking
+// @Produce      html
+// @Param        token  path  string  true  "Signed unsubscribe token"
+// @Success      200
+// @Router       /unsubscribe/{token} [get]
+func (h *trackingHandler) UnsubscribeForm(c echo.Context) error {
+	form := `<form method="post"><input type="hidden" name="List-Unsubscribe" value="One-Click"><button type="submit">Unsubscribe</button></form>`
+	return c.HTML(http.StatusOK, fmt.Sprintf(unsubscribePage, "Do you want to stop receiving these emails?", form))
+}
+
+// Unsubscribe godoc
+// @Summary      Unsubscribe a recipient
+// @Description  RFC 8058 one-click unsubscribe. Marks the contact unsubscribed, suppresses the address and cancels their enrollments and pending emails
+// @Tags         Tracking
+// @Produce      html
+// @Param        token  path  string  true  "Signed unsubscribe token"
+// @Success      200
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Router       /unsubscribe/{token} [post]
+func (h *trackingHandler) Unsubscribe(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	if err := h.usecase.Unsubscribe(c, c.Param("token")); err != nil {
+		ac.AppLoger.Errorf("Unsubscribe - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return c.HTML(http.StatusOK, fmt.Sprintf(unsubscribePage, "You have been unsubscribed and will not receive further emails.", ""))
+}
+
+// RecordReply godoc
+// @Summary      Ingest an inbound reply
+// @Description  Webhook for inbound mail. Matches the reply to a sent email by its In-Reply-To and References headers, records a replied event and completes the enrollment
+// @Tags         Tracking
+// @Accept       json
+// @Produce      json
+// @Param        X-Webhook-Secret  header  string                   true  "Shared webhook secret"
+// @Param        request           body    dto.InboundReplyRequest  true  "Inbound reply"
+// @Success      200  {object}  dto.ResponsePattern{data=dto.InboundReplyResponse}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      401  {object}  dto.ResponsePattern
+// @Router       /inbound/replies [post]
+func (h *trackingHandler) RecordReply(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.InboundReplyRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("RecordReply - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	resp, err := h.usecase.RecordReply(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("RecordReply - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Reply processed successfully", resp, "", "", http.StatusOK, nil)
+}
+
+// RecordBounce godoc
+// @Summary      Ingest a bounce
+// @Description  Webhook for inbound mail. Takes a raw delivery status notification (RFC 3464), classifies it as a hard or soft bounce and records it against the email it reports. Hard bounces end the enrollment and suppress the recipient
+// @Tags         Tracking
+// @Accept       message/rfc822
+// @Produce      json
+// @Param        X-Webhook-Secret  header  string  true  "Shared webhook secret"
+// @Success      200  {object}  dto.ResponsePattern{data=dto.InboundBounceResponse}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      401  {object}  dto.ResponsePattern
+// @Router       /inbound/bounces [post]
+func (h *trackingHandler) RecordBounce(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	resp, err := h.usecase.RecordBounce(c, io.LimitReader(c.Request().Body, maxInboundMessageSize))
+	if err != nil {
+		ac.AppLoger.Errorf("RecordBounce - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Bounce processed successfully", resp, "", "", http.StatusOK, nil)
+}
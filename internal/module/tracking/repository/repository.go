@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/tracking"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/unenroll"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type trackingRepository struct {
+	db *gorm.DB
+}
+
+func NewTrackingRepository(db *gorm.DB) tracking.Repository {
+	return &trackingRepository{
+		db: db,
+	}
+}
+
+func (r *trackingRepository) CreateEmailEvent(tx *gorm.DB, emailEvent *models.EmailEvent) error {
+	return tx.Create(emailEvent).Error
+}
+
+// GetEmailContact returns the recipient of an email and locks it, so concurrent unsubscribes of the same
+// contact are applied once.
+func (r *trackingRepository) GetEmailContact(tx *gorm.DB, emailQueueID uuid.UUID) (*models.Contact, error) {
+	var contact models.Contact
+	if err := tx.Joins("JOIN sequence_contacts sc ON sc.contact_id = contacts.id").
+		Joins("JOIN email_queues eq ON eq.sequence_contact_id = sc.id").
+		Where("eq.id = ?", emailQueueID).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "contacts"}}).
+		First(&contact).Error; err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *trackingRepository) UnsubscribeContact(tx *gorm.DB, contactID uuid.UUID) error {
+	return tx.Model(&models.Contact{}).
+		Where("id = ?", contactID).
+		Update("status", models.ContactStatusUnsubscribed).Error
+}
+
+func (r *trackingRepository) CreateSuppression(tx *gorm.DB, suppression *models.Suppression) error {
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(suppression).Error
+}
+
+// CancelContactEnrollments cancels the enrollments of a contact that did not finish yet and returns how
+// many were cancelled.
+func (r *trackingRepository) CancelContactEnrollments(tx *gorm.DB, contactID uuid.UUID) (int64, error) {
+	return unenroll.Contact(tx, contactID)
+}
+
+// CancelContactEmails cancels the scheduled and queued emails of a contact and gives back the mailbox
+// capacity reserved for them. It returns how many emails were cancelled.
+func (r *trackingRepository) CancelContactEmails(tx *gorm.DB, contactID uuid.UUID, errorMessage string) (int64, error) {
+	return unenroll.ContactEmails(tx, contactID, errorMessage)
+}
+
+// GetEmailByMessageIDs returns the most recent email sent with one of the Message-IDs.
+func (r *trackingRepository) GetEmailByMessageIDs(tx *gorm.DB, messageIDs []string) (*models.EmailQueue, error) {
+	var emailQueue models.EmailQueue
+	if err := tx.Where("message_id IN ?", messageIDs).
+		Order("created_at DESC").
+		First(&emailQueue).Error; err != nil {
+		return nil, err
+	}
+	return &emailQueue, nil
+}
+
+// HasEvent reports whether an event of the type whose data contains eventData was already recorded for
+// an email, so webhook deliveries that are retried are recorded once.
+func (r *trackingRepository) HasEvent(tx *gorm.DB, emailQueueID uuid.UUID, eventType models.EmailEventType, eventData models.EventData) (bool, error) {
+	var count int64
+	if err := tx.Model(&models.EmailEvent{}).
+		Where("email_queue_id = ? AND event_type = ? AND event_data @> ?::jsonb", emailQueueID, eventType, eventData).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CompleteEnrollment completes an enrollment that did not finish yet with the given reason. It reports
+// false when the enrollment already ended.
+func (r *trackingRepository) CompleteEnrollment(tx *gorm.DB, sequenceContactID uuid.UUID, reason string) (bool, error) {
+	return unenroll.Enrollment(tx, sequenceContactID, models.SequenceContactStatusCompleted, reason)
+}
+
+// RecordBounce counts a bounce of an email against its mailbox. A hard bounce also ends the enrollment,
+// cancels its pending emails and suppresses the recipient.
+func (r *trackingRepository) RecordBounce(tx *gorm.DB, emailQueueID, sequenceContactID uuid.UUID, recipient string, kind bounce.Kind) error {
+	return unenroll.Bounce(tx, emailQueueID, sequenceContactID, recipient, kind)
+}
+
+// CancelEnrollmentEmails cancels the scheduled and queued emails of an enrollment and gives back the
+// mailbox capacity reserved for them. It returns how many emails were cancelled.
+func (r *trackingRepository) CancelEnrollmentEmails(tx *gorm.DB, sequenceContactID uuid.UUID, errorMessage string) (int64, error) {
+	return unenroll.EnrollmentEmails(tx, sequenceContactID, errorMessage)
+}
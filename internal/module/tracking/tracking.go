@@ -0,0 +1,34 @@
+package tracking
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"gorm.io/gorm"
+)
+
+type Usecase interface {
+	TrackOpen(c echo.Context, token string) error
+	TrackClick(c echo.Context, token string) (string, error)
+	Unsubscribe(c echo.Context, token string) error
+	RecordReply(c echo.Context, req *dto.InboundReplyRequest) (*dto.InboundReplyResponse, error)
+	RecordBounce(c echo.Context, message io.Reader) (*dto.InboundBounceResponse, error)
+}
+
+type Repository interface {
+	CreateEmailEvent(tx *gorm.DB, emailEvent *models.EmailEvent) error
+	GetEmailContact(tx *gorm.DB, emailQueueID uuid.UUID) (*models.Contact, error)
+	UnsubscribeContact(tx *gorm.DB, contactID uuid.UUID) error
+	CreateSuppression(tx *gorm.DB, suppression *models.Suppression) error
+	CancelContactEnrollments(tx *gorm.DB, contactID uuid.UUID) (int64, error)
+	CancelContactEmails(tx *gorm.DB, contactID uuid.UUID, errorMessage string) (int64, error)
+	GetEmailByMessageIDs(tx *gorm.DB, messageIDs []string) (*models.EmailQueue, error)
+	HasEvent(tx *gorm.DB, emailQueueID uuid.UUID, eventType models.EmailEventType, eventData models.EventData) (bool, error)
+	CompleteEnrollment(tx *gorm.DB, sequenceContactID uuid.UUID, reason string) (bool, error)
+	CancelEnrollmentEmails(tx *gorm.DB, sequenceContactID uuid.UUID, errorMessage string) (int64, error)
+	RecordBounce(tx *gorm.DB, emailQueueID, sequenceContactID uuid.UUID, recipient string, kind bounce.Kind) error
+}
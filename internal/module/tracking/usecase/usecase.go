@@ -0,0 +1,352 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/module/tracking"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	pkgTracking "github.com/rohanchauhan02/sequence-service/internal/pkg/tracking"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/mail"
+	"gorm.io/gorm"
+)
+
+type trackingUsecase struct {
+	repository tracking.Repository
+	tracker    pkgTracking.Tracker
+}
+
+func NewTrackingUsecase(repository tracking.Repository, tracker pkgTracking.Tracker) tracking.Usecase {
+	return &trackingUsecase{
+		repository: repository,
+		tracker:    tracker,
+	}
+}
+
+// TrackOpen records an opened event for the email identified by the pixel token.
+func (u *trackingUsecase) TrackOpen(c echo.Context, token string) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	openToken, err := u.tracker.ParseOpenToken(token)
+	if err != nil {
+		ac.AppLoger.Warnf("TrackOpen - invalid token: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid tracking token")
+	}
+
+	return u.recordEvent(c, openToken.EmailQueueID, models.EmailEventTypeOpened, models.EventData{
+		"user_agent": c.Request().UserAgent(),
+		"ip":         c.RealIP(),
+	})
+}
+
+// TrackClick records a clicked event and returns the original link to redirect to.
+func (u *trackingUsecase) TrackClick(c echo.Context, token string) (string, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	clickToken, err := u.tracker.ParseClickToken(token)
+	if err != nil {
+		ac.AppLoger.Warnf("TrackClick - invalid token: %v", err)
+		return "", echo.NewHTTPError(http.StatusBadRequest, "Invalid tracking link")
+	}
+
+	err = u.recordEvent(c, clickToken.EmailQueueID, models.EmailEventTypeClicked, models.EventData{
+		"url":        clickToken.URL,
+		"user_agent": c.Request().UserAgent(),
+		"ip":         c.RealIP(),
+	})
+	return clickToken.URL, err
+}
+
+// Unsubscribe unsubscribes the recipient of the email identified by the unsubscribe token. The contact
+// is suppressed, their unfinished enrollments and pending emails are cancelled and an unsubscribed event
+// is recorded. Unsubscribing a contact again is a no-op, mail clients may repeat one-click requests.
+func (u *trackingUsecase) Unsubscribe(c echo.Context, token string) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	unsubscribeToken, err := u.tracker.ParseUnsubscribeToken(token)
+	if err != nil {
+		ac.AppLoger.Warnf("Unsubscribe - invalid token: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid unsubscribe link")
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	contact, err := u.repository.GetEmailContact(tx, unsubscribeToken.EmailQueueID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Contact not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("Unsubscribe - failed to fetch recipient of email %s: %v", unsubscribeToken.EmailQueueID, err)
+		return err
+	}
+
+	if contact.Status == models.ContactStatusUnsubscribed {
+		return nil
+	}
+
+	if err := u.repository.UnsubscribeContact(tx, contact.ID); err != nil {
+		ac.AppLoger.Errorf("Unsubscribe - failed to update contact: %v", err)
+		return err
+	}
+
+	suppression := &models.Suppression{
+		Type:   models.SuppressionTypeEmail,
+		Value:  strings.ToLower(strings.TrimSpace(contact.Email)),
+		Reason: models.SuppressionReasonUnsubscribed,
+	}
+	if err := u.repository.CreateSuppression(tx, suppression); err != nil {
+		ac.AppLoger.Errorf("Unsubscribe - failed to suppress email: %v", err)
+		return err
+	}
+
+	cancelledEnrollments, err := u.repository.CancelContactEnrollments(tx, contact.ID)
+	if err != nil {
+		ac.AppLoger.Errorf("Unsubscribe - failed to cancel enrollments: %v", err)
+		return err
+	}
+
+	cancelledEmails, err := u.repository.CancelContactEmails(tx, contact.ID, "recipient unsubscribed")
+	if err != nil {
+		ac.AppLoger.Errorf("Unsubscribe - failed to cancel pending emails: %v", err)
+		return err
+	}
+
+	emailEvent := &models.EmailEvent{
+		EmailQueueID: unsubscribeToken.EmailQueueID,
+		EventType:    models.EmailEventTypeUnsubscribed,
+		EventData: models.EventData{
+			"contact_id":            contact.ID,
+			"cancelled_enrollments": cancelledEnrollments,
+			"cancelled_emails":      cancelledEmails,
+			"user_agent":            c.Request().UserAgent(),
+			"ip":                    c.RealIP(),
+		},
+	}
+	if err := u.repository.CreateEmailEvent(tx, emailEvent); err != nil {
+		ac.AppLoger.Errorf("Unsubscribe - failed to record unsubscribed event: %v", err)
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("Unsubscribe - failed to commit transaction: %v", err)
+		return err
+	}
+	ac.AppLoger.Infof("Unsubscribe - contact %s unsubscribed, cancelled enrollments: %d, emails: %d", contact.ID, cancelledEnrollments, cancelledEmails)
+
+	u.publishEvent(c, emailEvent)
+	return nil
+}
+
+// RecordReply matches an inbound reply to the email it answers through its In-Reply-To and References
+// headers, records a replied event and completes the enrollment so no further steps are sent. Replies
+// that do not answer an email of the service are reported as not matched.
+func (u *trackingUsecase) RecordReply(c echo.Context, req *dto.InboundReplyRequest) (*dto.InboundReplyResponse, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	resp := &dto.InboundReplyResponse{}
+
+	messageIDs := mail.ParseMessageIDs(req.InReplyTo, req.References)
+	if len(messageIDs) == 0 {
+		return resp, nil
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	emailQueue, err := u.repository.GetEmailByMessageIDs(tx, messageIDs)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Infof("RecordReply - reply from %s does not match a sent email", req.From)
+		return resp, nil
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("RecordReply - failed to match reply: %v", err)
+		return nil, err
+	}
+	resp.Matched = true
+	resp.EmailQueueID = &emailQueue.ID
+	resp.SequenceContactID = &emailQueue.SequenceContactID
+
+	if req.MessageID != "" {
+		recorded, err := u.repository.HasEvent(tx, emailQueue.ID, models.EmailEventTypeReplied, models.EventData{"message_id": req.MessageID})
+		if err != nil {
+			ac.AppLoger.Errorf("RecordReply - failed to check for a recorded reply: %v", err)
+			return nil, err
+		}
+		if recorded {
+			return resp, nil
+		}
+	}
+
+	resp.Stopped, err = u.repository.CompleteEnrollment(tx, emailQueue.SequenceContactID, models.CompletedReasonReplied)
+	if err != nil {
+		ac.AppLoger.Errorf("RecordReply - failed to complete enrollment: %v", err)
+		return nil, err
+	}
+
+	if resp.Stopped {
+		if _, err := u.repository.CancelEnrollmentEmails(tx, emailQueue.SequenceContactID, "recipient replied"); err != nil {
+			ac.AppLoger.Errorf("RecordReply - failed to cancel pending emails: %v", err)
+			return nil, err
+		}
+	}
+
+	emailEvent := &models.EmailEvent{
+		EmailQueueID: emailQueue.ID,
+		EventType:    models.EmailEventTypeReplied,
+		EventData: models.EventData{
+			"from":       req.From,
+			"subject":    req.Subject,
+			"message_id": req.MessageID,
+		},
+	}
+	if err := u.repository.CreateEmailEvent(tx, emailEvent); err != nil {
+		ac.AppLoger.Errorf("RecordReply - failed to record replied event: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("RecordReply - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("RecordReply - reply to email %s recorded, enrollment stopped: %v", emailQueue.ID, resp.Stopped)
+
+	u.publishEvent(c, emailEvent)
+	return resp, nil
+}
+
+// RecordBounce parses a delivery status notification posted by the inbound mail webhook and matches it
+// to the email it reports through the Message-ID of the returned message. The bounce is recorded as a
+// bounced event and counted against the mailbox; a hard bounce also ends the enrollment, cancels its
+// pending emails and suppresses the recipient. Soft bounces are only recorded, the reporting server
+// already retried the delivery.
+func (u *trackingUsecase) RecordBounce(c echo.Context, message io.Reader) (*dto.InboundBounceResponse, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	resp := &dto.InboundBounceResponse{}
+
+	b, err := bounce.ParseDSN(message)
+	if errors.Is(err, bounce.ErrNotBounce) {
+		return resp, nil
+	}
+	if err != nil {
+		ac.AppLoger.Warnf("RecordBounce - invalid delivery status notification: %v", err)
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid delivery status notification: "+err.Error())
+	}
+	if b.MessageID == "" {
+		ac.AppLoger.Infof("RecordBounce - bounce for %s has no original Message-ID", b.Recipient)
+		return resp, nil
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	emailQueue, err := u.repository.GetEmailByMessageIDs(tx, []string{b.MessageID})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Infof("RecordBounce - bounce for %s does not match a sent email", b.Recipient)
+		return resp, nil
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("RecordBounce - failed to match bounce: %v", err)
+		return nil, err
+	}
+	resp.Matched = true
+	resp.EmailQueueID = &emailQueue.ID
+	resp.BounceType = string(b.Kind)
+
+	dedupe := models.EventData{"source": "dsn", "bounce_type": b.Kind}
+	recorded, err := u.repository.HasEvent(tx, emailQueue.ID, models.EmailEventTypeBounced, dedupe)
+	if err != nil {
+		ac.AppLoger.Errorf("RecordBounce - failed to check for a recorded bounce: %v", err)
+		return nil, err
+	}
+	if recorded {
+		return resp, nil
+	}
+
+	recipient := b.Recipient
+	if b.Kind == bounce.KindHard && recipient == "" {
+		contact, err := u.repository.GetEmailContact(tx, emailQueue.ID)
+		if err != nil {
+			ac.AppLoger.Errorf("RecordBounce - failed to fetch recipient of email %s: %v", emailQueue.ID, err)
+			return nil, err
+		}
+		recipient = contact.Email
+	}
+
+	if err := u.repository.RecordBounce(tx, emailQueue.ID, emailQueue.SequenceContactID, recipient, b.Kind); err != nil {
+		ac.AppLoger.Errorf("RecordBounce - failed to record bounce: %v", err)
+		return nil, err
+	}
+	resp.Suppressed = b.Kind == bounce.KindHard
+
+	emailEvent := &models.EmailEvent{
+		EmailQueueID: emailQueue.ID,
+		EventType:    models.EmailEventTypeBounced,
+		EventData: models.EventData{
+			"source":      "dsn",
+			"bounce_type": b.Kind,
+			"code":        b.Code,
+			"status":      b.Status,
+			"diagnostic":  b.Diagnostic,
+			"recipient":   b.Recipient,
+		},
+	}
+	if err := u.repository.CreateEmailEvent(tx, emailEvent); err != nil {
+		ac.AppLoger.Errorf("RecordBounce - failed to record bounced event: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("RecordBounce - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("RecordBounce - %s bounce of email %s recorded", b.Kind, emailQueue.ID)
+
+	u.publishEvent(c, emailEvent)
+	return resp, nil
+}
+
+// recordEvent stores the event and publishes it to the email-events topic. A failed publish is only
+// logged since the email_events row is the record of truth.
+func (u *trackingUsecase) recordEvent(c echo.Context, emailQueueID uuid.UUID, eventType models.EmailEventType, eventData models.EventData) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	emailEvent := &models.EmailEvent{
+		EmailQueueID: emailQueueID,
+		EventType:    eventType,
+		EventData:    eventData,
+	}
+	if err := u.repository.CreateEmailEvent(ac.Postgres, emailEvent); err != nil {
+		ac.AppLoger.Errorf("recordEvent - failed to record %s event for email %s: %v", eventType, emailQueueID, err)
+		return err
+	}
+
+	u.publishEvent(c, emailEvent)
+	return nil
+}
+
+func (u *trackingUsecase) publishEvent(c echo.Context, emailEvent *models.EmailEvent) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	message, err := json.Marshal(&dto.EmailEventMessage{
+		ID:           emailEvent.ID,
+		EmailQueueID: emailEvent.EmailQueueID,
+		EventType:    emailEvent.EventType,
+		EventData:    emailEvent.EventData,
+		OccurredAt:   emailEvent.CreatedAt,
+	})
+	if err != nil {
+		ac.AppLoger.Errorf("publishEvent - failed to marshal %s event %s: %v", emailEvent.EventType, emailEvent.ID, err)
+		return
+	}
+	if err := ac.Kakfa.Publish(ac.Config.GetKafkaConf().Topics.EmailEvents, message); err != nil {
+		ac.AppLoger.Errorf("publishEvent - failed to publish %s event %s: %v", emailEvent.EventType, emailEvent.ID, err)
+	}
+}
@@ -0,0 +1,628 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	mock_config "github.com/rohanchauhan02/sequence-service/files/mocks/config"
+	mock_kafka "github.com/rohanchauhan02/sequence-service/files/mocks/kafka"
+	mock_tracking "github.com/rohanchauhan02/sequence-service/files/mocks/tracking"
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	pkgTracking "github.com/rohanchauhan02/sequence-service/internal/pkg/tracking"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const testKey = "c2VxdWVuY2Utc2VydmljZS1zaWduaW5nLWtleS0zMmI="
+
+func Test_TrackClick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	signer, err := crypto.NewHMACSigner(testKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	mockRepo := mock_tracking.NewMockRepository(ctrl)
+	mockKafka := mock_kafka.NewMockKafkaClient(ctrl)
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	u := NewTrackingUsecase(mockRepo, pkgTracking.NewTracker("https://track.example.com", signer))
+
+	emailQueueID := uuid.New()
+	token, err := signer.Sign(&pkgTracking.ClickToken{EmailQueueID: emailQueueID, URL: "https://example.com/offer"})
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	newCtx := func() echo.Context {
+		e := echo.New()
+		return &ctx.CustomApplicationContext{
+			Context:  e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder()),
+			AppLoger: logger.NewLogger(),
+			Config:   mockConf,
+			Kakfa:    mockKafka,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		setupMocks func()
+		wantURL    string
+		wantErr    bool
+	}{
+		{
+			name:  "success - record click and publish the event",
+			token: token,
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					CreateEmailEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailEvent *models.EmailEvent) error {
+						if emailEvent.EmailQueueID != emailQueueID || emailEvent.EventType != models.EmailEventTypeClicked || emailEvent.EventData["url"] != "https://example.com/offer" {
+							t.Errorf("unexpected email event: %+v", emailEvent)
+						}
+						return nil
+					})
+				mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailEvents: "email-events"}})
+				mockKafka.EXPECT().Publish("email-events", gomock.Any()).Return(errors.New("broker down"))
+			},
+			wantURL: "https://example.com/offer",
+		},
+		{
+			name:       "error - tampered token",
+			token:      token + "x",
+			setupMocks: func() {},
+			wantErr:    true,
+		},
+		{
+			name:  "error - event not recorded still returns the link",
+			token: token,
+			setupMocks: func() {
+				mockRepo.EXPECT().CreateEmailEvent(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			},
+			wantURL: "https://example.com/offer",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			url, err := u.TrackClick(newCtx(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TrackClick() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if url != tt.wantURL {
+				t.Errorf("TrackClick() url = %q, want %q", url, tt.wantURL)
+			}
+		})
+	}
+}
+
+func Test_Unsubscribe(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	signer, err := crypto.NewHMACSigner(testKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	tracker := pkgTracking.NewTracker("https://track.example.com", signer)
+	mockRepo := mock_tracking.NewMockRepository(ctrl)
+	mockKafka := mock_kafka.NewMockKafkaClient(ctrl)
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	u := NewTrackingUsecase(mockRepo, tracker)
+
+	emailQueueID, contactID := uuid.New(), uuid.New()
+	unsubscribeURL, err := tracker.UnsubscribeURL(emailQueueID)
+	if err != nil {
+		t.Fatalf("failed to sign unsubscribe link: %v", err)
+	}
+	token := strings.TrimPrefix(unsubscribeURL, "https://track.example.com"+pkgTracking.UnsubscribePath)
+
+	openToken, err := signer.Sign(&pkgTracking.OpenToken{EmailQueueID: emailQueueID})
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	newCtx := func() echo.Context {
+		e := echo.New()
+		return &ctx.CustomApplicationContext{
+			Context:  e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder()),
+			Postgres: gormDB,
+			AppLoger: logger.NewLogger(),
+			Config:   mockConf,
+			Kakfa:    mockKafka,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		setupMocks func()
+		expectTx   bool
+		wantCommit bool
+		wantCode   int
+		wantErr    bool
+	}{
+		{
+			name:  "success - contact unsubscribed, suppressed and enrollments cancelled",
+			token: token,
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetEmailContact(gomock.Any(), emailQueueID).
+					Return(&models.Contact{ID: contactID, Email: "Lead@Example.com", Status: models.ContactStatusActive}, nil)
+				mockRepo.EXPECT().UnsubscribeContact(gomock.Any(), contactID).Return(nil)
+				mockRepo.EXPECT().
+					CreateSuppression(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, suppression *models.Suppression) error {
+						if suppression.Type != models.SuppressionTypeEmail || suppression.Value != "lead@example.com" || suppression.Reason != models.SuppressionReasonUnsubscribed {
+							t.Errorf("unexpected suppression: %+v", suppression)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().CancelContactEnrollments(gomock.Any(), contactID).Return(int64(2), nil)
+				mockRepo.EXPECT().CancelContactEmails(gomock.Any(), contactID, gomock.Any()).Return(int64(1), nil)
+				mockRepo.EXPECT().
+					CreateEmailEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailEvent *models.EmailEvent) error {
+						if emailEvent.EmailQueueID != emailQueueID || emailEvent.EventType != models.EmailEventTypeUnsubscribed {
+							t.Errorf("unexpected email event: %+v", emailEvent)
+						}
+						return nil
+					})
+				mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailEvents: "email-events"}})
+				mockKafka.EXPECT().Publish("email-events", gomock.Any()).Return(nil)
+			},
+			expectTx:   true,
+			wantCommit: true,
+		},
+		{
+			name:  "success - already unsubscribed contact is left as it is",
+			token: token,
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetEmailContact(gomock.Any(), emailQueueID).
+					Return(&models.Contact{ID: contactID, Status: models.ContactStatusUnsubscribed}, nil)
+			},
+			expectTx: true,
+		},
+		{
+			name:       "error - open token is not an unsubscribe token",
+			token:      openToken,
+			setupMocks: func() {},
+			wantCode:   http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:  "error - email no longer exists",
+			token: token,
+			setupMocks: func() {
+				mockRepo.EXPECT().GetEmailContact(gomock.Any(), emailQueueID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectTx: true,
+			wantCode: http.StatusNotFound,
+			wantErr:  true,
+		},
+		{
+			name:  "error - cancelling emails fails",
+			token: token,
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					GetEmailContact(gomock.Any(), emailQueueID).
+					Return(&models.Contact{ID: contactID, Email: "lead@example.com"}, nil)
+				mockRepo.EXPECT().UnsubscribeContact(gomock.Any(), contactID).Return(nil)
+				mockRepo.EXPECT().CreateSuppression(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CancelContactEnrollments(gomock.Any(), contactID).Return(int64(1), nil)
+				mockRepo.EXPECT().CancelContactEmails(gomock.Any(), contactID, gomock.Any()).Return(int64(0), errors.New("db error"))
+			},
+			expectTx: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if tt.wantCommit {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			tt.setupMocks()
+
+			err := u.Unsubscribe(newCtx(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unsubscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("Unsubscribe() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_RecordReply(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	signer, err := crypto.NewHMACSigner(testKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	mockRepo := mock_tracking.NewMockRepository(ctrl)
+	mockKafka := mock_kafka.NewMockKafkaClient(ctrl)
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	u := NewTrackingUsecase(mockRepo, pkgTracking.NewTracker("https://track.example.com", signer))
+
+	emailQueue := &models.EmailQueue{ID: uuid.New(), SequenceContactID: uuid.New()}
+	reply := &dto.InboundReplyRequest{
+		From:       "lead@example.com",
+		Subject:    "Re: Quick question",
+		MessageID:  "<reply-1@example.com>",
+		InReplyTo:  "<step-2@acme.example>",
+		References: "<step-1@acme.example> <step-2@acme.example>",
+	}
+
+	newCtx := func() echo.Context {
+		e := echo.New()
+		return &ctx.CustomApplicationContext{
+			Context:  e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder()),
+			Postgres: gormDB,
+			AppLoger: logger.NewLogger(),
+			Config:   mockConf,
+			Kakfa:    mockKafka,
+		}
+	}
+
+	matchEmail := func() {
+		mockRepo.EXPECT().
+			GetEmailByMessageIDs(gomock.Any(), []string{"<step-2@acme.example>", "<step-1@acme.example>"}).
+			Return(emailQueue, nil)
+	}
+
+	tests := []struct {
+		name        string
+		req         *dto.InboundReplyRequest
+		setupMocks  func()
+		expectTx    bool
+		wantCommit  bool
+		wantMatched bool
+		wantStopped bool
+		wantErr     bool
+	}{
+		{
+			name: "success - reply stops the enrollment",
+			req:  reply,
+			setupMocks: func() {
+				matchEmail()
+				mockRepo.EXPECT().HasEvent(gomock.Any(), emailQueue.ID, models.EmailEventTypeReplied, models.EventData{"message_id": "<reply-1@example.com>"}).Return(false, nil)
+				mockRepo.EXPECT().CompleteEnrollment(gomock.Any(), emailQueue.SequenceContactID, models.CompletedReasonReplied).Return(true, nil)
+				mockRepo.EXPECT().CancelEnrollmentEmails(gomock.Any(), emailQueue.SequenceContactID, gomock.Any()).Return(int64(1), nil)
+				mockRepo.EXPECT().
+					CreateEmailEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailEvent *models.EmailEvent) error {
+						if emailEvent.EmailQueueID != emailQueue.ID || emailEvent.EventType != models.EmailEventTypeReplied || emailEvent.EventData["from"] != "lead@example.com" {
+							t.Errorf("unexpected email event: %+v", emailEvent)
+						}
+						return nil
+					})
+				mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailEvents: "email-events"}})
+				mockKafka.EXPECT().Publish("email-events", gomock.Any()).Return(nil)
+			},
+			expectTx:    true,
+			wantCommit:  true,
+			wantMatched: true,
+			wantStopped: true,
+		},
+		{
+			name: "success - reply to an ended enrollment is only recorded",
+			req:  reply,
+			setupMocks: func() {
+				matchEmail()
+				mockRepo.EXPECT().HasEvent(gomock.Any(), emailQueue.ID, models.EmailEventTypeReplied, models.EventData{"message_id": "<reply-1@example.com>"}).Return(false, nil)
+				mockRepo.EXPECT().CompleteEnrollment(gomock.Any(), emailQueue.SequenceContactID, models.CompletedReasonReplied).Return(false, nil)
+				mockRepo.EXPECT().CreateEmailEvent(gomock.Any(), gomock.Any()).Return(nil)
+				mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailEvents: "email-events"}})
+				mockKafka.EXPECT().Publish("email-events", gomock.Any()).Return(nil)
+			},
+			expectTx:    true,
+			wantCommit:  true,
+			wantMatched: true,
+		},
+		{
+			name: "success - redelivered reply is recorded once",
+			req:  reply,
+			setupMocks: func() {
+				matchEmail()
+				mockRepo.EXPECT().HasEvent(gomock.Any(), emailQueue.ID, models.EmailEventTypeReplied, models.EventData{"message_id": "<reply-1@example.com>"}).Return(true, nil)
+			},
+			expectTx:    true,
+			wantMatched: true,
+		},
+		{
+			name:       "success - reply without Message-IDs is not matched",
+			req:        &dto.InboundReplyRequest{From: "lead@example.com", InReplyTo: "unknown"},
+			setupMocks: func() {},
+		},
+		{
+			name: "success - reply to an unknown email is not matched",
+			req:  reply,
+			setupMocks: func() {
+				mockRepo.EXPECT().GetEmailByMessageIDs(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectTx: true,
+		},
+		{
+			name: "error - completing the enrollment fails",
+			req:  reply,
+			setupMocks: func() {
+				matchEmail()
+				mockRepo.EXPECT().HasEvent(gomock.Any(), emailQueue.ID, models.EmailEventTypeReplied, models.EventData{"message_id": "<reply-1@example.com>"}).Return(false, nil)
+				mockRepo.EXPECT().CompleteEnrollment(gomock.Any(), emailQueue.SequenceContactID, models.CompletedReasonReplied).Return(false, errors.New("db error"))
+			},
+			expectTx: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if tt.wantCommit {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			tt.setupMocks()
+
+			resp, err := u.RecordReply(newCtx(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecordReply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (resp.Matched != tt.wantMatched || resp.Stopped != tt.wantStopped) {
+				t.Errorf("RecordReply() = %+v, want matched %v stopped %v", resp, tt.wantMatched, tt.wantStopped)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_RecordBounce(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	signer, err := crypto.NewHMACSigner(testKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	mockRepo := mock_tracking.NewMockRepository(ctrl)
+	mockKafka := mock_kafka.NewMockKafkaClient(ctrl)
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+	u := NewTrackingUsecase(mockRepo, pkgTracking.NewTracker("https://track.example.com", signer))
+
+	emailQueue := &models.EmailQueue{ID: uuid.New(), SequenceContactID: uuid.New()}
+	messageID := "<" + emailQueue.ID.String() + "@acme.example>"
+
+	dsn := func(action, status, diagnostic string) string {
+		return "From: MAILER-DAEMON@mx.example.com\r\n" +
+			"Content-Type: multipart/report; report-type=delivery-status; boundary=b1\r\n" +
+			"\r\n" +
+			"--b1\r\n" +
+			"Content-Type: message/delivery-status\r\n" +
+			"\r\n" +
+			"Reporting-MTA: dns; mx.example.com\r\n" +
+			"\r\n" +
+			"Final-Recipient: rfc822; lead@example.com\r\n" +
+			"Action: " + action + "\r\n" +
+			"Status: " + status + "\r\n" +
+			"Diagnostic-Code: smtp; " + diagnostic + "\r\n" +
+			"\r\n" +
+			"--b1\r\n" +
+			"Content-Type: text/rfc822-headers\r\n" +
+			"\r\n" +
+			"Message-ID: " + messageID + "\r\n" +
+			"\r\n" +
+			"--b1--\r\n"
+	}
+
+	newCtx := func() echo.Context {
+		e := echo.New()
+		return &ctx.CustomApplicationContext{
+			Context:  e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder()),
+			Postgres: gormDB,
+			AppLoger: logger.NewLogger(),
+			Config:   mockConf,
+			Kakfa:    mockKafka,
+		}
+	}
+
+	matchEmail := func() {
+		mockRepo.EXPECT().GetEmailByMessageIDs(gomock.Any(), []string{messageID}).Return(emailQueue, nil)
+	}
+	expectPublish := func() {
+		mockConf.EXPECT().GetKafkaConf().Return(config.Kafka{Topics: config.Topic{EmailEvents: "email-events"}})
+		mockKafka.EXPECT().Publish("email-events", gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name           string
+		message        string
+		setupMocks     func()
+		expectTx       bool
+		wantCommit     bool
+		wantMatched    bool
+		wantSuppressed bool
+		wantCode       int
+		wantErr        bool
+	}{
+		{
+			name:    "success - hard bounce ends the enrollment and suppresses the recipient",
+			message: dsn("failed", "5.1.1", "550 5.1.1 User unknown"),
+			setupMocks: func() {
+				matchEmail()
+				mockRepo.EXPECT().
+					HasEvent(gomock.Any(), emailQueue.ID, models.EmailEventTypeBounced, models.EventData{"source": "dsn", "bounce_type": bounce.KindHard}).
+					Return(false, nil)
+				mockRepo.EXPECT().RecordBounce(gomock.Any(), emailQueue.ID, emailQueue.SequenceContactID, "lead@example.com", bounce.KindHard).Return(nil)
+				mockRepo.EXPECT().
+					CreateEmailEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, emailEvent *models.EmailEvent) error {
+						if emailEvent.EventType != models.EmailEventTypeBounced || emailEvent.EventData["code"] != 550 {
+							t.Errorf("unexpected email event: %+v", emailEvent)
+						}
+						return nil
+					})
+				expectPublish()
+			},
+			expectTx:       true,
+			wantCommit:     true,
+			wantMatched:    true,
+			wantSuppressed: true,
+		},
+		{
+			name:    "success - soft bounce is only counted",
+			message: dsn("delayed", "4.4.1", "421 4.4.1 Connection timed out"),
+			setupMocks: func() {
+				matchEmail()
+				mockRepo.EXPECT().HasEvent(gomock.Any(), emailQueue.ID, models.EmailEventTypeBounced, gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RecordBounce(gomock.Any(), emailQueue.ID, emailQueue.SequenceContactID, "lead@example.com", bounce.KindSoft).Return(nil)
+				mockRepo.EXPECT().CreateEmailEvent(gomock.Any(), gomock.Any()).Return(nil)
+				expectPublish()
+			},
+			expectTx:    true,
+			wantCommit:  true,
+			wantMatched: true,
+		},
+		{
+			name:    "success - redelivered bounce is recorded once",
+			message: dsn("failed", "5.1.1", "550 5.1.1 User unknown"),
+			setupMocks: func() {
+				matchEmail()
+				mockRepo.EXPECT().HasEvent(gomock.Any(), emailQueue.ID, models.EmailEventTypeBounced, gomock.Any()).Return(true, nil)
+			},
+			expectTx:    true,
+			wantMatched: true,
+		},
+		{
+			name:       "success - delivery report is not a bounce",
+			message:    dsn("delivered", "2.0.0", "250 2.0.0 OK"),
+			setupMocks: func() {},
+		},
+		{
+			name:    "success - bounce of an unknown email is not matched",
+			message: dsn("failed", "5.1.1", "550 5.1.1 User unknown"),
+			setupMocks: func() {
+				mockRepo.EXPECT().GetEmailByMessageIDs(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectTx: true,
+		},
+		{
+			name:       "error - malformed report",
+			message:    "Content-Type: multipart/report; boundary=b1\r\n\r\n--b1\r\nContent-Type: message/delivery-status\r\n\r\nAction failed\r\n",
+			setupMocks: func() {},
+			wantCode:   http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if tt.wantCommit {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			tt.setupMocks()
+
+			resp, err := u.RecordBounce(newCtx(), strings.NewReader(tt.message))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecordBounce() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (resp.Matched != tt.wantMatched || resp.Suppressed != tt.wantSuppressed) {
+				t.Errorf("RecordBounce() = %+v, want matched %v suppressed %v", resp, tt.wantMatched, tt.wantSuppressed)
+			}
+
+			var httpErr *echo.HTTPError
+			if tt.wantCode != 0 && (!errors.As(err, &httpErr) || httpErr.Code != tt.wantCode) {
+				t.Errorf("RecordBounce() error = %v, want status %d", err, tt.wantCode)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
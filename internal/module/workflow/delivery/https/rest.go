@@ -2,10 +2,12 @@ package https
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/rohanchauhan02/sequence-service/internal/dto"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
 	"github.com/rohanchauhan02/sequence-service/internal/module/workflow"
 	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
 )
@@ -22,21 +24,37 @@ func NewWorkflowHandler(e *echo.Echo, usecase workflow.Usecase) {
 	api := e.Group("/api/v1")
 
 	api.POST("/sequence", h.CreateSequence)
+	api.GET("/sequences", h.ListSequences)
 	api.GET("/sequence/:id", h.GetSequence)
+	api.DELETE("/sequence/:id", h.DeleteSequence)
+	api.POST("/sequence/:id/restore", h.RestoreSequence)
+	api.POST("/sequence/:id/clone", h.CloneSequence)
+	api.POST("/sequence/:id/steps", h.AddStep)
+	api.PUT("/sequence/:id/steps/order", h.ReorderSteps)
+	api.GET("/sequence/:id/steps/deleted", h.ListDeletedSteps)
+	api.POST("/sequence/:id/steps/:stepId/restore", h.RestoreStep)
 	api.PUT("/sequence/:id/steps/:stepId", h.UpdateStep)
 	api.DELETE("/sequence/:id/steps/:stepId", h.DeleteStep)
 	api.PATCH("/sequence/:id", h.UpdateSequenceTracking)
+	api.POST("/sequence/:id/activate", h.ActivateSequence)
+	api.POST("/sequence/:id/pause", h.PauseSequence)
+	api.POST("/sequence/:id/archive", h.ArchiveSequence)
+	api.GET("/sequence/:id/versions", h.ListSequenceVersions)
+	api.GET("/sequence/:id/versions/diff", h.DiffSequenceVersions)
+	api.POST("/sequence/:id/mailboxes", h.AssignMailboxes)
+	api.GET("/sequence/:id/mailboxes", h.GetSequenceMailboxes)
+	api.DELETE("/sequence/:id/mailboxes/:mailboxId", h.UnassignMailbox)
 }
 
 // CreateSequence godoc
 // @Summary      Create a new email sequence
-// @Description  Create a new email sequence with steps
+// @Description  Create a new email sequence with steps. Subject and content may use merge fields such as {{first_name}} or {{first_name | "there"}}.
 // @Tags         Sequences
 // @Accept       json
 // @Produce      json
 // @Param        sequence  body      dto.CreateSequenceRequest  true  "Sequence details"
 // @Success      201  {object}  dto.ResponsePattern{data=dto.CreateSequenceResponse}
-// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      400  {object}  dto.ResponsePattern{data=[]dto.FieldError}
 // @Failure      500  {object}  dto.ResponsePattern
 // @Router       /sequence [post]
 func (h *workflowHandler) CreateSequence(c echo.Context) error {
@@ -51,7 +69,7 @@ func (h *workflowHandler) CreateSequence(c echo.Context) error {
 	resp, err := h.usecase.CreateSequence(c, reqPayload)
 	if err != nil {
 		ac.AppLoger.Errorf("CreateSequence - usecase error: %v", err)
-		return ac.CustomResponse(http.StatusText(http.StatusInternalServerError), nil, "", err.Error(), http.StatusInternalServerError, nil)
+		return ac.CustomErrorResponse(err)
 	}
 
 	ac.AppLoger.Infof("CreateSequence - sequence created with ID: %s", resp.ID)
@@ -89,17 +107,148 @@ func (h *workflowHandler) GetSequence(c echo.Context) error {
 	return ac.CustomResponse("Sequence details retrieved successfully", sequenceDetails, "", "", http.StatusOK, nil)
 }
 
+// ListSequences godoc
+// @Summary      List sequences
+// @Description  List sequences with pagination, optionally filtered by a case-insensitive name search. Steps are not included.
+// @Tags         Sequences
+// @Produce      json
+// @Param        page     query     int     false  "Page number"
+// @Param        limit    query     int     false  "Page size"
+// @Param        search   query     string  false  "Search in sequence name"
+// @Param        status   query     string  false  "Sequence status"  Enums(draft, active, paused, archived)
+// @Param        sort_by  query     string  false  "Sort field"  Enums(created_at, updated_at)
+// @Param        order    query     string  false  "Sort order"  Enums(asc, desc)
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.Sequence,meta=dto.PaginationMeta}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequences [get]
+func (h *workflowHandler) ListSequences(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	reqPayload := new(dto.ListSequencesRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("ListSequences - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	sequences, meta, err := h.usecase.ListSequences(c, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("ListSequences - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Sequences retrieved successfully", sequences, "", "", http.StatusOK, meta)
+}
+
+// DeleteSequence godoc
+// @Summary      Delete a sequence
+// @Description  Soft delete a sequence. Its unfinished enrollments and the emails not dispatched yet are cancelled. The sequence can be restored.
+// @Tags         Sequences
+// @Produce      json
+// @Param        id  path      string  true  "Sequence ID"
+// @Success      200  {object}  dto.ResponsePattern{data=dto.DeleteSequenceResponse}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id} [delete]
+func (h *workflowHandler) DeleteSequence(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteSequence - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	resp, err := h.usecase.DeleteSequence(c, sequenceUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteSequence - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Sequence deleted successfully", resp, "", "", http.StatusOK, nil)
+}
+
+// RestoreSequence godoc
+// @Summary      Restore a deleted sequence
+// @Description  Undo the delete of a sequence. Enrollments cancelled by the delete stay cancelled.
+// @Tags         Sequences
+// @Produce      json
+// @Param        id  path      string  true  "Sequence ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Sequence}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/restore [post]
+func (h *workflowHandler) RestoreSequence(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreSequence - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	sequence, err := h.usecase.RestoreSequence(c, sequenceUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreSequence - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Sequence restored successfully", sequence, "", "", http.StatusOK, nil)
+}
+
+// CloneSequence godoc
+// @Summary      Clone a sequence
+// @Description  Copy a sequence with its steps, tracking settings and mailboxes into a new draft sequence. The copy is named "<name> (copy)" unless a name is given.
+// @Tags         Sequences
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                    true   "Sequence ID"
+// @Param        sequence  body      dto.CloneSequenceRequest  false  "Name of the copy"
+// @Success      201  {object}  dto.ResponsePattern{data=models.Sequence}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/clone [post]
+func (h *workflowHandler) CloneSequence(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("CloneSequence - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.CloneSequenceRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("CloneSequence - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	sequence, err := h.usecase.CloneSequence(c, sequenceUUID, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("CloneSequence - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Sequence cloned successfully", sequence, "", "", http.StatusCreated, nil)
+}
+
 // UpdateStep godoc
 // @Summary      Update a step in the sequence
-// @Description  Update details of a specific step within an email sequence
+// @Description  Patch the content, delay or send window of a step. Set remove_send_window to clear the window.
 // @Tags         Sequences
 // @Accept       json
 // @Produce      json
-// @Param        id      path      string                   true  "Sequence ID"
-// @Param        stepId  path      string                   true  "Step ID"
-// @Param        step    body      dto.UpdateStepRequest    true  "Step details to update"
+// @Param        id      path      string                 true   "Sequence ID"
+// @Param        stepId  path      string                 true   "Step ID"
+// @Param        step    body      dto.UpdateStepRequest  true   "Step details to update"
+// @Param        force   query     bool                   false  "Change the steps of an active sequence"
 // @Success      200  {object}  dto.ResponsePattern
-// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      400  {object}  dto.ResponsePattern{data=[]dto.FieldError}
+// @Failure      409  {object}  dto.ResponsePattern
 // @Failure      500  {object}  dto.ResponsePattern
 // @Router       /sequence/{id}/steps/{stepId} [put]
 func (h *workflowHandler) UpdateStep(c echo.Context) error {
@@ -128,26 +277,198 @@ func (h *workflowHandler) UpdateStep(c echo.Context) error {
 		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid step ID", http.StatusBadRequest, nil)
 	}
 
-	err = h.usecase.UpdateStep(c, sequenceUUID, stepUUID, reqPayload)
+	force, err := parseForce(c)
+	if err != nil {
+		ac.AppLoger.Errorf("UpdateStep - %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid force flag", http.StatusBadRequest, nil)
+	}
+
+	err = h.usecase.UpdateStep(c, sequenceUUID, stepUUID, reqPayload, force)
 	if err != nil {
 		ac.AppLoger.Errorf("UpdateStep - usecase error: %v", err)
-		return ac.CustomResponse(http.StatusText(http.StatusInternalServerError), nil, "", err.Error(), http.StatusInternalServerError, nil)
+		return ac.CustomErrorResponse(err)
 	}
 
 	ac.AppLoger.Infof("UpdateStep - step updated successfully for sequenceID: %s, stepID: %s", sequenceID, stepID)
 	return ac.CustomResponse("Step updated successfully", nil, "", "", http.StatusOK, nil)
 }
 
+// AddStep godoc
+// @Summary      Add a step to a sequence
+// @Description  Insert a step at a position, shifting the steps from there on. The step is appended when position is omitted. Enrollments already past the position do not receive it.
+// @Tags         Sequences
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string              true   "Sequence ID"
+// @Param        step   body      dto.AddStepRequest  true   "Step details"
+// @Param        force  query     bool                false  "Change the steps of an active sequence"
+// @Success      201  {object}  dto.ResponsePattern{data=models.Step}
+// @Failure      400  {object}  dto.ResponsePattern{data=[]dto.FieldError}
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/steps [post]
+func (h *workflowHandler) AddStep(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("AddStep - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.AddStepRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("AddStep - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	force, err := parseForce(c)
+	if err != nil {
+		ac.AppLoger.Errorf("AddStep - %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid force flag", http.StatusBadRequest, nil)
+	}
+
+	step, err := h.usecase.AddStep(c, sequenceUUID, reqPayload, force)
+	if err != nil {
+		ac.AppLoger.Errorf("AddStep - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	ac.AppLoger.Infof("AddStep - step %s added at position %d for sequenceID: %s", step.ID, step.StepOrder, sequenceUUID)
+	return ac.CustomResponse("Step added successfully", step, "", "", http.StatusCreated, nil)
+}
+
+// ReorderSteps godoc
+// @Summary      Reorder the steps of a sequence
+// @Description  Renumber all steps of a sequence from 1 in the given order, in one transaction. Every step must be listed exactly once.
+// @Tags         Sequences
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                   true   "Sequence ID"
+// @Param        order  body      dto.ReorderStepsRequest  true   "Step IDs in their new order"
+// @Param        force  query     bool                     false  "Change the steps of an active sequence"
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.Step}
+// @Failure      400  {object}  dto.ResponsePattern{data=[]dto.FieldError}
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/steps/order [put]
+func (h *workflowHandler) ReorderSteps(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("ReorderSteps - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.ReorderStepsRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("ReorderSteps - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	force, err := parseForce(c)
+	if err != nil {
+		ac.AppLoger.Errorf("ReorderSteps - %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid force flag", http.StatusBadRequest, nil)
+	}
+
+	steps, err := h.usecase.ReorderSteps(c, sequenceUUID, reqPayload, force)
+	if err != nil {
+		ac.AppLoger.Errorf("ReorderSteps - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Steps reordered successfully", steps, "", "", http.StatusOK, nil)
+}
+
+// ListDeletedSteps godoc
+// @Summary      List deleted steps of a sequence
+// @Description  List the soft deleted steps of a sequence, most recently deleted first
+// @Tags         Sequences
+// @Produce      json
+// @Param        id  path      string  true  "Sequence ID"
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.Step}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/steps/deleted [get]
+func (h *workflowHandler) ListDeletedSteps(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("ListDeletedSteps - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	steps, err := h.usecase.ListDeletedSteps(c, sequenceUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("ListDeletedSteps - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Deleted steps retrieved successfully", steps, "", "", http.StatusOK, nil)
+}
+
+// RestoreStep godoc
+// @Summary      Restore a deleted step
+// @Description  Undelete a step. It is restored as the last step of the sequence.
+// @Tags         Sequences
+// @Produce      json
+// @Param        id      path      string  true   "Sequence ID"
+// @Param        stepId  path      string  true   "Step ID"
+// @Param        force   query     bool    false  "Change the steps of an active sequence"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Step}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/steps/{stepId}/restore [post]
+func (h *workflowHandler) RestoreStep(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreStep - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	stepUUID, err := uuid.Parse(c.Param("stepId"))
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreStep - invalid step ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid step ID", http.StatusBadRequest, nil)
+	}
+
+	force, err := parseForce(c)
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreStep - %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid force flag", http.StatusBadRequest, nil)
+	}
+
+	step, err := h.usecase.RestoreStep(c, sequenceUUID, stepUUID, force)
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreStep - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Step restored successfully", step, "", "", http.StatusOK, nil)
+}
+
 // DeleteStep godoc
 // @Summary      Delete a step from the sequence
 // @Description  Remove a specific step from an email sequence
 // @Tags         Sequences
 // @Accept       json
 // @Produce      json
-// @Param        id      path      string  true  "Sequence ID"
-// @Param        stepId  path      string  true  "Step ID"
+// @Param        id      path      string  true   "Sequence ID"
+// @Param        stepId  path      string  true   "Step ID"
+// @Param        force   query     bool    false  "Change the steps of an active sequence"
 // @Success      200  {object}  dto.ResponsePattern{data=string}
 // @Failure      400  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
 // @Failure      500  {object}  dto.ResponsePattern
 // @Router       /sequence/{id}/steps/{stepId} [delete]
 func (h *workflowHandler) DeleteStep(c echo.Context) error {
@@ -169,10 +490,16 @@ func (h *workflowHandler) DeleteStep(c echo.Context) error {
 		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid step ID", http.StatusBadRequest, nil)
 	}
 
-	err = h.usecase.DeleteStep(c, sequenceUUID, stepUUID)
+	force, err := parseForce(c)
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteStep - %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid force flag", http.StatusBadRequest, nil)
+	}
+
+	err = h.usecase.DeleteStep(c, sequenceUUID, stepUUID, force)
 	if err != nil {
 		ac.AppLoger.Errorf("DeleteStep - usecase error: %v", err)
-		return ac.CustomResponse(http.StatusText(http.StatusInternalServerError), nil, "", err.Error(), http.StatusInternalServerError, nil)
+		return ac.CustomErrorResponse(err)
 	}
 
 	ac.AppLoger.Infof("DeleteStep - step deleted successfully for sequenceID: %s, stepID: %s", sequenceID, stepID)
@@ -219,3 +546,245 @@ func (h *workflowHandler) UpdateSequenceTracking(c echo.Context) error {
 
 	return ac.CustomResponse("Sequence tracking info updated successfully", map[string]string{"sequence_id": sequenceUUID.String()}, "", "", http.StatusOK, nil)
 }
+
+// ActivateSequence godoc
+// @Summary      Activate a sequence
+// @Description  Start sending a draft sequence or resume a paused one
+// @Tags         Sequences
+// @Produce      json
+// @Param        id   path      string  true  "Sequence ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Sequence}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/activate [post]
+func (h *workflowHandler) ActivateSequence(c echo.Context) error {
+	return h.updateStatus(c, "ActivateSequence", "Sequence activated successfully", h.usecase.ActivateSequence)
+}
+
+// PauseSequence godoc
+// @Summary      Pause a sequence
+// @Description  Stop queueing and dispatching emails of an active sequence until it is activated again
+// @Tags         Sequences
+// @Produce      json
+// @Param        id   path      string  true  "Sequence ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Sequence}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/pause [post]
+func (h *workflowHandler) PauseSequence(c echo.Context) error {
+	return h.updateStatus(c, "PauseSequence", "Sequence paused successfully", h.usecase.PauseSequence)
+}
+
+// ArchiveSequence godoc
+// @Summary      Archive a sequence
+// @Description  Retire a sequence for good. It stops sending and accepts no new enrollments.
+// @Tags         Sequences
+// @Produce      json
+// @Param        id   path      string  true  "Sequence ID"
+// @Success      200  {object}  dto.ResponsePattern{data=models.Sequence}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      409  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/archive [post]
+func (h *workflowHandler) ArchiveSequence(c echo.Context) error {
+	return h.updateStatus(c, "ArchiveSequence", "Sequence archived successfully", h.usecase.ArchiveSequence)
+}
+
+func (h *workflowHandler) updateStatus(c echo.Context, action, message string, update func(echo.Context, uuid.UUID) (*models.Sequence, error)) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("%s - invalid sequence ID: %v", action, err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	sequence, err := update(c, sequenceUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("%s - usecase error: %v", action, err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse(message, sequence, "", "", http.StatusOK, nil)
+}
+
+// ListSequenceVersions godoc
+// @Summary      List versions of a sequence
+// @Description  List the published versions of a sequence, newest first. Every step change publishes a new version; enrollments stay on the version they started with.
+// @Tags         Sequences
+// @Produce      json
+// @Param        id  path      string  true  "Sequence ID"
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.SequenceVersion}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/versions [get]
+func (h *workflowHandler) ListSequenceVersions(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("ListSequenceVersions - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	versions, err := h.usecase.ListSequenceVersions(c, sequenceUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("ListSequenceVersions - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Versions retrieved successfully", versions, "", "", http.StatusOK, nil)
+}
+
+// DiffSequenceVersions godoc
+// @Summary      Diff two versions of a sequence
+// @Description  List the steps added, removed and changed between two versions of a sequence. Steps are matched by ID.
+// @Tags         Sequences
+// @Produce      json
+// @Param        id    path      string  true  "Sequence ID"
+// @Param        from  query     int     true  "Version to compare from"
+// @Param        to    query     int     true  "Version to compare to"
+// @Success      200  {object}  dto.ResponsePattern{data=dto.SequenceVersionDiff}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/versions/diff [get]
+func (h *workflowHandler) DiffSequenceVersions(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("DiffSequenceVersions - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.DiffSequenceVersionsRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("DiffSequenceVersions - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	diff, err := h.usecase.DiffSequenceVersions(c, sequenceUUID, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("DiffSequenceVersions - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Versions compared successfully", diff, "", "", http.StatusOK, nil)
+}
+
+// AssignMailboxes godoc
+// @Summary      Assign mailboxes to a sequence
+// @Description  Attach sender mailboxes to a sequence. Emails are rotated across the assigned mailboxes.
+// @Tags         Sequences
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                      true  "Sequence ID"
+// @Param        mailboxes  body      dto.AssignMailboxesRequest  true  "Mailboxes to assign"
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.Mailbox}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/mailboxes [post]
+func (h *workflowHandler) AssignMailboxes(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("AssignMailboxes - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	reqPayload := new(dto.AssignMailboxesRequest)
+	if err := ac.CustomBind(reqPayload); err != nil {
+		ac.AppLoger.Errorf("AssignMailboxes - validation error: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", err.Error(), http.StatusBadRequest, nil)
+	}
+
+	mailboxes, err := h.usecase.AssignMailboxes(c, sequenceUUID, reqPayload)
+	if err != nil {
+		ac.AppLoger.Errorf("AssignMailboxes - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Mailboxes assigned successfully", mailboxes, "", "", http.StatusOK, nil)
+}
+
+// GetSequenceMailboxes godoc
+// @Summary      List mailboxes assigned to a sequence
+// @Description  List the sender mailboxes assigned to a sequence
+// @Tags         Sequences
+// @Produce      json
+// @Param        id  path      string  true  "Sequence ID"
+// @Success      200  {object}  dto.ResponsePattern{data=[]models.Mailbox}
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/mailboxes [get]
+func (h *workflowHandler) GetSequenceMailboxes(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("GetSequenceMailboxes - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	mailboxes, err := h.usecase.GetSequenceMailboxes(c, sequenceUUID)
+	if err != nil {
+		ac.AppLoger.Errorf("GetSequenceMailboxes - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Mailboxes retrieved successfully", mailboxes, "", "", http.StatusOK, nil)
+}
+
+// UnassignMailbox godoc
+// @Summary      Unassign a mailbox from a sequence
+// @Description  Detach a sender mailbox from a sequence
+// @Tags         Sequences
+// @Produce      json
+// @Param        id         path      string  true  "Sequence ID"
+// @Param        mailboxId  path      string  true  "Mailbox ID"
+// @Success      200  {object}  dto.ResponsePattern
+// @Failure      400  {object}  dto.ResponsePattern
+// @Failure      404  {object}  dto.ResponsePattern
+// @Failure      500  {object}  dto.ResponsePattern
+// @Router       /sequence/{id}/mailboxes/{mailboxId} [delete]
+func (h *workflowHandler) UnassignMailbox(c echo.Context) error {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	sequenceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		ac.AppLoger.Errorf("UnassignMailbox - invalid sequence ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid sequence ID", http.StatusBadRequest, nil)
+	}
+
+	mailboxUUID, err := uuid.Parse(c.Param("mailboxId"))
+	if err != nil {
+		ac.AppLoger.Errorf("UnassignMailbox - invalid mailbox ID: %v", err)
+		return ac.CustomResponse(http.StatusText(http.StatusBadRequest), nil, "", "Invalid mailbox ID", http.StatusBadRequest, nil)
+	}
+
+	if err := h.usecase.UnassignMailbox(c, sequenceUUID, mailboxUUID); err != nil {
+		ac.AppLoger.Errorf("UnassignMailbox - usecase error: %v", err)
+		return ac.CustomErrorResponse(err)
+	}
+
+	return ac.CustomResponse("Mailbox unassigned successfully", nil, "", "", http.StatusOK, nil)
+}
+
+// parseForce reads the optional force query parameter that allows changing the steps of an active sequence.
+func parseForce(c echo.Context) (bool, error) {
+	force := c.QueryParam("force")
+	if force == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(force)
+}
@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/rohanchauhan02/sequence-service/internal/models"
 	"github.com/rohanchauhan02/sequence-service/internal/module/workflow"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/capacity"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/database"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/unenroll"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type workflowRepository struct {
@@ -34,10 +40,87 @@ func (r *workflowRepository) GetSequence(sequenceID uuid.UUID) (*models.Sequence
 	return &sequence, nil
 }
 
+// ListSequences returns sequences whose name contains search, optionally filtered by status, ordered by
+// sortBy and then by ID so pages stay stable. Steps are not loaded.
+func (r *workflowRepository) ListSequences(search, status, sortBy, order string, offset, limit int) ([]models.Sequence, int64, error) {
+	query := r.db.Model(&models.Sequence{})
+	if search != "" {
+		query = query.Where("name ILIKE ?", "%"+database.EscapeLike(search)+"%")
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	desc := order == "desc"
+	var sequences []models.Sequence
+	if err := query.
+		Order(clause.OrderByColumn{Column: clause.Column{Name: sortBy}, Desc: desc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc}).
+		Offset(offset).
+		Limit(limit).
+		Find(&sequences).Error; err != nil {
+		return nil, 0, err
+	}
+	return sequences, total, nil
+}
+
 func (r *workflowRepository) UpdateSequenceTracking(tx *gorm.DB, sequence *models.Sequence) error {
 	return tx.Save(sequence).Error
 }
 
+func (r *workflowRepository) UpdateSequenceStatus(tx *gorm.DB, sequenceID uuid.UUID, status models.SequenceStatus) error {
+	return tx.Model(&models.Sequence{}).Where("id = ?", sequenceID).Update("status", status).Error
+}
+
+func (r *workflowRepository) DeleteSequence(tx *gorm.DB, sequenceID uuid.UUID) error {
+	return tx.Delete(&models.Sequence{}, "id = ?", sequenceID).Error
+}
+
+// LockSequenceWithDeleted locks a sequence whether or not it is soft deleted.
+func (r *workflowRepository) LockSequenceWithDeleted(tx *gorm.DB, sequenceID uuid.UUID) (*models.Sequence, error) {
+	var sequence models.Sequence
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&sequence, "id = ?", sequenceID).Error; err != nil {
+		return nil, err
+	}
+	return &sequence, nil
+}
+
+func (r *workflowRepository) RestoreSequence(tx *gorm.DB, sequenceID uuid.UUID) error {
+	return tx.Unscoped().Model(&models.Sequence{}).Where("id = ?", sequenceID).Update("deleted_at", nil).Error
+}
+
+// CancelSequenceEnrollments cancels the unfinished enrollments of a sequence.
+func (r *workflowRepository) CancelSequenceEnrollments(tx *gorm.DB, sequenceID uuid.UUID) (int64, error) {
+	result := tx.Model(&models.SequenceContact{}).
+		Where("sequence_id = ? AND status IN ?", sequenceID,
+			unenroll.Unfinished).
+		Updates(map[string]any{
+			"status":       models.SequenceContactStatusCancelled,
+			"next_send_at": nil,
+			"completed_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CancelSequenceScheduledEmails cancels the emails of a sequence that were not dispatched yet and
+// releases the mailbox capacity reserved for them.
+func (r *workflowRepository) CancelSequenceScheduledEmails(tx *gorm.DB, sequenceID uuid.UUID) (int64, error) {
+	if err := capacity.Release(tx, "sequence_contact_id IN (SELECT id FROM sequence_contacts WHERE sequence_id = ?) AND status = ?",
+		sequenceID, models.EmailQueueStatusScheduled); err != nil {
+		return 0, err
+	}
+
+	result := tx.Model(&models.EmailQueue{}).
+		Where("sequence_contact_id IN (SELECT id FROM sequence_contacts WHERE sequence_id = ?) AND status = ?", sequenceID, models.EmailQueueStatusScheduled).
+		Update("status", models.EmailQueueStatusCancelled)
+	return result.RowsAffected, result.Error
+}
+
 func (r *workflowRepository) CreateSteps(tx *gorm.DB, steps []models.Step) (*[]models.Step, error) {
 	if err := tx.Create(&steps).Error; err != nil {
 		return nil, err
@@ -45,18 +128,139 @@ func (r *workflowRepository) CreateSteps(tx *gorm.DB, steps []models.Step) (*[]m
 	return &steps, nil
 }
 
-func (r *workflowRepository) GetStepByID(sequenceID, stepID uuid.UUID) (*models.Step, error) {
+func (r *workflowRepository) UpdateStep(tx *gorm.DB, step *models.Step) error {
+	return tx.Save(step).Error
+}
+
+func (r *workflowRepository) DeleteStep(tx *gorm.DB, sequenceID, stepID uuid.UUID) error {
+	return tx.Delete(&models.Step{}, "id = ? AND sequence_id = ?", stepID, sequenceID).Error
+}
+
+// LockSequence locks the sequence row so concurrent step changes of the sequence are serialized.
+func (r *workflowRepository) LockSequence(tx *gorm.DB, sequenceID uuid.UUID) (*models.Sequence, error) {
+	var sequence models.Sequence
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sequence, "id = ?", sequenceID).Error; err != nil {
+		return nil, err
+	}
+	return &sequence, nil
+}
+
+func (r *workflowRepository) GetSteps(tx *gorm.DB, sequenceID uuid.UUID) ([]models.Step, error) {
+	var steps []models.Step
+	if err := tx.Where("sequence_id = ?", sequenceID).Order("step_order ASC, created_at ASC").Find(&steps).Error; err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// GetDeletedSteps returns the soft deleted steps of a sequence, most recently deleted first.
+func (r *workflowRepository) GetDeletedSteps(sequenceID uuid.UUID) ([]models.Step, error) {
+	var steps []models.Step
+	if err := r.db.Unscoped().
+		Where("sequence_id = ? AND deleted_at IS NOT NULL", sequenceID).
+		Order("deleted_at DESC").
+		Find(&steps).Error; err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+func (r *workflowRepository) GetDeletedStep(tx *gorm.DB, sequenceID, stepID uuid.UUID) (*models.Step, error) {
 	var step models.Step
-	if err := r.db.Where("id = ? AND sequence_id = ?", stepID, sequenceID).First(&step).Error; err != nil {
+	if err := tx.Unscoped().
+		Where("id = ? AND sequence_id = ? AND deleted_at IS NOT NULL", stepID, sequenceID).
+		First(&step).Error; err != nil {
 		return nil, err
 	}
 	return &step, nil
 }
 
-func (r *workflowRepository) UpdateStep(tx *gorm.DB, step *models.Step) error {
-	return tx.Save(step).Error
+// RestoreStep undeletes a step at the given step_order.
+func (r *workflowRepository) RestoreStep(tx *gorm.DB, sequenceID, stepID uuid.UUID, stepOrder int) error {
+	return tx.Unscoped().Model(&models.Step{}).
+		Where("id = ? AND sequence_id = ?", stepID, sequenceID).
+		Updates(map[string]any{"deleted_at": nil, "step_order": stepOrder}).Error
 }
 
-func (r *workflowRepository) DeleteStep(tx *gorm.DB, sequenceID, stepID uuid.UUID) error {
-	return tx.Delete(&models.Step{}, "id = ? AND sequence_id = ?", stepID, sequenceID).Error
+// SetStepOrders moves steps to new step_order values. The steps are first parked on negative orders
+// so that no two live steps share an order at any point of the transaction.
+func (r *workflowRepository) SetStepOrders(tx *gorm.DB, sequenceID uuid.UUID, orders map[uuid.UUID]int) error {
+	for stepID, order := range orders {
+		if err := tx.Model(&models.Step{}).
+			Where("id = ? AND sequence_id = ?", stepID, sequenceID).
+			Update("step_order", -order).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&models.Step{}).
+		Where("sequence_id = ? AND step_order < 0", sequenceID).
+		Update("step_order", gorm.Expr("-step_order")).Error
+}
+
+func (r *workflowRepository) GetLatestVersion(tx *gorm.DB, sequenceID uuid.UUID) (*models.SequenceVersion, error) {
+	var version models.SequenceVersion
+	if err := tx.Where("sequence_id = ?", sequenceID).Order("version DESC").First(&version).Error; err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (r *workflowRepository) GetSequenceVersion(sequenceID uuid.UUID, version int) (*models.SequenceVersion, error) {
+	var sequenceVersion models.SequenceVersion
+	if err := r.db.Where("sequence_id = ? AND version = ?", sequenceID, version).First(&sequenceVersion).Error; err != nil {
+		return nil, err
+	}
+	return &sequenceVersion, nil
+}
+
+// ListSequenceVersions returns the versions of a sequence, newest first, without their steps.
+func (r *workflowRepository) ListSequenceVersions(sequenceID uuid.UUID) ([]models.SequenceVersion, error) {
+	var versions []models.SequenceVersion
+	if err := r.db.Select("id", "sequence_id", "version", "created_at").
+		Where("sequence_id = ?", sequenceID).
+		Order("version DESC").
+		Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (r *workflowRepository) CreateSequenceVersion(tx *gorm.DB, version *models.SequenceVersion) error {
+	return tx.Create(version).Error
+}
+
+func (r *workflowRepository) GetMailboxesByIDs(mailboxIDs []uuid.UUID) ([]models.Mailbox, error) {
+	var mailboxes []models.Mailbox
+	if err := r.db.Where("id IN ?", mailboxIDs).Find(&mailboxes).Error; err != nil {
+		return nil, err
+	}
+	return mailboxes, nil
+}
+
+func (r *workflowRepository) CreateSequenceMailboxes(tx *gorm.DB, sequenceMailboxes []models.SequenceMailbox) error {
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequenceMailboxes).Error
+}
+
+// CopySequenceMailboxes assigns the mailboxes of one sequence to another, keeping their rotation order.
+func (r *workflowRepository) CopySequenceMailboxes(tx *gorm.DB, fromSequenceID, toSequenceID uuid.UUID) error {
+	return tx.Exec(`INSERT INTO sequence_mailboxes (sequence_id, mailbox_id, created_at)
+		SELECT ?, mailbox_id, created_at FROM sequence_mailboxes WHERE sequence_id = ?`,
+		toSequenceID, fromSequenceID,
+	).Error
+}
+
+func (r *workflowRepository) GetSequenceMailboxes(sequenceID uuid.UUID) ([]models.Mailbox, error) {
+	var mailboxes []models.Mailbox
+	if err := r.db.Joins("JOIN sequence_mailboxes sm ON sm.mailbox_id = mailboxes.id").
+		Where("sm.sequence_id = ?", sequenceID).
+		Order("sm.created_at ASC").
+		Find(&mailboxes).Error; err != nil {
+		return nil, err
+	}
+	return mailboxes, nil
+}
+
+func (r *workflowRepository) DeleteSequenceMailbox(tx *gorm.DB, sequenceID, mailboxID uuid.UUID) (int64, error) {
+	result := tx.Delete(&models.SequenceMailbox{}, "sequence_id = ? AND mailbox_id = ?", sequenceID, mailboxID)
+	return result.RowsAffected, result.Error
 }
@@ -1,12 +1,21 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/rohanchauhan02/sequence-service/internal/dto"
 	"github.com/rohanchauhan02/sequence-service/internal/models"
 	"github.com/rohanchauhan02/sequence-service/internal/module/workflow"
 	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/merge"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/sendwindow"
+	"gorm.io/gorm"
 )
 
 type workflowUsecase struct {
@@ -21,10 +30,21 @@ func NewWorkflowUsecase(repository workflow.Repository) workflow.Usecase {
 
 func (u *workflowUsecase) CreateSequence(c echo.Context, req *dto.CreateSequenceRequest) (*dto.CreateSequenceResponse, error) {
 	ac := c.(*ctx.CustomApplicationContext)
+	if errs := validateSteps(req.Steps); len(errs) > 0 {
+		ac.AppLoger.Errorf("CreateSequence - invalid steps: %v", errs)
+		return nil, echo.NewHTTPError(http.StatusBadRequest, errs)
+	}
+
+	status := models.SequenceStatusDraft
+	if req.Status != "" {
+		status = models.SequenceStatus(req.Status)
+	}
+
 	sequenceData := &models.Sequence{
 		Name:                 req.Name,
 		OpenTrackingEnabled:  req.OpenTrackingEnabled,
 		ClickTrackingEnabled: req.ClickTrackingEnabled,
+		Status:               status,
 	}
 
 	tx := ac.Postgres.Begin()
@@ -40,12 +60,15 @@ func (u *workflowUsecase) CreateSequence(c echo.Context, req *dto.CreateSequence
 		steps := make([]models.Step, len(req.Steps))
 		for i, stepReq := range req.Steps {
 			steps[i] = models.Step{
-				SequenceID: resp.ID,
-				StepOrder:  stepReq.StepOrder,
-				Subject:    stepReq.Subject,
-				Content:    stepReq.Content,
-				WaitDays:   stepReq.WaitDays,
+				SequenceID:  resp.ID,
+				StepOrder:   stepReq.StepOrder,
+				Subject:     stepReq.Subject,
+				Content:     stepReq.Content,
+				WaitDays:    stepReq.WaitDays,
+				WaitHours:   stepReq.WaitHours,
+				WaitMinutes: stepReq.WaitMinutes,
 			}
+			setSendWindow(&steps[i], stepReq.SendWindow)
 		}
 		_, err = u.repository.CreateSteps(tx, steps)
 		if err != nil {
@@ -54,6 +77,11 @@ func (u *workflowUsecase) CreateSequence(c echo.Context, req *dto.CreateSequence
 		}
 	}
 
+	if _, err := u.publishVersion(tx, resp.ID); err != nil {
+		ac.AppLoger.Errorf("CreateSequence - failed to publish version: %v", err)
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		ac.AppLoger.Errorf("CreateSequence - failed to commit transaction: %v", err)
 		return nil, err
@@ -68,6 +96,196 @@ func (u *workflowUsecase) GetSequence(c echo.Context, sequenceID uuid.UUID) (*mo
 	return u.repository.GetSequence(sequenceID)
 }
 
+func (u *workflowUsecase) ListSequences(c echo.Context, req *dto.ListSequencesRequest) ([]models.Sequence, *dto.PaginationMeta, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+
+	page, limit := req.Page, req.Limit
+	if page == 0 {
+		page = dto.DefaultPage
+	}
+	if limit == 0 {
+		limit = dto.DefaultLimit
+	}
+
+	sortBy, order := req.SortBy, req.Order
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if order == "" {
+		order = "desc"
+	}
+
+	sequences, total, err := u.repository.ListSequences(strings.TrimSpace(req.Search), req.Status, sortBy, order, (page-1)*limit, limit)
+	if err != nil {
+		ac.AppLoger.Errorf("ListSequences - failed to list sequences: %v", err)
+		return nil, nil, err
+	}
+
+	return sequences, dto.NewPaginationMeta(page, limit, total), nil
+}
+
+// CloneSequence copies a sequence with its live steps, its tracking settings and its mailboxes into a
+// new draft sequence.
+func (u *workflowUsecase) CloneSequence(c echo.Context, sequenceID uuid.UUID, req *dto.CloneSequenceRequest) (*models.Sequence, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	source, err := u.repository.LockSequence(tx, sequenceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Error("CloneSequence - sequence not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("CloneSequence - failed to fetch sequence: %v", err)
+		return nil, err
+	}
+
+	steps, err := u.repository.GetSteps(tx, sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("CloneSequence - failed to fetch steps: %v", err)
+		return nil, err
+	}
+
+	name := req.Name
+	if name == "" {
+		name = copyName(source.Name)
+	}
+
+	clone, err := u.repository.CreateSequence(tx, &models.Sequence{
+		Name:                 name,
+		OpenTrackingEnabled:  source.OpenTrackingEnabled,
+		ClickTrackingEnabled: source.ClickTrackingEnabled,
+		Status:               models.SequenceStatusDraft,
+	})
+	if err != nil {
+		ac.AppLoger.Errorf("CloneSequence - failed to create sequence: %v", err)
+		return nil, err
+	}
+
+	if len(steps) > 0 {
+		copies := make([]models.Step, len(steps))
+		for i, step := range steps {
+			copies[i] = models.Step{
+				SequenceID:      clone.ID,
+				StepOrder:       step.StepOrder,
+				Subject:         step.Subject,
+				Content:         step.Content,
+				WaitDays:        step.WaitDays,
+				WaitHours:       step.WaitHours,
+				WaitMinutes:     step.WaitMinutes,
+				SendWindowStart: step.SendWindowStart,
+				SendWindowEnd:   step.SendWindowEnd,
+				SendWindowDays:  step.SendWindowDays,
+			}
+		}
+		created, err := u.repository.CreateSteps(tx, copies)
+		if err != nil {
+			ac.AppLoger.Errorf("CloneSequence - failed to create steps: %v", err)
+			return nil, err
+		}
+		clone.Steps = *created
+	}
+
+	if err := u.repository.CopySequenceMailboxes(tx, sequenceID, clone.ID); err != nil {
+		ac.AppLoger.Errorf("CloneSequence - failed to copy mailboxes: %v", err)
+		return nil, err
+	}
+
+	if _, err := u.publishVersion(tx, clone.ID); err != nil {
+		ac.AppLoger.Errorf("CloneSequence - failed to publish version: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("CloneSequence - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("CloneSequence - sequence %s cloned to %s", sequenceID.String(), clone.ID.String())
+
+	return clone, nil
+}
+
+// DeleteSequence soft deletes a sequence. Its unfinished enrollments are cancelled together with the
+// emails not dispatched yet.
+func (u *workflowUsecase) DeleteSequence(c echo.Context, sequenceID uuid.UUID) (*dto.DeleteSequenceResponse, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	_, err := u.repository.LockSequence(tx, sequenceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Error("DeleteSequence - sequence not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteSequence - failed to fetch sequence: %v", err)
+		return nil, err
+	}
+
+	cancelledEmails, err := u.repository.CancelSequenceScheduledEmails(tx, sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteSequence - failed to cancel scheduled emails: %v", err)
+		return nil, err
+	}
+
+	cancelledEnrollments, err := u.repository.CancelSequenceEnrollments(tx, sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteSequence - failed to cancel enrollments: %v", err)
+		return nil, err
+	}
+
+	if err := u.repository.DeleteSequence(tx, sequenceID); err != nil {
+		ac.AppLoger.Errorf("DeleteSequence - failed to delete sequence: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("DeleteSequence - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("DeleteSequence - sequence %s deleted, cancelled %d enrollments and %d emails", sequenceID.String(), cancelledEnrollments, cancelledEmails)
+
+	return &dto.DeleteSequenceResponse{
+		CancelledEnrollments: cancelledEnrollments,
+		CancelledEmails:      cancelledEmails,
+	}, nil
+}
+
+// RestoreSequence undeletes a sequence. Enrollments cancelled by the delete stay cancelled.
+func (u *workflowUsecase) RestoreSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	sequence, err := u.repository.LockSequenceWithDeleted(tx, sequenceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Error("RestoreSequence - sequence not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreSequence - failed to fetch sequence: %v", err)
+		return nil, err
+	}
+	if !sequence.DeletedAt.Valid {
+		return nil, echo.NewHTTPError(http.StatusConflict, "Sequence is not deleted")
+	}
+
+	if err := u.repository.RestoreSequence(tx, sequenceID); err != nil {
+		ac.AppLoger.Errorf("RestoreSequence - failed to restore sequence: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("RestoreSequence - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("RestoreSequence - sequence %s restored", sequenceID.String())
+
+	sequence.DeletedAt = gorm.DeletedAt{}
+	return sequence, nil
+}
+
 func (u *workflowUsecase) UpdateSequenceTracking(c echo.Context, sequenceID uuid.UUID, req *dto.UpdateSequenceTrackingRequest) error {
 	ac := c.(*ctx.CustomApplicationContext)
 	sequence, err := u.repository.GetSequence(sequenceID)
@@ -106,18 +324,40 @@ func (u *workflowUsecase) UpdateSequenceTracking(c echo.Context, sequenceID uuid
 	return nil
 }
 
-func (u *workflowUsecase) UpdateStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID, req *dto.UpdateStepRequest) error {
+// UpdateStep patches a step and publishes the result as a new version. Enrollments of earlier versions
+// keep receiving the step as it was.
+func (u *workflowUsecase) UpdateStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID, req *dto.UpdateStepRequest, force bool) error {
 	ac := c.(*ctx.CustomApplicationContext)
-	existingStep, err := u.repository.GetStepByID(sequenceID, stepID)
-	if existingStep == nil {
-		ac.AppLoger.Error("UpdateStep - step not found")
-		return echo.NewHTTPError(404, "Step not found")
+	errs := validateStepTemplates("", req.Subject, req.Content)
+	errs = append(errs, validateSendWindow("send_window", req.SendWindow)...)
+	if req.SendWindow != nil && req.RemoveSendWindow {
+		errs = append(errs, dto.FieldError{Field: "remove_send_window", Message: "cannot be combined with send_window"})
 	}
+	if len(errs) > 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, errs)
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	steps, err := u.lockSteps(tx, sequenceID, force)
 	if err != nil {
-		ac.AppLoger.Errorf("UpdateStep - failed to fetch step: %v", err)
+		ac.AppLoger.Errorf("UpdateStep - %v", err)
 		return err
 	}
 
+	var existingStep *models.Step
+	for i := range steps {
+		if steps[i].ID == stepID {
+			existingStep = &steps[i]
+			break
+		}
+	}
+	if existingStep == nil {
+		ac.AppLoger.Error("UpdateStep - step not found")
+		return echo.NewHTTPError(http.StatusNotFound, "Step not found")
+	}
+
 	if req.Subject != nil {
 		existingStep.Subject = *req.Subject
 	}
@@ -126,8 +366,21 @@ func (u *workflowUsecase) UpdateStep(c echo.Context, sequenceID uuid.UUID, stepI
 		existingStep.Content = *req.Content
 	}
 
-	tx := ac.Postgres.Begin()
-	defer tx.Rollback()
+	if req.WaitDays != nil {
+		existingStep.WaitDays = *req.WaitDays
+	}
+
+	if req.WaitHours != nil {
+		existingStep.WaitHours = *req.WaitHours
+	}
+
+	if req.WaitMinutes != nil {
+		existingStep.WaitMinutes = *req.WaitMinutes
+	}
+
+	if req.SendWindow != nil || req.RemoveSendWindow {
+		setSendWindow(existingStep, req.SendWindow)
+	}
 
 	err = u.repository.UpdateStep(tx, existingStep)
 	if err != nil {
@@ -135,6 +388,11 @@ func (u *workflowUsecase) UpdateStep(c echo.Context, sequenceID uuid.UUID, stepI
 		return err
 	}
 
+	if _, err := u.publishVersion(tx, sequenceID); err != nil {
+		ac.AppLoger.Errorf("UpdateStep - failed to publish version: %v", err)
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		ac.AppLoger.Errorf("UpdateStep - failed to commit transaction: %v", err)
 		return err
@@ -143,17 +401,44 @@ func (u *workflowUsecase) UpdateStep(c echo.Context, sequenceID uuid.UUID, stepI
 	return nil
 }
 
-func (u *workflowUsecase) DeleteStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID) error {
+// DeleteStep removes a step, renumbers the remaining steps from 1 and publishes a new version.
+func (u *workflowUsecase) DeleteStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID, force bool) error {
 	ac := c.(*ctx.CustomApplicationContext)
 	tx := ac.Postgres.Begin()
 	defer tx.Rollback()
 
-	err := u.repository.DeleteStep(nil, sequenceID, stepID)
+	steps, err := u.lockSteps(tx, sequenceID, force)
+	if err != nil {
+		ac.AppLoger.Errorf("DeleteStep - %v", err)
+		return err
+	}
+
+	orders := make(map[uuid.UUID]int, len(steps))
+	for _, step := range steps {
+		if step.ID != stepID {
+			orders[step.ID] = len(orders) + 1
+		}
+	}
+	if len(orders) == len(steps) {
+		return echo.NewHTTPError(http.StatusNotFound, "Step not found")
+	}
+
+	err = u.repository.DeleteStep(tx, sequenceID, stepID)
 	if err != nil {
 		ac.AppLoger.Errorf("DeleteStep - failed to delete step: %v", err)
 		return err
 	}
 
+	if err := u.repository.SetStepOrders(tx, sequenceID, orders); err != nil {
+		ac.AppLoger.Errorf("DeleteStep - failed to renumber steps: %v", err)
+		return err
+	}
+
+	if _, err := u.publishVersion(tx, sequenceID); err != nil {
+		ac.AppLoger.Errorf("DeleteStep - failed to publish version: %v", err)
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		ac.AppLoger.Errorf("DeleteStep - failed to commit transaction: %v", err)
 		return err
@@ -162,3 +447,599 @@ func (u *workflowUsecase) DeleteStep(c echo.Context, sequenceID uuid.UUID, stepI
 
 	return nil
 }
+
+// AddStep inserts a step at the requested position, renumbers the steps of the sequence from 1 and
+// publishes a new version. Only new enrollments receive the new step.
+func (u *workflowUsecase) AddStep(c echo.Context, sequenceID uuid.UUID, req *dto.AddStepRequest, force bool) (*models.Step, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	errs := validateStepTemplates("", &req.Subject, &req.Content)
+	errs = append(errs, validateSendWindow("send_window", req.SendWindow)...)
+	if len(errs) > 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, errs)
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	steps, err := u.lockSteps(tx, sequenceID, force)
+	if err != nil {
+		ac.AppLoger.Errorf("AddStep - %v", err)
+		return nil, err
+	}
+
+	position := req.Position
+	if position == 0 {
+		position = len(steps) + 1
+	}
+	if position > len(steps)+1 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, dto.FieldErrors{{
+			Field:   "position",
+			Message: fmt.Sprintf("must be between 1 and %d", len(steps)+1),
+		}})
+	}
+
+	orders := make(map[uuid.UUID]int, len(steps))
+	for i, step := range steps {
+		order := i + 1
+		if order >= position {
+			order++
+		}
+		orders[step.ID] = order
+	}
+	if err := u.repository.SetStepOrders(tx, sequenceID, orders); err != nil {
+		ac.AppLoger.Errorf("AddStep - failed to renumber steps: %v", err)
+		return nil, err
+	}
+
+	step := models.Step{
+		SequenceID:  sequenceID,
+		StepOrder:   position,
+		Subject:     req.Subject,
+		Content:     req.Content,
+		WaitDays:    req.WaitDays,
+		WaitHours:   req.WaitHours,
+		WaitMinutes: req.WaitMinutes,
+	}
+	setSendWindow(&step, req.SendWindow)
+
+	created, err := u.repository.CreateSteps(tx, []models.Step{step})
+	if err != nil {
+		ac.AppLoger.Errorf("AddStep - failed to create step: %v", err)
+		return nil, err
+	}
+
+	if _, err := u.publishVersion(tx, sequenceID); err != nil {
+		ac.AppLoger.Errorf("AddStep - failed to publish version: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("AddStep - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	return &(*created)[0], nil
+}
+
+// ReorderSteps renumbers the steps of a sequence from 1 in the requested order and publishes a new
+// version.
+func (u *workflowUsecase) ReorderSteps(c echo.Context, sequenceID uuid.UUID, req *dto.ReorderStepsRequest, force bool) ([]models.Step, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	steps, err := u.lockSteps(tx, sequenceID, force)
+	if err != nil {
+		ac.AppLoger.Errorf("ReorderSteps - %v", err)
+		return nil, err
+	}
+
+	if errs := validateStepOrder(steps, req.StepIDs); len(errs) > 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, errs)
+	}
+
+	orders := make(map[uuid.UUID]int, len(req.StepIDs))
+	for i, stepID := range req.StepIDs {
+		orders[stepID] = i + 1
+	}
+	if err := u.repository.SetStepOrders(tx, sequenceID, orders); err != nil {
+		ac.AppLoger.Errorf("ReorderSteps - failed to renumber steps: %v", err)
+		return nil, err
+	}
+
+	version, err := u.publishVersion(tx, sequenceID)
+	if err != nil {
+		ac.AppLoger.Errorf("ReorderSteps - failed to publish version: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("ReorderSteps - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	return version.Steps, nil
+}
+
+// ListDeletedSteps returns the soft deleted steps of a sequence, most recently deleted first.
+func (u *workflowUsecase) ListDeletedSteps(c echo.Context, sequenceID uuid.UUID) ([]models.Step, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	if err := u.ensureSequenceExists(sequenceID); err != nil {
+		ac.AppLoger.Errorf("ListDeletedSteps - %v", err)
+		return nil, err
+	}
+	return u.repository.GetDeletedSteps(sequenceID)
+}
+
+// RestoreStep undeletes a step as the last step of the sequence, since its old position may have been
+// taken since, and publishes a new version.
+func (u *workflowUsecase) RestoreStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID, force bool) (*models.Step, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	steps, err := u.lockSteps(tx, sequenceID, force)
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreStep - %v", err)
+		return nil, err
+	}
+
+	step, err := u.repository.GetDeletedStep(tx, sequenceID, stepID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Deleted step not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("RestoreStep - failed to fetch step: %v", err)
+		return nil, err
+	}
+
+	step.StepOrder = len(steps) + 1
+	step.DeletedAt = gorm.DeletedAt{}
+	if err := u.repository.RestoreStep(tx, sequenceID, stepID, step.StepOrder); err != nil {
+		ac.AppLoger.Errorf("RestoreStep - failed to restore step: %v", err)
+		return nil, err
+	}
+
+	if _, err := u.publishVersion(tx, sequenceID); err != nil {
+		ac.AppLoger.Errorf("RestoreStep - failed to publish version: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("RestoreStep - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("RestoreStep - step %s of sequence %s restored at %d", stepID.String(), sequenceID.String(), step.StepOrder)
+
+	return step, nil
+}
+
+// lockSteps locks the sequence against concurrent step changes and returns its steps in order.
+func (u *workflowUsecase) lockSteps(tx *gorm.DB, sequenceID uuid.UUID, force bool) ([]models.Step, error) {
+	if _, err := u.lockSequence(tx, sequenceID, force); err != nil {
+		return nil, err
+	}
+	return u.repository.GetSteps(tx, sequenceID)
+}
+
+// lockSequence locks the sequence and rejects step changes of archived sequences, and of active
+// sequences unless forced.
+func (u *workflowUsecase) lockSequence(tx *gorm.DB, sequenceID uuid.UUID, force bool) (*models.Sequence, error) {
+	sequence, err := u.repository.LockSequence(tx, sequenceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStepsEditable(sequence, force); err != nil {
+		return nil, err
+	}
+	return sequence, nil
+}
+
+// publishVersion snapshots the current steps of a locked sequence as its next version. The latest
+// version is returned as is when the steps did not change.
+func (u *workflowUsecase) publishVersion(tx *gorm.DB, sequenceID uuid.UUID) (*models.SequenceVersion, error) {
+	steps, err := u.repository.GetSteps(tx, sequenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := 1
+	latest, err := u.repository.GetLatestVersion(tx, sequenceID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		if added, removed, changed := diffSteps(latest.Steps, steps); len(added)+len(removed)+len(changed) == 0 {
+			return latest, nil
+		}
+		next = latest.Version + 1
+	}
+
+	version := &models.SequenceVersion{
+		SequenceID: sequenceID,
+		Version:    next,
+		Steps:      steps,
+	}
+	if err := u.repository.CreateSequenceVersion(tx, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+func checkStepsEditable(sequence *models.Sequence, force bool) error {
+	switch {
+	case sequence.Status == models.SequenceStatusArchived:
+		return echo.NewHTTPError(http.StatusConflict, "Steps of an archived sequence cannot be changed")
+	case sequence.Status == models.SequenceStatusActive && !force:
+		return echo.NewHTTPError(http.StatusConflict, "Sequence is active, pause it or pass force=true to change its steps")
+	}
+	return nil
+}
+
+// validateStepOrder checks that stepIDs lists every step exactly once.
+func validateStepOrder(steps []models.Step, stepIDs []uuid.UUID) dto.FieldErrors {
+	known := make(map[uuid.UUID]struct{}, len(steps))
+	for _, step := range steps {
+		known[step.ID] = struct{}{}
+	}
+
+	var errs dto.FieldErrors
+	seen := make(map[uuid.UUID]struct{}, len(stepIDs))
+	for i, stepID := range stepIDs {
+		field := fmt.Sprintf("step_ids[%d]", i)
+		if _, ok := known[stepID]; !ok {
+			errs = append(errs, dto.FieldError{Field: field, Message: fmt.Sprintf("step %s does not belong to this sequence", stepID)})
+			continue
+		}
+		if _, ok := seen[stepID]; ok {
+			errs = append(errs, dto.FieldError{Field: field, Message: fmt.Sprintf("step %s is listed more than once", stepID)})
+			continue
+		}
+		seen[stepID] = struct{}{}
+	}
+
+	var missing []string
+	for _, step := range steps {
+		if _, ok := seen[step.ID]; !ok {
+			missing = append(missing, step.ID.String())
+		}
+	}
+	if len(missing) > 0 {
+		errs = append(errs, dto.FieldError{Field: "step_ids", Message: "missing steps " + strings.Join(missing, ", ")})
+	}
+	return errs
+}
+
+func (u *workflowUsecase) ActivateSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error) {
+	return u.transition(c, sequenceID, models.SequenceStatusActive, models.SequenceStatusDraft, models.SequenceStatusPaused)
+}
+
+// PauseSequence stops the scheduler from queueing and dispatching emails of the sequence until it is activated again.
+func (u *workflowUsecase) PauseSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error) {
+	return u.transition(c, sequenceID, models.SequenceStatusPaused, models.SequenceStatusActive)
+}
+
+// ArchiveSequence retires a sequence for good. It no longer sends and accepts no new enrollments.
+func (u *workflowUsecase) ArchiveSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error) {
+	return u.transition(c, sequenceID, models.SequenceStatusArchived,
+		models.SequenceStatusDraft, models.SequenceStatusActive, models.SequenceStatusPaused)
+}
+
+// transition moves a sequence to status when its current status is one of from.
+func (u *workflowUsecase) transition(c echo.Context, sequenceID uuid.UUID, status models.SequenceStatus, from ...models.SequenceStatus) (*models.Sequence, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	sequence, err := u.repository.LockSequence(tx, sequenceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ac.AppLoger.Error("transition - sequence not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+	}
+	if err != nil {
+		ac.AppLoger.Errorf("transition - failed to fetch sequence: %v", err)
+		return nil, err
+	}
+
+	if !slices.Contains(from, sequence.Status) {
+		return nil, echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("Sequence cannot move from %s to %s", sequence.Status, status))
+	}
+
+	if err := u.repository.UpdateSequenceStatus(tx, sequenceID, status); err != nil {
+		ac.AppLoger.Errorf("transition - failed to update sequence: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("transition - failed to commit transaction: %v", err)
+		return nil, err
+	}
+	ac.AppLoger.Infof("transition - sequence %s moved to %s", sequenceID.String(), status)
+
+	sequence.Status = status
+	return sequence, nil
+}
+
+// ListSequenceVersions returns the published versions of a sequence, newest first.
+func (u *workflowUsecase) ListSequenceVersions(c echo.Context, sequenceID uuid.UUID) ([]models.SequenceVersion, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	if err := u.ensureSequenceExists(sequenceID); err != nil {
+		ac.AppLoger.Errorf("ListSequenceVersions - %v", err)
+		return nil, err
+	}
+	return u.repository.ListSequenceVersions(sequenceID)
+}
+
+// DiffSequenceVersions compares the steps of two versions of a sequence.
+func (u *workflowUsecase) DiffSequenceVersions(c echo.Context, sequenceID uuid.UUID, req *dto.DiffSequenceVersionsRequest) (*dto.SequenceVersionDiff, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	from, err := u.getVersion(sequenceID, req.From)
+	if err != nil {
+		ac.AppLoger.Errorf("DiffSequenceVersions - %v", err)
+		return nil, err
+	}
+	to, err := u.getVersion(sequenceID, req.To)
+	if err != nil {
+		ac.AppLoger.Errorf("DiffSequenceVersions - %v", err)
+		return nil, err
+	}
+
+	added, removed, changed := diffSteps(from.Steps, to.Steps)
+	return &dto.SequenceVersionDiff{
+		From:    from.Version,
+		To:      to.Version,
+		Added:   added,
+		Removed: removed,
+		Changed: changed,
+	}, nil
+}
+
+func (u *workflowUsecase) getVersion(sequenceID uuid.UUID, version int) (*models.SequenceVersion, error) {
+	sequenceVersion, err := u.repository.GetSequenceVersion(sequenceID, version)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Version %d not found", version))
+	}
+	return sequenceVersion, err
+}
+
+// AssignMailboxes links mailboxes to a sequence as senders. Already assigned mailboxes are left untouched.
+func (u *workflowUsecase) AssignMailboxes(c echo.Context, sequenceID uuid.UUID, req *dto.AssignMailboxesRequest) ([]models.Mailbox, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	if err := u.ensureSequenceExists(sequenceID); err != nil {
+		ac.AppLoger.Errorf("AssignMailboxes - %v", err)
+		return nil, err
+	}
+
+	mailboxes, err := u.repository.GetMailboxesByIDs(req.MailboxIDs)
+	if err != nil {
+		ac.AppLoger.Errorf("AssignMailboxes - failed to fetch mailboxes: %v", err)
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]struct{}, len(mailboxes))
+	for _, mailbox := range mailboxes {
+		found[mailbox.ID] = struct{}{}
+	}
+
+	var missing []string
+	sequenceMailboxes := make([]models.SequenceMailbox, 0, len(req.MailboxIDs))
+	for _, mailboxID := range req.MailboxIDs {
+		if _, ok := found[mailboxID]; !ok {
+			missing = append(missing, mailboxID.String())
+			continue
+		}
+		sequenceMailboxes = append(sequenceMailboxes, models.SequenceMailbox{
+			SequenceID: sequenceID,
+			MailboxID:  mailboxID,
+		})
+	}
+	if len(missing) > 0 {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Mailboxes not found: "+strings.Join(missing, ", "))
+	}
+
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	if err := u.repository.CreateSequenceMailboxes(tx, sequenceMailboxes); err != nil {
+		ac.AppLoger.Errorf("AssignMailboxes - failed to assign mailboxes: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("AssignMailboxes - failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	return u.repository.GetSequenceMailboxes(sequenceID)
+}
+
+func (u *workflowUsecase) GetSequenceMailboxes(c echo.Context, sequenceID uuid.UUID) ([]models.Mailbox, error) {
+	ac := c.(*ctx.CustomApplicationContext)
+	if err := u.ensureSequenceExists(sequenceID); err != nil {
+		ac.AppLoger.Errorf("GetSequenceMailboxes - %v", err)
+		return nil, err
+	}
+	return u.repository.GetSequenceMailboxes(sequenceID)
+}
+
+func (u *workflowUsecase) UnassignMailbox(c echo.Context, sequenceID uuid.UUID, mailboxID uuid.UUID) error {
+	ac := c.(*ctx.CustomApplicationContext)
+	tx := ac.Postgres.Begin()
+	defer tx.Rollback()
+
+	deleted, err := u.repository.DeleteSequenceMailbox(tx, sequenceID, mailboxID)
+	if err != nil {
+		ac.AppLoger.Errorf("UnassignMailbox - failed to unassign mailbox: %v", err)
+		return err
+	}
+	if deleted == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Mailbox is not assigned to this sequence")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		ac.AppLoger.Errorf("UnassignMailbox - failed to commit transaction: %v", err)
+		return err
+	}
+	ac.AppLoger.Infof("UnassignMailbox - mailbox %s unassigned from sequence %s", mailboxID.String(), sequenceID.String())
+
+	return nil
+}
+
+func (u *workflowUsecase) ensureSequenceExists(sequenceID uuid.UUID) error {
+	_, err := u.repository.GetSequence(sequenceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Sequence not found")
+	}
+	return err
+}
+
+// validateSteps checks the steps of a new sequence. Step orders must be unique and contiguous from 1;
+// each colliding step is reported together with the step it collides with.
+func validateSteps(steps []dto.CreateStepRequest) dto.FieldErrors {
+	var errs dto.FieldErrors
+	firstByOrder := make(map[int]int, len(steps))
+	for i, step := range steps {
+		if first, ok := firstByOrder[step.StepOrder]; ok {
+			errs = append(errs, dto.FieldError{
+				Field:   fmt.Sprintf("steps[%d].step_order", i),
+				Message: fmt.Sprintf("step_order %d is already used by steps[%d]", step.StepOrder, first),
+			})
+			continue
+		}
+		firstByOrder[step.StepOrder] = i
+	}
+
+	for i, step := range steps {
+		if step.StepOrder < 1 || step.StepOrder > len(steps) {
+			errs = append(errs, dto.FieldError{
+				Field:   fmt.Sprintf("steps[%d].step_order", i),
+				Message: fmt.Sprintf("step_order %d is out of range, orders must run from 1 to %d without gaps", step.StepOrder, len(steps)),
+			})
+		}
+	}
+
+	for i := range steps {
+		errs = append(errs, validateStepTemplates(fmt.Sprintf("steps[%d].", i), &steps[i].Subject, &steps[i].Content)...)
+		errs = append(errs, validateSendWindow(fmt.Sprintf("steps[%d].send_window", i), steps[i].SendWindow)...)
+	}
+	return errs
+}
+
+// validateStepTemplates checks the merge fields of a step subject and content. The errors name the
+// offending field, prefixed with fieldPrefix. Nil fields are not validated.
+func validateStepTemplates(fieldPrefix string, subject, content *string) dto.FieldErrors {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{name: "subject", value: subject},
+		{name: "content", value: content},
+	}
+	var errs dto.FieldErrors
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+		if err := merge.Validate(*field.value); err != nil {
+			errs = append(errs, dto.FieldError{Field: fieldPrefix + field.name, Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// validateSendWindow checks the times and days of a send window. A nil window is valid.
+func validateSendWindow(field string, window *dto.SendWindow) dto.FieldErrors {
+	if window == nil {
+		return nil
+	}
+	if _, err := sendwindow.New(window.Start, window.End, window.Days); err != nil {
+		return dto.FieldErrors{{Field: field, Message: err.Error()}}
+	}
+	return nil
+}
+
+// setSendWindow replaces the send window of a step, or clears it when window is nil.
+func setSendWindow(step *models.Step, window *dto.SendWindow) {
+	if window == nil {
+		step.SendWindowStart, step.SendWindowEnd, step.SendWindowDays = nil, nil, nil
+		return
+	}
+	start, end := window.Start, window.End
+	step.SendWindowStart, step.SendWindowEnd = &start, &end
+	step.SendWindowDays = models.Weekdays(window.Days)
+}
+
+// diffSteps matches the steps of two versions by ID and returns the steps only in to, the steps only in
+// from and the field changes of the steps in both. Empty slices are returned rather than nil.
+func diffSteps(from, to []models.Step) ([]models.Step, []models.Step, []dto.StepDiff) {
+	before := make(map[uuid.UUID]models.Step, len(from))
+	for _, step := range from {
+		before[step.ID] = step
+	}
+
+	added, removed, changed := []models.Step{}, []models.Step{}, []dto.StepDiff{}
+	seen := make(map[uuid.UUID]struct{}, len(to))
+	for _, step := range to {
+		seen[step.ID] = struct{}{}
+		old, ok := before[step.ID]
+		if !ok {
+			added = append(added, step)
+			continue
+		}
+		if changes := diffStepFields(old, step); len(changes) > 0 {
+			changed = append(changed, dto.StepDiff{StepID: step.ID, Changes: changes})
+		}
+	}
+	for _, step := range from {
+		if _, ok := seen[step.ID]; !ok {
+			removed = append(removed, step)
+		}
+	}
+	return added, removed, changed
+}
+
+func diffStepFields(from, to models.Step) []dto.FieldChange {
+	fields := []struct {
+		name     string
+		from, to any
+	}{
+		{"step_order", from.StepOrder, to.StepOrder},
+		{"subject", from.Subject, to.Subject},
+		{"content", from.Content, to.Content},
+		{"wait_days", from.WaitDays, to.WaitDays},
+		{"wait_hours", from.WaitHours, to.WaitHours},
+		{"wait_minutes", from.WaitMinutes, to.WaitMinutes},
+		{"send_window_start", derefString(from.SendWindowStart), derefString(to.SendWindowStart)},
+		{"send_window_end", derefString(from.SendWindowEnd), derefString(to.SendWindowEnd)},
+		{"send_window_days", strings.Join(from.SendWindowDays, ","), strings.Join(to.SendWindowDays, ",")},
+	}
+
+	var changes []dto.FieldChange
+	for _, field := range fields {
+		if field.from != field.to {
+			changes = append(changes, dto.FieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	return changes
+}
+
+// copyName suffixes the name of a cloned sequence, trimming it to fit the 255 characters of the column.
+func copyName(name string) string {
+	const suffix = " (copy)"
+	runes := []rune(name)
+	if limit := 255 - len(suffix); len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return string(runes) + suffix
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
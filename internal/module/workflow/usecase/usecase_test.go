@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"errors"
+	"net/http"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
@@ -13,6 +16,7 @@ import (
 	"github.com/rohanchauhan02/sequence-service/internal/models"
 	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
 	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/utils"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -26,6 +30,24 @@ func newMockCtx(db *gorm.DB) echo.Context {
 	}
 }
 
+// expectPublishVersion expects the steps to be published as version want on top of latest, which may be nil.
+func expectPublishVersion(t *testing.T, mockRepo *mock_workflow.MockRepository, sequenceID uuid.UUID, latest *models.SequenceVersion, steps []models.Step, want int) {
+	mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+	if latest == nil {
+		mockRepo.EXPECT().GetLatestVersion(gomock.Any(), sequenceID).Return(nil, gorm.ErrRecordNotFound)
+	} else {
+		mockRepo.EXPECT().GetLatestVersion(gomock.Any(), sequenceID).Return(latest, nil)
+	}
+	mockRepo.EXPECT().
+		CreateSequenceVersion(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *gorm.DB, version *models.SequenceVersion) error {
+			if version.SequenceID != sequenceID || version.Version != want || len(version.Steps) != len(steps) {
+				t.Errorf("unexpected version: %+v", version)
+			}
+			return nil
+		})
+}
+
 func Test_CreateSequence(t *testing.T) {
 	sqlDB, mock, err := sqlmock.New()
 	if err != nil {
@@ -45,12 +67,16 @@ func Test_CreateSequence(t *testing.T) {
 
 	mockRepo := mock_workflow.NewMockRepository(ctrl)
 	u := NewWorkflowUsecase(mockRepo)
+	validator := utils.DefaultValidator()
 
 	tests := []struct {
-		name       string
-		req        *dto.CreateSequenceRequest
-		setupMocks func()
-		wantErr    bool
+		name        string
+		req         *dto.CreateSequenceRequest
+		setupMocks  func()
+		wantInvalid bool
+		wantErr     bool
+		wantStatus  int
+		wantFields  []string
 	}{
 		{
 			name: "success - create sequence with steps",
@@ -63,16 +89,59 @@ func Test_CreateSequence(t *testing.T) {
 				},
 			},
 			setupMocks: func() {
+				sequenceID := uuid.New()
 				mockRepo.EXPECT().
 					CreateSequence(gomock.Any(), gomock.Any()).
-					Return(&models.Sequence{ID: uuid.New()}, nil)
+					Return(&models.Sequence{ID: sequenceID}, nil)
 
 				mockRepo.EXPECT().
 					CreateSteps(gomock.Any(), gomock.Any()).
 					Return(nil, nil)
+
+				steps := []models.Step{{ID: uuid.New(), SequenceID: sequenceID, StepOrder: 1, Subject: "Subj", Content: "Cont", WaitDays: 2}}
+				expectPublishVersion(t, mockRepo, sequenceID, nil, steps, 1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - create sequence with a sub-day delay",
+			req: &dto.CreateSequenceRequest{
+				Name: "Seq 4",
+				Steps: []dto.CreateStepRequest{
+					{StepOrder: 1, Subject: "Subj", Content: "Cont", WaitDays: 0, WaitHours: 2},
+				},
+			},
+			setupMocks: func() {
+				sequenceID := uuid.New()
+				mockRepo.EXPECT().
+					CreateSequence(gomock.Any(), gomock.Any()).
+					Return(&models.Sequence{ID: sequenceID}, nil)
+
+				mockRepo.EXPECT().
+					CreateSteps(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, steps []models.Step) (*[]models.Step, error) {
+						if steps[0].WaitDays != 0 || steps[0].WaitHours != 2 {
+							t.Errorf("unexpected step delay: %d days %d hours", steps[0].WaitDays, steps[0].WaitHours)
+						}
+						return &steps, nil
+					})
+
+				steps := []models.Step{{ID: uuid.New(), SequenceID: sequenceID, StepOrder: 1, Subject: "Subj", Content: "Cont", WaitHours: 2}}
+				expectPublishVersion(t, mockRepo, sequenceID, nil, steps, 1)
 			},
 			wantErr: false,
 		},
+		{
+			name: "error - negative step delay",
+			req: &dto.CreateSequenceRequest{
+				Name: "Seq 5",
+				Steps: []dto.CreateStepRequest{
+					{StepOrder: 1, Subject: "Subj", Content: "Cont", WaitDays: -1},
+				},
+			},
+			setupMocks:  func() {},
+			wantInvalid: true,
+		},
 		{
 			name: "error - repository fails to create sequence",
 			req:  &dto.CreateSequenceRequest{Name: "Bad Seq"},
@@ -83,12 +152,369 @@ func Test_CreateSequence(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error - unknown merge field in step content",
+			req: &dto.CreateSequenceRequest{
+				Name: "Seq 2",
+				Steps: []dto.CreateStepRequest{
+					{StepOrder: 1, Subject: "Hi {{first_name}}", Content: "Cont"},
+					{StepOrder: 2, Subject: "Follow up", Content: "Hi {{frist_name}}"},
+				},
+			},
+			setupMocks: func() {},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"steps[1].content"},
+		},
+		{
+			name: "error - colliding and gapped step orders",
+			req: &dto.CreateSequenceRequest{
+				Name: "Seq 3",
+				Steps: []dto.CreateStepRequest{
+					{StepOrder: 1, Subject: "Subj", Content: "Cont"},
+					{StepOrder: 1, Subject: "Subj", Content: "Cont"},
+					{StepOrder: 4, Subject: "Subj", Content: "Cont"},
+				},
+			},
+			setupMocks: func() {},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"steps[1].step_order", "steps[2].step_order"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The handler validates the request before calling the usecase.
+			if err := validator.Validate(tt.req); (err != nil) != tt.wantInvalid {
+				t.Fatalf("Validate() error = %v, wantInvalid %v", err, tt.wantInvalid)
+			}
+			if tt.wantInvalid {
+				return
+			}
+
+			if tt.wantStatus == 0 {
+				mock.ExpectBegin()
+				if !tt.wantErr {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			_, err := u.CreateSequence(c, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateSequence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Fatalf("CreateSequence() error = %v, want status %d", err, tt.wantStatus)
+				}
+				fieldErrs, _ := httpErr.Message.(dto.FieldErrors)
+				if len(fieldErrs) != len(tt.wantFields) {
+					t.Fatalf("CreateSequence() field errors = %v, want fields %v", fieldErrs, tt.wantFields)
+				}
+				for i, field := range tt.wantFields {
+					if fieldErrs[i].Field != field {
+						t.Errorf("CreateSequence() field error %d = %q, want %q", i, fieldErrs[i].Field, field)
+					}
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+
+}
+
+func Test_AssignMailboxes(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID := uuid.New()
+	mailboxID := uuid.New()
+
+	tests := []struct {
+		name       string
+		req        *dto.AssignMailboxesRequest
+		setupMocks func()
+		expectTx   bool
+		wantStatus int
+	}{
+		{
+			name: "success - assign mailboxes",
+			req:  &dto.AssignMailboxesRequest{MailboxIDs: []uuid.UUID{mailboxID}},
+			setupMocks: func() {
+				mockRepo.EXPECT().GetSequence(sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetMailboxesByIDs([]uuid.UUID{mailboxID}).Return([]models.Mailbox{{ID: mailboxID}}, nil)
+				mockRepo.EXPECT().
+					CreateSequenceMailboxes(gomock.Any(), []models.SequenceMailbox{{SequenceID: sequenceID, MailboxID: mailboxID}}).
+					Return(nil)
+				mockRepo.EXPECT().GetSequenceMailboxes(sequenceID).Return([]models.Mailbox{{ID: mailboxID}}, nil)
+			},
+			expectTx: true,
+		},
+		{
+			name: "error - sequence not found",
+			req:  &dto.AssignMailboxesRequest{MailboxIDs: []uuid.UUID{mailboxID}},
+			setupMocks: func() {
+				mockRepo.EXPECT().GetSequence(sequenceID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "error - mailbox not found",
+			req:  &dto.AssignMailboxesRequest{MailboxIDs: []uuid.UUID{mailboxID, uuid.New()}},
+			setupMocks: func() {
+				mockRepo.EXPECT().GetSequence(sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetMailboxesByIDs(gomock.Any()).Return([]models.Mailbox{{ID: mailboxID}}, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			_, err := u.AssignMailboxes(c, sequenceID, tt.req)
+			if tt.wantStatus == 0 && err != nil {
+				t.Errorf("AssignMailboxes() unexpected error = %v", err)
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("AssignMailboxes() error = %v, want status %d", err, tt.wantStatus)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ListSequences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	tests := []struct {
+		name       string
+		req        *dto.ListSequencesRequest
+		setupMocks func()
+		wantErr    bool
+		wantMeta   *dto.PaginationMeta
+	}{
+		{
+			name: "success - defaults to newest first",
+			req:  &dto.ListSequencesRequest{},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ListSequences("", "", "created_at", "desc", 0, dto.DefaultLimit).
+					Return([]models.Sequence{{ID: uuid.New()}}, int64(1), nil)
+			},
+			wantMeta: dto.NewPaginationMeta(dto.DefaultPage, dto.DefaultLimit, 1),
+		},
+		{
+			name: "success - search and sort by updated_at",
+			req:  &dto.ListSequencesRequest{Page: 3, Limit: 5, Search: " onboarding ", SortBy: "updated_at", Order: "asc"},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ListSequences("onboarding", "", "updated_at", "asc", 10, 5).
+					Return([]models.Sequence{}, int64(12), nil)
+			},
+			wantMeta: dto.NewPaginationMeta(3, 5, 12),
+		},
+		{
+			name: "error - repository fails to list sequences",
+			req:  &dto.ListSequencesRequest{},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					ListSequences(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, int64(0), errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMockCtx(nil)
+
+			tt.setupMocks()
+
+			_, meta, err := u.ListSequences(c, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListSequences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantMeta != nil && (meta == nil || *meta != *tt.wantMeta) {
+				t.Errorf("ListSequences() meta = %+v, want %+v", meta, tt.wantMeta)
+			}
+		})
+	}
+}
+
+func Test_AddStep(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	steps := []models.Step{{ID: first, StepOrder: 1}, {ID: second, StepOrder: 3}}
+
+	tests := []struct {
+		name       string
+		req        *dto.AddStepRequest
+		force      bool
+		setupMocks func()
+		wantCommit bool
+		wantStatus int
+	}{
+		{
+			name: "success - insert between steps and renumber",
+			req:  &dto.AddStepRequest{Position: 2, Subject: "Hi {{first_name}}", Content: "Cont", WaitDays: 1},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+				mockRepo.EXPECT().SetStepOrders(gomock.Any(), sequenceID, map[uuid.UUID]int{first: 1, second: 3}).Return(nil)
+				mockRepo.EXPECT().
+					CreateSteps(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, created []models.Step) (*[]models.Step, error) {
+						if len(created) != 1 || created[0].StepOrder != 2 || created[0].SequenceID != sequenceID {
+							t.Errorf("unexpected step: %+v", created)
+						}
+						return &created, nil
+					})
+				expectPublishVersion(t, mockRepo, sequenceID, &models.SequenceVersion{Version: 1, Steps: steps}, append(steps, models.Step{ID: uuid.New()}), 2)
+			},
+			wantCommit: true,
+		},
+		{
+			name: "success - append when position is omitted",
+			req:  &dto.AddStepRequest{Subject: "Subj", Content: "Cont"},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+				mockRepo.EXPECT().SetStepOrders(gomock.Any(), sequenceID, map[uuid.UUID]int{first: 1, second: 2}).Return(nil)
+				mockRepo.EXPECT().
+					CreateSteps(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, created []models.Step) (*[]models.Step, error) {
+						if created[0].StepOrder != 3 {
+							t.Errorf("expected step appended at 3, got %d", created[0].StepOrder)
+						}
+						return &created, nil
+					})
+				expectPublishVersion(t, mockRepo, sequenceID, &models.SequenceVersion{Version: 1, Steps: steps}, append(steps, models.Step{ID: uuid.New()}), 2)
+			},
+			wantCommit: true,
+		},
+		{
+			name: "error - position past the end",
+			req:  &dto.AddStepRequest{Position: 4, Subject: "Subj", Content: "Cont"},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - sequence not found",
+			req:  &dto.AddStepRequest{Subject: "Subj", Content: "Cont"},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "error - active sequence without force",
+			req:  &dto.AddStepRequest{Subject: "Subj", Content: "Cont"},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusActive}, nil)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:  "success - active sequence with force",
+			req:   &dto.AddStepRequest{Subject: "Subj", Content: "Cont"},
+			force: true,
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusActive}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+				mockRepo.EXPECT().SetStepOrders(gomock.Any(), sequenceID, map[uuid.UUID]int{first: 1, second: 2}).Return(nil)
+				mockRepo.EXPECT().
+					CreateSteps(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, created []models.Step) (*[]models.Step, error) {
+						return &created, nil
+					})
+				expectPublishVersion(t, mockRepo, sequenceID, &models.SequenceVersion{Version: 1, Steps: steps}, append(steps, models.Step{ID: uuid.New()}), 2)
+			},
+			wantCommit: true,
+		},
+		{
+			name:  "error - archived sequence even with force",
+			req:   &dto.AddStepRequest{Subject: "Subj", Content: "Cont"},
+			force: true,
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusArchived}, nil)
+			},
+			wantStatus: http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mock.ExpectBegin()
-			if !tt.wantErr {
+			if tt.wantCommit {
 				mock.ExpectCommit()
 			} else {
 				mock.ExpectRollback()
@@ -98,9 +524,15 @@ func Test_CreateSequence(t *testing.T) {
 
 			tt.setupMocks()
 
-			_, err := u.CreateSequence(c, tt.req)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("CreateSequence() error = %v, wantErr %v", err, tt.wantErr)
+			_, err := u.AddStep(c, sequenceID, tt.req, tt.force)
+			if tt.wantStatus == 0 && err != nil {
+				t.Errorf("AddStep() unexpected error = %v", err)
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("AddStep() error = %v, want status %d", err, tt.wantStatus)
+				}
 			}
 
 			if err := mock.ExpectationsWereMet(); err != nil {
@@ -108,5 +540,934 @@ func Test_CreateSequence(t *testing.T) {
 			}
 		})
 	}
+}
+
+func Test_ReorderSteps(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
 
+	sequenceID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	steps := []models.Step{{ID: first, StepOrder: 1}, {ID: second, StepOrder: 2}}
+
+	tests := []struct {
+		name       string
+		stepIDs    []uuid.UUID
+		force      bool
+		setupMocks func()
+		wantCommit bool
+		wantStatus int
+	}{
+		{
+			name:    "success - swap steps",
+			stepIDs: []uuid.UUID{second, first},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+				mockRepo.EXPECT().SetStepOrders(gomock.Any(), sequenceID, map[uuid.UUID]int{second: 1, first: 2}).Return(nil)
+				expectPublishVersion(t, mockRepo, sequenceID, &models.SequenceVersion{Version: 4, Steps: steps},
+					[]models.Step{{ID: second, StepOrder: 1}, {ID: first, StepOrder: 2}}, 5)
+			},
+			wantCommit: true,
+		},
+		{
+			name:    "error - step missing from the order",
+			stepIDs: []uuid.UUID{second},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "error - duplicate step",
+			stepIDs: []uuid.UUID{first, first},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "error - active sequence without force",
+			stepIDs: []uuid.UUID{second, first},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusActive}, nil)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:    "error - step of another sequence",
+			stepIDs: []uuid.UUID{first, uuid.New()},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			_, err := u.ReorderSteps(c, sequenceID, &dto.ReorderStepsRequest{StepIDs: tt.stepIDs}, tt.force)
+			if tt.wantStatus == 0 && err != nil {
+				t.Errorf("ReorderSteps() unexpected error = %v", err)
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("ReorderSteps() error = %v, want status %d", err, tt.wantStatus)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_UpdateStep(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID, stepID := uuid.New(), uuid.New()
+	hours, minutes := 4, 30
+	subject := "Subj"
+	latest := &models.SequenceVersion{Version: 2, Steps: models.VersionSteps{{ID: stepID, StepOrder: 1, Subject: subject, WaitDays: 2}}}
+
+	tests := []struct {
+		name       string
+		req        *dto.UpdateStepRequest
+		force      bool
+		setupMocks func()
+		expectTx   bool
+		wantCommit bool
+		wantStatus int
+	}{
+		{
+			name: "success - patch delay and send window",
+			req: &dto.UpdateStepRequest{
+				WaitHours:   &hours,
+				WaitMinutes: &minutes,
+				SendWindow:  &dto.SendWindow{Start: "09:00", End: "17:00", Days: []string{"mon", "fri"}},
+			},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return([]models.Step{{ID: stepID, StepOrder: 1, Subject: subject, WaitDays: 2}}, nil)
+				mockRepo.EXPECT().
+					UpdateStep(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, step *models.Step) error {
+						if step.WaitDays != 2 || step.WaitHours != 4 || step.WaitMinutes != 30 ||
+							step.SendWindowStart == nil || *step.SendWindowStart != "09:00" || len(step.SendWindowDays) != 2 {
+							t.Errorf("unexpected step: %+v", step)
+						}
+						return nil
+					})
+				expectPublishVersion(t, mockRepo, sequenceID, latest, []models.Step{{ID: stepID, StepOrder: 1, Subject: subject, WaitDays: 2, WaitHours: 4}}, 3)
+			},
+			expectTx:   true,
+			wantCommit: true,
+		},
+		{
+			name: "success - unchanged step publishes no version",
+			req:  &dto.UpdateStepRequest{Subject: &subject},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return([]models.Step{{ID: stepID, StepOrder: 1, Subject: subject, WaitDays: 2}}, nil)
+				mockRepo.EXPECT().UpdateStep(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return([]models.Step(latest.Steps), nil)
+				mockRepo.EXPECT().GetLatestVersion(gomock.Any(), sequenceID).Return(latest, nil)
+			},
+			expectTx:   true,
+			wantCommit: true,
+		},
+		{
+			name: "success - remove send window",
+			req:  &dto.UpdateStepRequest{RemoveSendWindow: true},
+			setupMocks: func() {
+				start, end := "09:00", "17:00"
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return([]models.Step{{ID: stepID, SendWindowStart: &start, SendWindowEnd: &end}}, nil)
+				mockRepo.EXPECT().
+					UpdateStep(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, step *models.Step) error {
+						if step.SendWindowStart != nil || step.SendWindowEnd != nil {
+							t.Errorf("expected send window removed, got: %+v", step)
+						}
+						return nil
+					})
+				expectPublishVersion(t, mockRepo, sequenceID, latest, []models.Step{{ID: stepID, StepOrder: 1}}, 3)
+			},
+			expectTx:   true,
+			wantCommit: true,
+		},
+		{
+			name: "error - step not found",
+			req:  &dto.UpdateStepRequest{WaitHours: &hours},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return([]models.Step{{ID: uuid.New(), StepOrder: 1}}, nil)
+			},
+			expectTx:   true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error - send window ends before it starts",
+			req:        &dto.UpdateStepRequest{SendWindow: &dto.SendWindow{Start: "17:00", End: "09:00"}},
+			setupMocks: func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - active sequence without force",
+			req:  &dto.UpdateStepRequest{WaitHours: &hours},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusActive}, nil)
+			},
+			expectTx:   true,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:  "success - active sequence with force",
+			req:   &dto.UpdateStepRequest{WaitHours: &hours},
+			force: true,
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusActive}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return([]models.Step{{ID: stepID}}, nil)
+				mockRepo.EXPECT().UpdateStep(gomock.Any(), gomock.Any()).Return(nil)
+				expectPublishVersion(t, mockRepo, sequenceID, latest, []models.Step{{ID: stepID, StepOrder: 1, WaitHours: 4}}, 3)
+			},
+			expectTx:   true,
+			wantCommit: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectTx {
+				mock.ExpectBegin()
+				if tt.wantCommit {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			err := u.UpdateStep(c, sequenceID, stepID, tt.req, tt.force)
+			if tt.wantStatus == 0 && err != nil {
+				t.Errorf("UpdateStep() unexpected error = %v", err)
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("UpdateStep() error = %v, want status %d", err, tt.wantStatus)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_DeleteStep(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID := uuid.New()
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	steps := []models.Step{{ID: first, StepOrder: 1}, {ID: second, StepOrder: 2}, {ID: third, StepOrder: 3}}
+
+	tests := []struct {
+		name       string
+		stepID     uuid.UUID
+		setupMocks func()
+		wantCommit bool
+		wantStatus int
+	}{
+		{
+			name:   "success - delete and renumber the remaining steps",
+			stepID: second,
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+				mockRepo.EXPECT().DeleteStep(gomock.Any(), sequenceID, second).Return(nil)
+				mockRepo.EXPECT().SetStepOrders(gomock.Any(), sequenceID, map[uuid.UUID]int{first: 1, third: 2}).Return(nil)
+				expectPublishVersion(t, mockRepo, sequenceID, &models.SequenceVersion{Version: 1, Steps: steps},
+					[]models.Step{{ID: first, StepOrder: 1}, {ID: third, StepOrder: 2}}, 2)
+			},
+			wantCommit: true,
+		},
+		{
+			name:   "error - step not found",
+			stepID: uuid.New(),
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			err := u.DeleteStep(c, sequenceID, tt.stepID, false)
+			if tt.wantStatus == 0 && err != nil {
+				t.Errorf("DeleteStep() unexpected error = %v", err)
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("DeleteStep() error = %v, want status %d", err, tt.wantStatus)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_DiffSequenceVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID := uuid.New()
+	kept, moved, dropped, added := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	start := "09:00"
+
+	mockRepo.EXPECT().GetSequenceVersion(sequenceID, 1).Return(&models.SequenceVersion{Version: 1, Steps: models.VersionSteps{
+		{ID: kept, StepOrder: 1, Subject: "Hi", WaitDays: 1},
+		{ID: dropped, StepOrder: 2, Subject: "Bye"},
+		{ID: moved, StepOrder: 3, Subject: "Again"},
+	}}, nil).Times(2)
+	mockRepo.EXPECT().GetSequenceVersion(sequenceID, 2).Return(&models.SequenceVersion{Version: 2, Steps: models.VersionSteps{
+		{ID: kept, StepOrder: 1, Subject: "Hello", WaitDays: 1, SendWindowStart: &start},
+		{ID: moved, StepOrder: 2, Subject: "Again"},
+		{ID: added, StepOrder: 3, Subject: "New"},
+	}}, nil)
+	mockRepo.EXPECT().GetSequenceVersion(sequenceID, 9).Return(nil, gorm.ErrRecordNotFound)
+
+	c := newMockCtx(nil)
+
+	diff, err := u.DiffSequenceVersions(c, sequenceID, &dto.DiffSequenceVersionsRequest{From: 1, To: 2})
+	if err != nil {
+		t.Fatalf("DiffSequenceVersions() unexpected error = %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].ID != added {
+		t.Errorf("DiffSequenceVersions() added = %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != dropped {
+		t.Errorf("DiffSequenceVersions() removed = %+v", diff.Removed)
+	}
+	want := []dto.StepDiff{
+		{StepID: kept, Changes: []dto.FieldChange{
+			{Field: "subject", From: "Hi", To: "Hello"},
+			{Field: "send_window_start", From: "", To: "09:00"},
+		}},
+		{StepID: moved, Changes: []dto.FieldChange{{Field: "step_order", From: 3, To: 2}}},
+	}
+	if !reflect.DeepEqual(diff.Changed, want) {
+		t.Errorf("DiffSequenceVersions() changed = %+v, want %+v", diff.Changed, want)
+	}
+
+	_, err = u.DiffSequenceVersions(c, sequenceID, &dto.DiffSequenceVersionsRequest{From: 1, To: 9})
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusNotFound {
+		t.Errorf("DiffSequenceVersions() error = %v, want status %d", err, http.StatusNotFound)
+	}
+}
+
+func Test_SequenceTransitions(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID := uuid.New()
+
+	tests := []struct {
+		name       string
+		call       func(c echo.Context) (*models.Sequence, error)
+		current    models.SequenceStatus
+		want       models.SequenceStatus
+		wantStatus int
+	}{
+		{
+			name:    "success - activate draft",
+			call:    func(c echo.Context) (*models.Sequence, error) { return u.ActivateSequence(c, sequenceID) },
+			current: models.SequenceStatusDraft,
+			want:    models.SequenceStatusActive,
+		},
+		{
+			name:    "success - resume paused",
+			call:    func(c echo.Context) (*models.Sequence, error) { return u.ActivateSequence(c, sequenceID) },
+			current: models.SequenceStatusPaused,
+			want:    models.SequenceStatusActive,
+		},
+		{
+			name:    "success - pause active",
+			call:    func(c echo.Context) (*models.Sequence, error) { return u.PauseSequence(c, sequenceID) },
+			current: models.SequenceStatusActive,
+			want:    models.SequenceStatusPaused,
+		},
+		{
+			name:    "success - archive active",
+			call:    func(c echo.Context) (*models.Sequence, error) { return u.ArchiveSequence(c, sequenceID) },
+			current: models.SequenceStatusActive,
+			want:    models.SequenceStatusArchived,
+		},
+		{
+			name:       "error - pause draft",
+			call:       func(c echo.Context) (*models.Sequence, error) { return u.PauseSequence(c, sequenceID) },
+			current:    models.SequenceStatusDraft,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "error - activate archived",
+			call:       func(c echo.Context) (*models.Sequence, error) { return u.ActivateSequence(c, sequenceID) },
+			current:    models.SequenceStatusArchived,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, Status: tt.current}, nil)
+			if tt.wantStatus == 0 {
+				mockRepo.EXPECT().UpdateSequenceStatus(gomock.Any(), sequenceID, tt.want).Return(nil)
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			c := newMockCtx(gormDB)
+
+			sequence, err := tt.call(c)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("transition unexpected error = %v", err)
+				} else if sequence.Status != tt.want {
+					t.Errorf("transition status = %s, want %s", sequence.Status, tt.want)
+				}
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("transition error = %v, want status %d", err, tt.wantStatus)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_CloneSequence(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID, cloneID := uuid.New(), uuid.New()
+	start, end := "09:00", "17:00"
+	source := &models.Sequence{ID: sequenceID, Name: "Onboarding", OpenTrackingEnabled: true, Status: models.SequenceStatusActive}
+	steps := []models.Step{
+		{ID: uuid.New(), SequenceID: sequenceID, StepOrder: 1, Subject: "Hi", Content: "Cont", WaitDays: 1},
+		{ID: uuid.New(), SequenceID: sequenceID, StepOrder: 2, Subject: "Again", Content: "Cont", WaitHours: 4, SendWindowStart: &start, SendWindowEnd: &end},
+	}
+
+	tests := []struct {
+		name       string
+		req        *dto.CloneSequenceRequest
+		setupMocks func()
+		wantCommit bool
+		wantName   string
+		wantStatus int
+	}{
+		{
+			name: "success - copy steps and mailboxes with the default name",
+			req:  &dto.CloneSequenceRequest{},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(source, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+				mockRepo.EXPECT().
+					CreateSequence(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, sequence *models.Sequence) (*models.Sequence, error) {
+						if sequence.Status != models.SequenceStatusDraft || !sequence.OpenTrackingEnabled || sequence.ClickTrackingEnabled {
+							t.Errorf("unexpected sequence: %+v", sequence)
+						}
+						sequence.ID = cloneID
+						return sequence, nil
+					})
+				mockRepo.EXPECT().
+					CreateSteps(gomock.Any(), gomock.Len(2)).
+					DoAndReturn(func(_ *gorm.DB, created []models.Step) (*[]models.Step, error) {
+						for i, step := range created {
+							if step.ID != uuid.Nil || step.SequenceID != cloneID || step.StepOrder != steps[i].StepOrder || step.Subject != steps[i].Subject {
+								t.Errorf("unexpected step copy: %+v", step)
+							}
+						}
+						if created[1].WaitHours != 4 || created[1].SendWindowStart == nil || *created[1].SendWindowStart != "09:00" {
+							t.Errorf("expected delay and send window copied, got: %+v", created[1])
+						}
+						return &created, nil
+					})
+				mockRepo.EXPECT().CopySequenceMailboxes(gomock.Any(), sequenceID, cloneID).Return(nil)
+				expectPublishVersion(t, mockRepo, cloneID, nil, steps, 1)
+			},
+			wantCommit: true,
+			wantName:   "Onboarding (copy)",
+		},
+		{
+			name: "success - custom name and no steps",
+			req:  &dto.CloneSequenceRequest{Name: "Q4 Outreach"},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(source, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(nil, nil)
+				mockRepo.EXPECT().
+					CreateSequence(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ *gorm.DB, sequence *models.Sequence) (*models.Sequence, error) {
+						sequence.ID = cloneID
+						return sequence, nil
+					})
+				mockRepo.EXPECT().CopySequenceMailboxes(gomock.Any(), sequenceID, cloneID).Return(nil)
+				expectPublishVersion(t, mockRepo, cloneID, nil, nil, 1)
+			},
+			wantCommit: true,
+			wantName:   "Q4 Outreach",
+		},
+		{
+			name: "error - sequence not found",
+			req:  &dto.CloneSequenceRequest{},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "error - copying mailboxes fails",
+			req:  &dto.CloneSequenceRequest{},
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(source, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(nil, nil)
+				mockRepo.EXPECT().CreateSequence(gomock.Any(), gomock.Any()).Return(&models.Sequence{ID: cloneID}, nil)
+				mockRepo.EXPECT().CopySequenceMailboxes(gomock.Any(), sequenceID, cloneID).Return(errors.New("db error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			clone, err := u.CloneSequence(c, sequenceID, tt.req)
+			switch {
+			case tt.wantCommit:
+				if err != nil {
+					t.Fatalf("CloneSequence() unexpected error = %v", err)
+				}
+				if clone.Name != tt.wantName {
+					t.Errorf("CloneSequence() name = %q, want %q", clone.Name, tt.wantName)
+				}
+			case tt.wantStatus != 0:
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("CloneSequence() error = %v, want status %d", err, tt.wantStatus)
+				}
+			case err == nil:
+				t.Errorf("CloneSequence() expected an error")
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_DeleteSequence(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID := uuid.New()
+
+	tests := []struct {
+		name       string
+		setupMocks func()
+		wantCommit bool
+		wantResp   *dto.DeleteSequenceResponse
+		wantStatus int
+	}{
+		{
+			name: "success - cancel enrollments and pending emails",
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().CancelSequenceScheduledEmails(gomock.Any(), sequenceID).Return(int64(4), nil)
+				mockRepo.EXPECT().CancelSequenceEnrollments(gomock.Any(), sequenceID).Return(int64(3), nil)
+				mockRepo.EXPECT().DeleteSequence(gomock.Any(), sequenceID).Return(nil)
+			},
+			wantCommit: true,
+			wantResp:   &dto.DeleteSequenceResponse{CancelledEnrollments: 3, CancelledEmails: 4},
+		},
+		{
+			name: "error - sequence not found",
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			resp, err := u.DeleteSequence(c, sequenceID)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("DeleteSequence() unexpected error = %v", err)
+				} else if *resp != *tt.wantResp {
+					t.Errorf("DeleteSequence() = %+v, want %+v", resp, tt.wantResp)
+				}
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("DeleteSequence() error = %v, want status %d", err, tt.wantStatus)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_RestoreSequence(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID := uuid.New()
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	tests := []struct {
+		name       string
+		setupMocks func()
+		wantCommit bool
+		wantStatus int
+	}{
+		{
+			name: "success - restore deleted sequence",
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequenceWithDeleted(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, DeletedAt: deleted}, nil)
+				mockRepo.EXPECT().RestoreSequence(gomock.Any(), sequenceID).Return(nil)
+			},
+			wantCommit: true,
+		},
+		{
+			name: "error - sequence is not deleted",
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequenceWithDeleted(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "error - sequence not found",
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequenceWithDeleted(gomock.Any(), sequenceID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			sequence, err := u.RestoreSequence(c, sequenceID)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("RestoreSequence() unexpected error = %v", err)
+				} else if sequence.DeletedAt.Valid {
+					t.Errorf("RestoreSequence() returned a deleted sequence: %+v", sequence)
+				}
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("RestoreSequence() error = %v, want status %d", err, tt.wantStatus)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_RestoreStep(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: sqlDB,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_workflow.NewMockRepository(ctrl)
+	u := NewWorkflowUsecase(mockRepo)
+
+	sequenceID, stepID := uuid.New(), uuid.New()
+	steps := []models.Step{{ID: uuid.New(), StepOrder: 1}, {ID: uuid.New(), StepOrder: 2}}
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	tests := []struct {
+		name       string
+		setupMocks func()
+		wantCommit bool
+		wantStatus int
+	}{
+		{
+			name: "success - restore as the last step",
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+				mockRepo.EXPECT().GetDeletedStep(gomock.Any(), sequenceID, stepID).Return(&models.Step{ID: stepID, StepOrder: 1, DeletedAt: deleted}, nil)
+				mockRepo.EXPECT().RestoreStep(gomock.Any(), sequenceID, stepID, 3).Return(nil)
+				expectPublishVersion(t, mockRepo, sequenceID, &models.SequenceVersion{Version: 2, Steps: steps},
+					append(steps, models.Step{ID: stepID, StepOrder: 3}), 3)
+			},
+			wantCommit: true,
+		},
+		{
+			name: "error - step is not deleted",
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID}, nil)
+				mockRepo.EXPECT().GetSteps(gomock.Any(), sequenceID).Return(steps, nil)
+				mockRepo.EXPECT().GetDeletedStep(gomock.Any(), sequenceID, stepID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "error - active sequence without force",
+			setupMocks: func() {
+				mockRepo.EXPECT().LockSequence(gomock.Any(), sequenceID).Return(&models.Sequence{ID: sequenceID, Status: models.SequenceStatusActive}, nil)
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			c := newMockCtx(gormDB)
+
+			tt.setupMocks()
+
+			step, err := u.RestoreStep(c, sequenceID, stepID, false)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("RestoreStep() unexpected error = %v", err)
+				} else if step.StepOrder != 3 || step.DeletedAt.Valid {
+					t.Errorf("RestoreStep() = %+v", step)
+				}
+			}
+			if tt.wantStatus != 0 {
+				var httpErr *echo.HTTPError
+				if !errors.As(err, &httpErr) || httpErr.Code != tt.wantStatus {
+					t.Errorf("RestoreStep() error = %v, want status %d", err, tt.wantStatus)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
 }
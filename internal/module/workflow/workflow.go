@@ -11,19 +11,62 @@ import (
 type Usecase interface {
 	CreateSequence(c echo.Context, req *dto.CreateSequenceRequest) (*dto.CreateSequenceResponse, error)
 	GetSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error)
+	ListSequences(c echo.Context, req *dto.ListSequencesRequest) ([]models.Sequence, *dto.PaginationMeta, error)
+	CloneSequence(c echo.Context, sequenceID uuid.UUID, req *dto.CloneSequenceRequest) (*models.Sequence, error)
+	DeleteSequence(c echo.Context, sequenceID uuid.UUID) (*dto.DeleteSequenceResponse, error)
+	RestoreSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error)
 	UpdateSequenceTracking(c echo.Context, sequenceID uuid.UUID, req *dto.UpdateSequenceTrackingRequest) error
-	UpdateStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID, req *dto.UpdateStepRequest) error
-	DeleteStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID) error
+	ActivateSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error)
+	PauseSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error)
+	ArchiveSequence(c echo.Context, sequenceID uuid.UUID) (*models.Sequence, error)
+	ListSequenceVersions(c echo.Context, sequenceID uuid.UUID) ([]models.SequenceVersion, error)
+	DiffSequenceVersions(c echo.Context, sequenceID uuid.UUID, req *dto.DiffSequenceVersionsRequest) (*dto.SequenceVersionDiff, error)
+
+	// Step changes are rejected on active sequences unless force is set. Each change publishes a new
+	// version of the sequence.
+	UpdateStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID, req *dto.UpdateStepRequest, force bool) error
+	DeleteStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID, force bool) error
+	AddStep(c echo.Context, sequenceID uuid.UUID, req *dto.AddStepRequest, force bool) (*models.Step, error)
+	ReorderSteps(c echo.Context, sequenceID uuid.UUID, req *dto.ReorderStepsRequest, force bool) ([]models.Step, error)
+	ListDeletedSteps(c echo.Context, sequenceID uuid.UUID) ([]models.Step, error)
+	RestoreStep(c echo.Context, sequenceID uuid.UUID, stepID uuid.UUID, force bool) (*models.Step, error)
+
+	AssignMailboxes(c echo.Context, sequenceID uuid.UUID, req *dto.AssignMailboxesRequest) ([]models.Mailbox, error)
+	GetSequenceMailboxes(c echo.Context, sequenceID uuid.UUID) ([]models.Mailbox, error)
+	UnassignMailbox(c echo.Context, sequenceID uuid.UUID, mailboxID uuid.UUID) error
 }
 
 type Repository interface {
 	CreateSequence(tx *gorm.DB, sequence *models.Sequence) (*models.Sequence, error)
 	GetSequence(sequenceID uuid.UUID) (*models.Sequence, error)
+	ListSequences(search, status, sortBy, order string, offset, limit int) ([]models.Sequence, int64, error)
 	UpdateSequenceTracking(tx *gorm.DB, sequence *models.Sequence) error
+	UpdateSequenceStatus(tx *gorm.DB, sequenceID uuid.UUID, status models.SequenceStatus) error
+	DeleteSequence(tx *gorm.DB, sequenceID uuid.UUID) error
+	LockSequenceWithDeleted(tx *gorm.DB, sequenceID uuid.UUID) (*models.Sequence, error)
+	RestoreSequence(tx *gorm.DB, sequenceID uuid.UUID) error
+	CancelSequenceEnrollments(tx *gorm.DB, sequenceID uuid.UUID) (int64, error)
+	CancelSequenceScheduledEmails(tx *gorm.DB, sequenceID uuid.UUID) (int64, error)
 
 	CreateSteps(tx *gorm.DB, steps []models.Step) (*[]models.Step, error)
-	GetStepByID(sequenceID uuid.UUID, stepID uuid.UUID) (*models.Step, error)
 
 	UpdateStep(tx *gorm.DB, sequence *models.Step) error
 	DeleteStep(tx *gorm.DB, sequenceID uuid.UUID, stepID uuid.UUID) error
+	LockSequence(tx *gorm.DB, sequenceID uuid.UUID) (*models.Sequence, error)
+	GetSteps(tx *gorm.DB, sequenceID uuid.UUID) ([]models.Step, error)
+	SetStepOrders(tx *gorm.DB, sequenceID uuid.UUID, orders map[uuid.UUID]int) error
+	GetDeletedSteps(sequenceID uuid.UUID) ([]models.Step, error)
+	GetDeletedStep(tx *gorm.DB, sequenceID uuid.UUID, stepID uuid.UUID) (*models.Step, error)
+	RestoreStep(tx *gorm.DB, sequenceID uuid.UUID, stepID uuid.UUID, stepOrder int) error
+
+	GetLatestVersion(tx *gorm.DB, sequenceID uuid.UUID) (*models.SequenceVersion, error)
+	GetSequenceVersion(sequenceID uuid.UUID, version int) (*models.SequenceVersion, error)
+	ListSequenceVersions(sequenceID uuid.UUID) ([]models.SequenceVersion, error)
+	CreateSequenceVersion(tx *gorm.DB, version *models.SequenceVersion) error
+
+	GetMailboxesByIDs(mailboxIDs []uuid.UUID) ([]models.Mailbox, error)
+	CreateSequenceMailboxes(tx *gorm.DB, sequenceMailboxes []models.SequenceMailbox) error
+	CopySequenceMailboxes(tx *gorm.DB, fromSequenceID uuid.UUID, toSequenceID uuid.UUID) error
+	GetSequenceMailboxes(sequenceID uuid.UUID) ([]models.Mailbox, error)
+	DeleteSequenceMailbox(tx *gorm.DB, sequenceID uuid.UUID, mailboxID uuid.UUID) (int64, error)
 }
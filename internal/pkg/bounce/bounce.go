@@ -0,0 +1,247 @@
+package bounce
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Kind string
+
+const (
+	// KindHard is a permanent failure of the recipient address, which is suppressed.
+	KindHard Kind = "hard"
+	// KindSoft is a temporary failure or a rejection that does not prove the address is invalid.
+	KindSoft Kind = "soft"
+)
+
+// ErrNotBounce is returned by ParseDSN for delivery status notifications that do not report a failure,
+// e.g. delivered or relayed messages.
+var ErrNotBounce = errors.New("message is not a bounce")
+
+var (
+	// replyPattern matches an SMTP reply code, optionally followed by an enhanced status code, at the start
+	// of an error message or after a "context: " prefix.
+	replyPattern  = regexp.MustCompile(`(?:^|: )([45]\d\d)[ -](?:([45]\.\d{1,3}\.\d{1,3})\b)?`)
+	statusPattern = regexp.MustCompile(`\b([245]\.\d{1,3}\.\d{1,3})\b`)
+)
+
+// Bounce is a failed delivery to a recipient.
+type Bounce struct {
+	Kind Kind
+	// Code is the SMTP reply code, 0 when it is unknown.
+	Code int
+	// Status is the enhanced status code (RFC 3463), e.g. 5.1.1.
+	Status     string
+	Diagnostic string
+	// Recipient and MessageID are only set for delivery status notifications.
+	Recipient string
+	MessageID string
+}
+
+// RecipientError marks an SMTP error returned in reply to RCPT TO, which rejects the recipient itself.
+type RecipientError struct {
+	Err error
+}
+
+func (e *RecipientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RecipientError) Unwrap() error {
+	return e.Err
+}
+
+// Recipient marks err as the rejection of a recipient by RCPT TO.
+func Recipient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RecipientError{Err: err}
+}
+
+// Classify returns the bounce of an SMTP error rejecting a recipient or a message, nil for errors that
+// are not SMTP rejections such as network failures or command syntax errors. Only rejections marked
+// with Recipient can be hard: a 5xx reply to MAIL FROM or DATA concerns the sender or the message,
+// not the address, and is soft whatever its status code.
+func Classify(err error) *Bounce {
+	if err == nil {
+		return nil
+	}
+
+	var code int
+	var status string
+	diagnostic := err.Error()
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		code = protoErr.Code
+		status = statusPattern.FindString(protoErr.Msg)
+		diagnostic = fmt.Sprintf("%d %s", protoErr.Code, protoErr.Msg)
+	} else if groups := replyPattern.FindStringSubmatch(diagnostic); groups != nil {
+		code, _ = strconv.Atoi(groups[1])
+		status = groups[2]
+	}
+	if code == 0 || (code >= 500 && code <= 504) {
+		return nil
+	}
+
+	kind := ClassifyStatus(code, status)
+	var recipientErr *RecipientError
+	if !errors.As(err, &recipientErr) {
+		kind = KindSoft
+	}
+
+	return &Bounce{
+		Kind:       kind,
+		Code:       code,
+		Status:     status,
+		Diagnostic: diagnostic,
+	}
+}
+
+// ClassifyStatus tells hard from soft bounces by their enhanced status code, or by the SMTP reply code
+// when there is none. Only permanent failures of the address are hard: bad or disabled mailboxes
+// (5.1.x, 5.2.1) and 550, 551 and 553 replies. Full mailboxes (5.2.2, 552) and policy rejections
+// (5.7.x, 554), which usually concern the sender, are soft like every temporary failure.
+func ClassifyStatus(code int, status string) Kind {
+	if status != "" {
+		class, subject, detail := splitStatus(status)
+		switch {
+		case class != "5":
+			return KindSoft
+		case subject == "1", subject == "2" && detail == "1":
+			return KindHard
+		case subject == "0":
+			// 5.0.0 carries no detail beyond the reply code.
+		default:
+			return KindSoft
+		}
+	}
+
+	switch code {
+	case 550, 551, 553:
+		return KindHard
+	default:
+		return KindSoft
+	}
+}
+
+// ParseDSN parses a delivery status notification (RFC 3464) and returns the failure it reports for the
+// first failed or delayed recipient. MessageID is the Message-ID of the returned original message.
+func ParseDSN(r io.Reader) (*Bounce, error) {
+	msg, err := netmail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/report" || params["boundary"] == "" {
+		return nil, ErrNotBounce
+	}
+
+	var bounce *Bounce
+	var messageID string
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid report: %w", err)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch partType {
+		case "message/delivery-status":
+			if bounce, err = parseDeliveryStatus(part); err != nil {
+				return nil, err
+			}
+		case "message/rfc822", "text/rfc822-headers":
+			header, err := textproto.NewReader(bufio.NewReader(part)).ReadMIMEHeader()
+			if err != nil && len(header) == 0 {
+				return nil, fmt.Errorf("invalid original message: %w", err)
+			}
+			messageID = strings.TrimSpace(header.Get("Message-Id"))
+		}
+	}
+
+	if bounce == nil {
+		return nil, ErrNotBounce
+	}
+	bounce.MessageID = messageID
+	return bounce, nil
+}
+
+// parseDeliveryStatus reads the per-message fields and the per-recipient field groups of a
+// message/delivery-status part, and returns the first recipient that failed or was delayed.
+func parseDeliveryStatus(r io.Reader) (*Bounce, error) {
+	reader := textproto.NewReader(bufio.NewReader(r))
+	if _, err := reader.ReadMIMEHeader(); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid delivery status: %w", err)
+	}
+
+	for {
+		fields, err := reader.ReadMIMEHeader()
+		if len(fields) > 0 {
+			if bounce := recipientBounce(fields); bounce != nil {
+				return bounce, nil
+			}
+		}
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid delivery status: %w", err)
+		}
+	}
+}
+
+func recipientBounce(fields textproto.MIMEHeader) *Bounce {
+	action := strings.ToLower(strings.TrimSpace(fields.Get("Action")))
+	if action != "failed" && action != "delayed" {
+		return nil
+	}
+
+	bounce := &Bounce{
+		Status:     statusPattern.FindString(fields.Get("Status")),
+		Diagnostic: typedValue(fields.Get("Diagnostic-Code")),
+		Recipient:  strings.ToLower(typedValue(fields.Get("Final-Recipient"))),
+	}
+	if groups := replyPattern.FindStringSubmatch(bounce.Diagnostic); groups != nil {
+		bounce.Code, _ = strconv.Atoi(groups[1])
+	}
+
+	bounce.Kind = ClassifyStatus(bounce.Code, bounce.Status)
+	if action == "delayed" {
+		bounce.Kind = KindSoft
+	}
+	return bounce
+}
+
+// typedValue strips the type of a DSN field such as "rfc822; lead@example.com" or "smtp; 550 ...".
+func typedValue(value string) string {
+	if _, v, found := strings.Cut(value, ";"); found {
+		value = v
+	}
+	return strings.TrimSpace(value)
+}
+
+func splitStatus(status string) (class, subject, detail string) {
+	fields := strings.SplitN(status, ".", 3)
+	if len(fields) != 3 {
+		return "", "", ""
+	}
+	return fields[0], fields[1], fields[2]
+}
@@ -0,0 +1,161 @@
+package bounce
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func Test_Classify(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantBounce bool
+		wantKind   Kind
+		wantCode   int
+		wantStatus string
+	}{
+		{
+			name:       "unknown mailbox is hard",
+			err:        fmt.Errorf("rcpt to: %w", Recipient(&textproto.Error{Code: 550, Msg: "5.1.1 <lead@example.com>: Recipient address rejected: User unknown"})),
+			wantBounce: true, wantKind: KindHard, wantCode: 550, wantStatus: "5.1.1",
+		},
+		{
+			name:       "unknown mailbox status outside RCPT TO is soft",
+			err:        fmt.Errorf("data: %w", &textproto.Error{Code: 550, Msg: "5.1.1 User unknown"}),
+			wantBounce: true, wantKind: KindSoft, wantCode: 550, wantStatus: "5.1.1",
+		},
+		{
+			name:       "full mailbox is soft",
+			err:        &textproto.Error{Code: 552, Msg: "5.2.2 Mailbox full"},
+			wantBounce: true, wantKind: KindSoft, wantCode: 552, wantStatus: "5.2.2",
+		},
+		{
+			name:       "policy rejection is soft",
+			err:        fmt.Errorf("smtp: %w", errors.New("550 5.7.1 Message rejected as spam")),
+			wantBounce: true, wantKind: KindSoft, wantCode: 550, wantStatus: "5.7.1",
+		},
+		{
+			name:       "greylisting is soft",
+			err:        errors.New("send: 451 4.7.1 Greylisted, try again later"),
+			wantBounce: true, wantKind: KindSoft, wantCode: 451, wantStatus: "4.7.1",
+		},
+		{
+			name:       "reply code without enhanced status",
+			err:        Recipient(errors.New("553 sorry, that domain isn't in my list of allowed rcpthosts")),
+			wantBounce: true, wantKind: KindHard, wantCode: 553,
+		},
+		{
+			name: "command syntax error is not a bounce",
+			err:  &textproto.Error{Code: 502, Msg: "5.5.1 Unrecognized command"},
+		},
+		{
+			name: "network error is not a bounce",
+			err:  errors.New("dial tcp 10.0.0.1:587: connect: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Classify(tt.err)
+			if (got != nil) != tt.wantBounce {
+				t.Fatalf("Classify() = %+v, want bounce %v", got, tt.wantBounce)
+			}
+			if got != nil && (got.Kind != tt.wantKind || got.Code != tt.wantCode || got.Status != tt.wantStatus) {
+				t.Errorf("Classify() = %+v, want %s %d %q", got, tt.wantKind, tt.wantCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+const dsn = "From: MAILER-DAEMON@mx.example.com\r\n" +
+	"To: sales@acme.example\r\n" +
+	"Subject: Undelivered Mail Returned to Sender\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/report; report-type=delivery-status; boundary=\"b1\"\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"Your message could not be delivered.\r\n" +
+	"--b1\r\n" +
+	"Content-Type: message/delivery-status\r\n" +
+	"\r\n" +
+	"Reporting-MTA: dns; mx.example.com\r\n" +
+	"Arrival-Date: Fri, 17 Oct 2025 09:00:00 +0000\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; Lead@Example.com\r\n" +
+	"Action: %s\r\n" +
+	"Status: %s\r\n" +
+	"Diagnostic-Code: smtp; %s\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/rfc822-headers\r\n" +
+	"\r\n" +
+	"From: sales@acme.example\r\n" +
+	"To: lead@example.com\r\n" +
+	"Message-ID: <5f0c7a3e-8d52-4a51-9a6b-2f0b4c1d9e10@acme.example>\r\n" +
+	"Subject: Quick question\r\n" +
+	"\r\n" +
+	"--b1--\r\n"
+
+func Test_ParseDSN(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		wantErr    error
+		wantKind   Kind
+		wantCode   int
+		wantStatus string
+	}{
+		{
+			name:     "failed recipient is a hard bounce",
+			message:  fmt.Sprintf(dsn, "failed", "5.1.1", "550 5.1.1 The email account does not exist"),
+			wantKind: KindHard, wantCode: 550, wantStatus: "5.1.1",
+		},
+		{
+			name:     "failed recipient with a full mailbox is a soft bounce",
+			message:  fmt.Sprintf(dsn, "failed", "5.2.2", "552 5.2.2 Mailbox full"),
+			wantKind: KindSoft, wantCode: 552, wantStatus: "5.2.2",
+		},
+		{
+			name:     "delayed delivery is a soft bounce",
+			message:  fmt.Sprintf(dsn, "delayed", "4.4.1", "421 4.4.1 Connection timed out"),
+			wantKind: KindSoft, wantCode: 421, wantStatus: "4.4.1",
+		},
+		{
+			name:    "delivered report is not a bounce",
+			message: fmt.Sprintf(dsn, "delivered", "2.0.0", "250 2.0.0 OK"),
+			wantErr: ErrNotBounce,
+		},
+		{
+			name:    "regular email is not a bounce",
+			message: "From: lead@example.com\r\nContent-Type: text/plain\r\n\r\nThanks!\r\n",
+			wantErr: ErrNotBounce,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDSN(strings.NewReader(tt.message))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseDSN() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDSN() unexpected error: %v", err)
+			}
+
+			if got.Kind != tt.wantKind || got.Code != tt.wantCode || got.Status != tt.wantStatus {
+				t.Errorf("ParseDSN() = %+v, want %s %d %q", got, tt.wantKind, tt.wantCode, tt.wantStatus)
+			}
+			if got.Recipient != "lead@example.com" || got.MessageID != "<5f0c7a3e-8d52-4a51-9a6b-2f0b4c1d9e10@acme.example>" {
+				t.Errorf("ParseDSN() recipient = %q, message ID = %q", got.Recipient, got.MessageID)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Package capacity keeps the daily sending capacity and bounce counters of mailboxes in
+// mailbox_daily_counts. An email holds one slot of its mailbox on the UTC day of its scheduled_for, from
+// when it is scheduled until it is sent, or given back when it is cancelled or fails.
+package capacity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"gorm.io/gorm"
+)
+
+// Reserve atomically takes one slot of the mailbox's daily_capacity on the UTC day of day. It reports
+// false when the cap is already reached or the mailbox is deleted.
+func Reserve(tx *gorm.DB, mailboxID uuid.UUID, day time.Time) (bool, error) {
+	result := tx.Exec(`INSERT INTO mailbox_daily_counts (mailbox_id, date, sent_count)
+		SELECT id, ?, 1 FROM mailboxes WHERE id = ? AND daily_capacity > 0 AND deleted_at IS NULL
+		ON CONFLICT (mailbox_id, date) DO UPDATE SET sent_count = mailbox_daily_counts.sent_count + 1
+		WHERE mailbox_daily_counts.sent_count < (SELECT daily_capacity FROM mailboxes WHERE id = EXCLUDED.mailbox_id)`,
+		day.UTC().Format(time.DateOnly), mailboxID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release gives back the slots of the email_queues rows matching query, e.g. "id = ?" or
+// "sequence_contact_id = ? AND status = ?", without counting a failure.
+func Release(tx *gorm.DB, query string, args ...any) error {
+	return release(tx, "mdc.failed_count", query, args)
+}
+
+// ReleaseFailed gives back the slots of the email_queues rows matching query and counts them in
+// failed_count.
+func ReleaseFailed(tx *gorm.DB, query string, args ...any) error {
+	return release(tx, "mdc.failed_count + eq.released", query, args)
+}
+
+// Move moves the slots of the email_queues rows matching query to the UTC day of day, before their
+// scheduled_for is changed to it. The slots are taken even when that day is already full, since the
+// emails hold them already.
+func Move(tx *gorm.DB, day time.Time, query string, args ...any) error {
+	date := day.UTC().Format(time.DateOnly)
+	query = "(" + query + ") AND (scheduled_for AT TIME ZONE 'UTC')::date <> ?::date"
+	args = append(args[:len(args):len(args)], date)
+
+	if err := tx.Exec(`INSERT INTO mailbox_daily_counts (mailbox_id, date, sent_count)
+		SELECT mailbox_id, ?::date, COUNT(*)
+		FROM email_queues
+		WHERE (`+query+`) AND mailbox_id IS NOT NULL
+		GROUP BY mailbox_id
+		ON CONFLICT (mailbox_id, date) DO UPDATE SET sent_count = mailbox_daily_counts.sent_count + EXCLUDED.sent_count`,
+		append([]any{date}, args...)...).Error; err != nil {
+		return err
+	}
+	return Release(tx, query, args...)
+}
+
+func release(tx *gorm.DB, failedCount string, query string, args []any) error {
+	return tx.Exec(`UPDATE mailbox_daily_counts mdc
+		SET sent_count = GREATEST(mdc.sent_count - eq.released, 0), failed_count = `+failedCount+`
+		FROM (
+			SELECT mailbox_id, (scheduled_for AT TIME ZONE 'UTC')::date AS date, COUNT(*) AS released
+			FROM email_queues
+			WHERE (`+query+`) AND mailbox_id IS NOT NULL
+			GROUP BY 1, 2
+		) eq
+		WHERE mdc.mailbox_id = eq.mailbox_id AND mdc.date = eq.date`,
+		args...).Error
+}
+
+// CountBounce counts a hard or soft bounce of an email against its mailbox, on the day it was scheduled
+// for.
+func CountBounce(tx *gorm.DB, emailQueueID uuid.UUID, kind bounce.Kind) error {
+	column := "soft_bounce_count"
+	if kind == bounce.KindHard {
+		column = "hard_bounce_count"
+	}
+	return tx.Exec(`UPDATE mailbox_daily_counts mdc
+		SET `+column+` = mdc.`+column+` + 1
+		FROM email_queues eq
+		WHERE eq.id = ? AND mdc.mailbox_id = eq.mailbox_id AND mdc.date = (eq.scheduled_for AT TIME ZONE 'UTC')::date`,
+		emailQueueID).Error
+}
@@ -0,0 +1,115 @@
+package capacity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	return db, mock
+}
+
+func Test_Reserve(t *testing.T) {
+	mailboxID := uuid.New()
+	// 23:30 in New York is already the next UTC day.
+	day := time.Date(2026, 3, 9, 23, 30, 0, 0, time.FixedZone("EDT", -4*60*60))
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		want         bool
+	}{
+		{name: "success - slot taken", rowsAffected: 1, want: true},
+		{name: "success - cap reached", rowsAffected: 0, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDB(t)
+			mock.ExpectExec(`INSERT INTO mailbox_daily_counts`).
+				WithArgs("2026-03-10", mailboxID).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			got, err := Reserve(db, mailboxID, day)
+			if err != nil {
+				t.Fatalf("Reserve() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Reserve() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_Release(t *testing.T) {
+	emailQueueID := uuid.New()
+
+	tests := []struct {
+		name    string
+		release func(tx *gorm.DB) error
+		set     string
+	}{
+		{
+			name:    "success - release",
+			release: func(tx *gorm.DB) error { return Release(tx, "id = ?", emailQueueID) },
+			set:     `failed_count = mdc\.failed_count\s+FROM`,
+		},
+		{
+			name:    "success - release failed",
+			release: func(tx *gorm.DB) error { return ReleaseFailed(tx, "id = ?", emailQueueID) },
+			set:     `failed_count = mdc\.failed_count \+ eq\.released`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDB(t)
+			mock.ExpectExec(`UPDATE mailbox_daily_counts mdc\s+SET sent_count = GREATEST\(mdc\.sent_count - eq\.released, 0\), ` + tt.set).
+				WithArgs(emailQueueID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			if err := tt.release(db); err != nil {
+				t.Fatalf("release unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_Move(t *testing.T) {
+	db, mock := newDB(t)
+	emailQueueID := uuid.New()
+	day := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO mailbox_daily_counts`).
+		WithArgs("2026-03-10", emailQueueID, "queued", "2026-03-10").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE mailbox_daily_counts mdc`).
+		WithArgs(emailQueueID, "queued", "2026-03-10").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := Move(db, day, "id = ? AND status = ?", emailQueueID, "queued"); err != nil {
+		t.Fatalf("Move() unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
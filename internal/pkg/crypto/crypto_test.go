@@ -0,0 +1,44 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+const testKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+func Test_AESCipher(t *testing.T) {
+	c, err := NewAESCipher(testKey)
+	if err != nil {
+		t.Fatalf("NewAESCipher() unexpected error: %v", err)
+	}
+
+	ciphertext, err := c.Encrypt([]byte("smtp-secret"))
+	if err != nil {
+		t.Fatalf("Encrypt() unexpected error: %v", err)
+	}
+	if bytes.Contains(ciphertext, []byte("smtp-secret")) {
+		t.Error("Encrypt() ciphertext contains the plaintext")
+	}
+
+	plaintext, err := c.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt() unexpected error: %v", err)
+	}
+	if string(plaintext) != "smtp-secret" {
+		t.Errorf("Decrypt() = %q, want %q", plaintext, "smtp-secret")
+	}
+
+	ciphertext[len(ciphertext)-1] ^= 0xff
+	if _, err := c.Decrypt(ciphertext); err == nil {
+		t.Error("Decrypt() expected error for tampered ciphertext")
+	}
+}
+
+func Test_NewAESCipher_InvalidKey(t *testing.T) {
+	for _, key := range []string{"", "not-base64!", "c2hvcnQ="} {
+		if _, err := NewAESCipher(key); err == nil {
+			t.Errorf("NewAESCipher(%q) expected error", key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Signer issues tamper-proof tokens for data embedded in public URLs, such as tracking links.
+type Signer interface {
+	Sign(payload any) (string, error)
+	Verify(token string, payload any) error
+}
+
+type hmacSigner struct {
+	key []byte
+}
+
+// NewHMACSigner returns a HMAC-SHA256 signer for a base64 encoded key of at least 32 bytes.
+// Tokens have the form base64url(json payload) + "." + base64url(signature).
+func NewHMACSigner(encodedKey string) (Signer, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key encoding: %w", err)
+	}
+	if len(key) < 32 {
+		return nil, fmt.Errorf("signing key must be at least 32 bytes, got %d", len(key))
+	}
+
+	return &hmacSigner{key: key}, nil
+}
+
+func (s *hmacSigner) Sign(payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), nil
+}
+
+// Verify checks the token signature and decodes its payload into payload.
+func (s *hmacSigner) Verify(token string, payload any) error {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidToken
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return ErrInvalidToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if err := json.Unmarshal(data, payload); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func (s *hmacSigner) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
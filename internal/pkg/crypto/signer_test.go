@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_HMACSigner(t *testing.T) {
+	s, err := NewHMACSigner(testKey)
+	if err != nil {
+		t.Fatalf("NewHMACSigner() unexpected error: %v", err)
+	}
+
+	type payload struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+
+	token, err := s.Sign(payload{ID: "42", URL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Sign() unexpected error: %v", err)
+	}
+
+	var got payload
+	if err := s.Verify(token, &got); err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+	if got.ID != "42" || got.URL != "https://example.com" {
+		t.Errorf("Verify() payload = %+v", got)
+	}
+
+	encoded, signature, _ := strings.Cut(token, ".")
+	forged, _ := s.Sign(payload{ID: "43", URL: "https://evil.example"})
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for _, tampered := range []string{forgedPayload + "." + signature, encoded, encoded + ".", "garbage"} {
+		if err := s.Verify(tampered, &got); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Verify(%q) error = %v, want ErrInvalidToken", tampered, err)
+		}
+	}
+}
+
+func Test_NewHMACSigner_InvalidKey(t *testing.T) {
+	for _, key := range []string{"", "not-base64!", "c2hvcnQ="} {
+		if _, err := NewHMACSigner(key); err == nil {
+			t.Errorf("NewHMACSigner(%q) expected error", key)
+		}
+	}
+}
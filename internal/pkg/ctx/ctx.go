@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -55,6 +56,19 @@ func (c *CustomApplicationContext) CustomResponse(status string, data any, messa
 	return c.JSON(code, response)
 }
 
+// CustomErrorResponse responds with the status and message of an *echo.HTTPError, or 500 for any other error.
+// Field errors carried by the *echo.HTTPError are returned in data.
+func (c *CustomApplicationContext) CustomErrorResponse(err error) error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		if fieldErrs, ok := httpErr.Message.(dto.FieldErrors); ok {
+			return c.CustomResponse(http.StatusText(httpErr.Code), fieldErrs, "", fieldErrs.Error(), httpErr.Code, nil)
+		}
+		return c.CustomResponse(http.StatusText(httpErr.Code), nil, "", fmt.Sprint(httpErr.Message), httpErr.Code, nil)
+	}
+	return c.CustomResponse(http.StatusText(http.StatusInternalServerError), nil, "", err.Error(), http.StatusInternalServerError, nil)
+}
+
 // CustomBind binds and validates incoming request data.
 func (c *CustomApplicationContext) CustomBind(i any) error {
 	if err := c.Bind(i); err != nil {
@@ -0,0 +1,114 @@
+// Package merge renders merge fields such as {{first_name}} or {{first_name | "there"}} in step
+// subjects and content.
+package merge
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Variables are the merge fields that can be used in templates.
+var Variables = []string{"first_name", "last_name", "email", "company", "phone"}
+
+var fieldPattern = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*(?:\|\s*"([^"]*)"\s*)?$`)
+
+// Error describes an invalid template.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+type part struct {
+	text     string
+	variable string
+	fallback string
+}
+
+// Template is a parsed template.
+type Template struct {
+	parts []part
+}
+
+// Parse parses text and checks that every merge field is well formed and a known variable.
+func Parse(text string) (*Template, error) {
+	t := &Template{}
+	rest, offset := text, 0
+	for {
+		start := strings.Index(rest, "{{")
+		closing := strings.Index(rest, "}}")
+		if closing != -1 && (start == -1 || closing < start) {
+			return nil, &Error{Pos: offset + closing, Msg: `unexpected "}}"`}
+		}
+		if start == -1 {
+			t.parts = append(t.parts, part{text: rest})
+			return t, nil
+		}
+
+		end := strings.Index(rest[start+2:], "}}")
+		if end == -1 {
+			return nil, &Error{Pos: offset + start, Msg: `unclosed "{{"`}
+		}
+
+		field := rest[start+2 : start+2+end]
+		if strings.Contains(field, "{{") {
+			return nil, &Error{Pos: offset + start, Msg: `unclosed "{{"`}
+		}
+		matches := fieldPattern.FindStringSubmatch(field)
+		if matches == nil {
+			return nil, &Error{Pos: offset + start, Msg: fmt.Sprintf("invalid merge field %q", "{{"+field+"}}")}
+		}
+		if !isVariable(matches[1]) {
+			return nil, &Error{Pos: offset + start, Msg: fmt.Sprintf("unknown variable %q, expected one of %s", matches[1], strings.Join(Variables, ", "))}
+		}
+
+		t.parts = append(t.parts,
+			part{text: rest[:start]},
+			part{variable: matches[1], fallback: matches[2]},
+		)
+
+		consumed := start + 2 + end + 2
+		rest, offset = rest[consumed:], offset+consumed
+	}
+}
+
+// Validate reports whether text is a valid template.
+func Validate(text string) error {
+	_, err := Parse(text)
+	return err
+}
+
+// Render fills in the merge fields from values, using the fallback when a value is empty.
+// Values are passed through escape when it is not nil, e.g. html.EscapeString for HTML content.
+func (t *Template) Render(values map[string]string, escape func(string) string) string {
+	var b strings.Builder
+	for _, p := range t.parts {
+		if p.variable == "" {
+			b.WriteString(p.text)
+			continue
+		}
+
+		value := strings.TrimSpace(values[p.variable])
+		if value == "" {
+			value = p.fallback
+		}
+		if escape != nil {
+			value = escape(value)
+		}
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
+func isVariable(name string) bool {
+	for _, v := range Variables {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package merge
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"testing"
+)
+
+func Test_Render(t *testing.T) {
+	values := map[string]string{"first_name": "Ada", "company": "<Acme & Co>", "last_name": "  "}
+
+	tests := []struct {
+		name   string
+		text   string
+		escape func(string) string
+		want   string
+	}{
+		{name: "plain text", text: "Hello there", want: "Hello there"},
+		{name: "variable", text: "Hi {{first_name}}!", want: "Hi Ada!"},
+		{name: "whitespace inside braces", text: "Hi {{ first_name }}", want: "Hi Ada"},
+		{name: "fallback used for missing value", text: `Hi {{phone | "n/a"}}`, want: "Hi n/a"},
+		{name: "fallback used for blank value", text: `Dear {{last_name|"friend"}}`, want: "Dear friend"},
+		{name: "fallback ignored when value is set", text: `Hi {{first_name | "there"}}`, want: "Hi Ada"},
+		{name: "missing value without fallback", text: "Hi {{email}}.", want: "Hi ."},
+		{name: "escaped value", text: "<p>{{company}}</p>", escape: html.EscapeString, want: "<p>&lt;Acme &amp; Co&gt;</p>"},
+		{name: "multiple fields", text: "{{first_name}} at {{company}}", want: "Ada at <Acme & Co>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if got := tmpl.Render(values, tt.escape); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		text    string
+		wantMsg string
+	}{
+		{text: "Hi {{frist_name}}", wantMsg: `unknown variable "frist_name"`},
+		{text: "Hi {{first_name", wantMsg: `unclosed "{{"`},
+		{text: "Hi first_name}}", wantMsg: `unexpected "}}"`},
+		{text: "Hi {{first_name | there}}", wantMsg: "invalid merge field"},
+		{text: "Hi {{}}", wantMsg: "invalid merge field"},
+		{text: "Hi {{ {{first_name}}", wantMsg: `unclosed "{{"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			err := Validate(tt.text)
+			var mergeErr *Error
+			if !errors.As(err, &mergeErr) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"net/http"
+
 	"github.com/labstack/echo/v4"
 
 	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
 )
 
+// HeaderWebhookSecret carries the shared secret of inbound mail webhooks.
+const HeaderWebhookSecret = "X-Webhook-Secret"
+
 func MiddlewareRequestID() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -19,3 +26,24 @@ func MiddlewareRequestID() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// MiddlewareWebhookSecret rejects requests whose X-Webhook-Secret header does not match
+// SECURITY.WEBHOOK_SECRET. Every request is rejected while no secret is configured, so the webhooks are
+// never left open by a missing setting.
+func MiddlewareWebhookSecret() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ac := c.(*ctx.CustomApplicationContext)
+
+			secret := ac.Config.GetSecurityConf().WebhookSecret
+			if secret == "" {
+				ac.AppLoger.Error("MiddlewareWebhookSecret - SECURITY.WEBHOOK_SECRET is not configured")
+			}
+			given := c.Request().Header.Get(HeaderWebhookSecret)
+			if secret == "" || subtle.ConstantTimeCompare([]byte(given), []byte(secret)) != 1 {
+				return ac.CustomResponse(http.StatusText(http.StatusUnauthorized), nil, "", "invalid webhook secret", http.StatusUnauthorized, nil)
+			}
+			return next(c)
+		}
+	}
+}
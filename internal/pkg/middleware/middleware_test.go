@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	mock_config "github.com/rohanchauhan02/sequence-service/files/mocks/config"
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/ctx"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+)
+
+func Test_MiddlewareWebhookSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConf := mock_config.NewMockImmutableConfig(ctrl)
+
+	tests := []struct {
+		name     string
+		secret   string
+		header   string
+		wantCode int
+	}{
+		{
+			name:     "success - matching secret",
+			secret:   "webhook-secret",
+			header:   "webhook-secret",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "error - missing secret",
+			secret:   "webhook-secret",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "error - wrong secret",
+			secret:   "webhook-secret",
+			header:   "webhook-secreT",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "error - no secret configured",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConf.EXPECT().GetSecurityConf().Return(config.Security{WebhookSecret: tt.secret})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/inbound/replies", nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderWebhookSecret, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			c := &ctx.CustomApplicationContext{
+				Context:  echo.New().NewContext(req, rec),
+				AppLoger: logger.NewLogger(),
+				Config:   mockConf,
+			}
+
+			handler := MiddlewareWebhookSecret()(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			if err := handler(c); err != nil {
+				t.Fatalf("MiddlewareWebhookSecret() unexpected error: %v", err)
+			}
+			if rec.Code != tt.wantCode {
+				t.Errorf("MiddlewareWebhookSecret() code = %d, want %d", rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
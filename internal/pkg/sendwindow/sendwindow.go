@@ -0,0 +1,94 @@
+// Package sendwindow computes when an email may go out given a daily time range and the weekdays it
+// applies to, evaluated in the contact's timezone.
+package sendwindow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// The runtime image ships without a zoneinfo database, contact timezones are resolved from the embedded copy.
+	_ "time/tzdata"
+)
+
+// Days are the accepted weekday names, starting with Sunday to match time.Weekday.
+var Days = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Window is a daily send window such as 09:00-17:00 on weekdays.
+type Window struct {
+	start int // minutes after midnight
+	end   int // minutes after midnight, exclusive
+	days  [7]bool
+}
+
+// New parses a window from HH:MM start and end times and weekday names. All days are allowed when
+// days is empty. The end must be after the start; windows crossing midnight are not supported.
+func New(start, end string, days []string) (*Window, error) {
+	w := &Window{}
+
+	var err error
+	if w.start, err = parseClock(start); err != nil {
+		return nil, fmt.Errorf("start %w", err)
+	}
+	if w.end, err = parseClock(end); err != nil {
+		return nil, fmt.Errorf("end %w", err)
+	}
+	if w.end <= w.start {
+		return nil, errors.New("end must be after start")
+	}
+
+	if len(days) == 0 {
+		for i := range w.days {
+			w.days[i] = true
+		}
+		return w, nil
+	}
+	for _, day := range days {
+		weekday := dayIndex(day)
+		if weekday == -1 {
+			return nil, fmt.Errorf("unknown day %q, expected one of %s", day, strings.Join(Days, ", "))
+		}
+		w.days[weekday] = true
+	}
+	return w, nil
+}
+
+// Next returns t when it falls inside the window in loc, or else the next time the window opens.
+func (w *Window) Next(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	for offset := 0; offset <= 7; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		if !w.days[day.Weekday()] {
+			continue
+		}
+
+		// Wall clock times, not durations since midnight, which are off by an hour on DST transition days.
+		open := time.Date(day.Year(), day.Month(), day.Day(), w.start/60, w.start%60, 0, 0, loc)
+		if offset > 0 || local.Before(open) {
+			return open.In(t.Location())
+		}
+		if local.Before(time.Date(day.Year(), day.Month(), day.Day(), w.end/60, w.end%60, 0, 0, loc)) {
+			return t
+		}
+	}
+	// Unreachable: New always allows at least one day.
+	return t
+}
+
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("must be a time of day in HH:MM format, got %q", value)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+func dayIndex(day string) int {
+	for i, name := range Days {
+		if strings.EqualFold(name, day) {
+			return i
+		}
+	}
+	return -1
+}
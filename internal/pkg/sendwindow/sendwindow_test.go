@@ -0,0 +1,100 @@
+package sendwindow
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Next(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+
+	weekdays, err := New("09:00", "17:00", []string{"mon", "tue", "wed", "thu", "fri"})
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	everyDay, err := New("09:00", "17:00", nil)
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		window *Window
+		at     time.Time
+		want   time.Time
+	}{
+		{
+			name:   "inside the window",
+			window: weekdays,
+			at:     time.Date(2025, 10, 8, 10, 30, 0, 0, newYork), // Wednesday
+			want:   time.Date(2025, 10, 8, 10, 30, 0, 0, newYork),
+		},
+		{
+			name:   "before the window opens",
+			window: weekdays,
+			at:     time.Date(2025, 10, 8, 7, 0, 0, 0, newYork),
+			want:   time.Date(2025, 10, 8, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:   "after the window closes",
+			window: weekdays,
+			at:     time.Date(2025, 10, 8, 17, 0, 0, 0, newYork),
+			want:   time.Date(2025, 10, 9, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:   "friday evening moves to monday",
+			window: weekdays,
+			at:     time.Date(2025, 10, 10, 18, 0, 0, 0, newYork),
+			want:   time.Date(2025, 10, 13, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:   "opens at the wall clock time on a DST transition day",
+			window: everyDay,
+			at:     time.Date(2026, 3, 8, 0, 30, 0, 0, newYork), // clocks spring forward at 02:00
+			want:   time.Date(2026, 3, 8, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:   "closes at the wall clock time on a DST transition day",
+			window: everyDay,
+			at:     time.Date(2026, 11, 1, 16, 30, 0, 0, newYork), // clocks fall back at 02:00
+			want:   time.Date(2026, 11, 1, 16, 30, 0, 0, newYork),
+		},
+		{
+			name:   "evaluated in the contact timezone",
+			window: everyDay,
+			at:     time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC), // 08:00 in New York
+			want:   time.Date(2025, 10, 8, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Next(tt.at, newYork); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		days       []string
+	}{
+		{name: "invalid start", start: "9am", end: "17:00"},
+		{name: "end before start", start: "17:00", end: "09:00"},
+		{name: "unknown day", start: "09:00", end: "17:00", days: []string{"monday"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.start, tt.end, tt.days); err == nil {
+				t.Errorf("New() expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Package suppression matches contacts against the suppression list, which blocks an email address or
+// every address of a domain.
+package suppression
+
+import (
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// match matches a suppression s against the address given by the SQL expression email, when it blocks
+// the address or the domain of it. Its arguments are the email suppression type, then the domain one,
+// each followed by the address when email is a placeholder. Enrollment and send time checks both go
+// through it, so they agree on what is suppressed.
+func match(email string) string {
+	return `(s.type = ? AND s.value = LOWER(` + email + `))
+	OR (s.type = ? AND s.value = LOWER(SPLIT_PART(` + email + `, '@', 2)))`
+}
+
+// contactMatch matches a suppression s against the contact c.
+var contactMatch = match("c.email")
+
+// ContactIDs returns the contacts among contactIDs whose email, or the domain of it, is suppressed.
+func ContactIDs(tx *gorm.DB, contactIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var suppressedIDs []uuid.UUID
+	if err := tx.Table("contacts c").
+		Joins("JOIN suppressions s ON "+contactMatch, models.SuppressionTypeEmail, models.SuppressionTypeDomain).
+		Where("c.id IN ?", contactIDs).
+		Distinct().
+		Pluck("c.id", &suppressedIDs).Error; err != nil {
+		return nil, err
+	}
+	return suppressedIDs, nil
+}
+
+// Find returns the suppression of an email address, or of its domain when the address itself is not
+// suppressed.
+func Find(tx *gorm.DB, email string) (*models.Suppression, error) {
+	var found models.Suppression
+	if err := tx.Table("suppressions AS s").
+		Where(match("?::text"), models.SuppressionTypeEmail, email, models.SuppressionTypeDomain, email).
+		Order("s.type").
+		Take(&found).Error; err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// ExcludeContacts leaves out the rows of query whose contact, joined as c, is suppressed.
+func ExcludeContacts(query *gorm.DB) *gorm.DB {
+	return query.Where("NOT EXISTS (SELECT 1 FROM suppressions s WHERE "+contactMatch+")",
+		models.SuppressionTypeEmail, models.SuppressionTypeDomain)
+}
+
+// Without returns ids without the ones in exclude, keeping their order.
+func Without(ids []uuid.UUID, exclude []uuid.UUID) []uuid.UUID {
+	if len(exclude) == 0 {
+		return ids
+	}
+	excluded := make(map[uuid.UUID]struct{}, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = struct{}{}
+	}
+	kept := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := excluded[id]; !ok {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
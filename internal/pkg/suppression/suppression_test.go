@@ -0,0 +1,86 @@
+package suppression
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_Find(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db: %v", err)
+	}
+
+	email := "Jane@Example.com"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    models.SuppressionType
+		wantErr error
+	}{
+		{
+			name: "success - domain suppressed",
+			rows: sqlmock.NewRows([]string{"id", "type", "value"}).AddRow(uuid.New(), models.SuppressionTypeDomain, "example.com"),
+			want: models.SuppressionTypeDomain,
+		},
+		{
+			name:    "error - not suppressed",
+			rows:    sqlmock.NewRows([]string{"id", "type", "value"}),
+			wantErr: gorm.ErrRecordNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectQuery(`SELECT \* FROM suppressions AS s WHERE .*SPLIT_PART\(\$4::text, '@', 2\).* ORDER BY s\.type LIMIT`).
+				WithArgs(models.SuppressionTypeEmail, email, models.SuppressionTypeDomain, email, 1).
+				WillReturnRows(tt.rows)
+
+			got, err := Find(gormDB, email)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Find() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Type != tt.want {
+				t.Errorf("Find() type = %s, want %s", got.Type, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_Without(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		ids     []uuid.UUID
+		exclude []uuid.UUID
+		want    []uuid.UUID
+	}{
+		{name: "success - nothing excluded", ids: []uuid.UUID{a, b, c}, want: []uuid.UUID{a, b, c}},
+		{name: "success - keeps order", ids: []uuid.UUID{a, b, c}, exclude: []uuid.UUID{b}, want: []uuid.UUID{a, c}},
+		{name: "success - ignores unknown ids", ids: []uuid.UUID{a}, exclude: []uuid.UUID{b, c}, want: []uuid.UUID{a}},
+		{name: "success - everything excluded", ids: []uuid.UUID{a, b}, exclude: []uuid.UUID{b, a}, want: []uuid.UUID{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Without(tt.ids, tt.exclude); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Without() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package tracking
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+)
+
+const (
+	OpenPath        = "/api/v1/track/open/"
+	ClickPath       = "/api/v1/track/click/"
+	UnsubscribePath = "/api/v1/unsubscribe/"
+
+	// unsubscribeAction tells unsubscribe tokens apart from open tokens, which carry the same email.
+	unsubscribeAction = "unsubscribe"
+)
+
+var (
+	linkPattern = regexp.MustCompile(`(?i)(<a\b[^>]*?\bhref\s*=\s*)(?:"(https?://[^"]*)"|'(https?://[^']*)')`)
+	bodyClose   = regexp.MustCompile(`(?i)</body\s*>`)
+)
+
+// OpenToken identifies the email an open is recorded for.
+type OpenToken struct {
+	EmailQueueID uuid.UUID `json:"e"`
+}
+
+// ClickToken identifies the email a click is recorded for and the original link to redirect to.
+type ClickToken struct {
+	EmailQueueID uuid.UUID `json:"e"`
+	URL          string    `json:"u"`
+}
+
+// UnsubscribeToken identifies the email, and through it the recipient, an unsubscribe link was sent with.
+type UnsubscribeToken struct {
+	EmailQueueID uuid.UUID `json:"e"`
+	Action       string    `json:"a"`
+}
+
+// Tracker instruments outgoing HTML with signed open and click tracking URLs.
+type Tracker interface {
+	Instrument(emailQueueID uuid.UUID, content string, openTracking, clickTracking bool) (string, error)
+	ParseOpenToken(token string) (*OpenToken, error)
+	ParseClickToken(token string) (*ClickToken, error)
+	UnsubscribeURL(emailQueueID uuid.UUID) (string, error)
+	ParseUnsubscribeToken(token string) (*UnsubscribeToken, error)
+}
+
+type tracker struct {
+	baseURL string
+	signer  crypto.Signer
+}
+
+// NewTracker returns a tracker whose URLs point to the tracking endpoints served at baseURL.
+func NewTracker(baseURL string, signer crypto.Signer) Tracker {
+	return &tracker{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		signer:  signer,
+	}
+}
+
+// Instrument rewrites http(s) links to the click endpoint when clickTracking is set and injects the
+// open pixel when openTracking is set. Links that already point to this service are left untouched.
+func (t *tracker) Instrument(emailQueueID uuid.UUID, content string, openTracking, clickTracking bool) (string, error) {
+	var err error
+	if clickTracking {
+		content = linkPattern.ReplaceAllStringFunc(content, func(match string) string {
+			groups := linkPattern.FindStringSubmatch(match)
+			link := groups[2] + groups[3]
+			if err != nil || strings.HasPrefix(link, t.baseURL) {
+				return match
+			}
+
+			token, signErr := t.signer.Sign(&ClickToken{EmailQueueID: emailQueueID, URL: html.UnescapeString(link)})
+			if signErr != nil {
+				err = signErr
+				return match
+			}
+			return fmt.Sprintf(`%s"%s%s%s"`, groups[1], t.baseURL, ClickPath, token)
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if openTracking {
+		token, err := t.signer.Sign(&OpenToken{EmailQueueID: emailQueueID})
+		if err != nil {
+			return "", err
+		}
+
+		pixel := fmt.Sprintf(`<img src="%s%s%s" width="1" height="1" alt="" style="display:none" />`, t.baseURL, OpenPath, token)
+		if loc := lastMatch(bodyClose, content); loc != nil {
+			content = content[:loc[0]] + pixel + content[loc[0]:]
+		} else {
+			content += pixel
+		}
+	}
+
+	return content, nil
+}
+
+func (t *tracker) ParseOpenToken(token string) (*OpenToken, error) {
+	openToken := new(OpenToken)
+	if err := t.signer.Verify(token, openToken); err != nil {
+		return nil, err
+	}
+	return openToken, nil
+}
+
+func (t *tracker) ParseClickToken(token string) (*ClickToken, error) {
+	clickToken := new(ClickToken)
+	if err := t.signer.Verify(token, clickToken); err != nil {
+		return nil, err
+	}
+	return clickToken, nil
+}
+
+// UnsubscribeURL returns the signed one-click unsubscribe URL of the recipient of an email.
+func (t *tracker) UnsubscribeURL(emailQueueID uuid.UUID) (string, error) {
+	token, err := t.signer.Sign(&UnsubscribeToken{EmailQueueID: emailQueueID, Action: unsubscribeAction})
+	if err != nil {
+		return "", err
+	}
+	return t.baseURL + UnsubscribePath + token, nil
+}
+
+func (t *tracker) ParseUnsubscribeToken(token string) (*UnsubscribeToken, error) {
+	unsubscribeToken := new(UnsubscribeToken)
+	if err := t.signer.Verify(token, unsubscribeToken); err != nil {
+		return nil, err
+	}
+	if unsubscribeToken.Action != unsubscribeAction {
+		return nil, crypto.ErrInvalidToken
+	}
+	return unsubscribeToken, nil
+}
+
+// AddUnsubscribeLink appends an unsubscribe footer with the given URL to the content, before </body>
+// when the content is a full HTML document.
+func AddUnsubscribeLink(content, unsubscribeURL string) string {
+	footer := fmt.Sprintf(`<p style="font-size:12px;color:#888888">If you no longer wish to receive these emails, <a href="%s">unsubscribe</a>.</p>`, html.EscapeString(unsubscribeURL))
+	if loc := lastMatch(bodyClose, content); loc != nil {
+		return content[:loc[0]] + footer + content[loc[0]:]
+	}
+	return content + footer
+}
+
+func lastMatch(re *regexp.Regexp, s string) []int {
+	matches := re.FindAllStringIndex(s, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	return matches[len(matches)-1]
+}
@@ -0,0 +1,143 @@
+package tracking
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/crypto"
+)
+
+const testKey = "c2VxdWVuY2Utc2VydmljZS1zaWduaW5nLWtleS0zMmI="
+
+func newTestTracker(t *testing.T) Tracker {
+	signer, err := crypto.NewHMACSigner(testKey)
+	if err != nil {
+		t.Fatalf("NewHMACSigner() unexpected error: %v", err)
+	}
+	return NewTracker("https://track.example.com/", signer)
+}
+
+func Test_Instrument(t *testing.T) {
+	tr := newTestTracker(t)
+	emailQueueID := uuid.New()
+	content := `<html><body><p>Hi <a href="https://example.com/a?x=1&amp;y=2">there</a> and <a class='btn' href='http://example.com/b'>here</a>, <a href="mailto:me@example.com">mail</a> <a href="https://track.example.com/api/v1/unsubscribe/x">unsubscribe</a></p></body></html>`
+
+	tests := []struct {
+		name          string
+		openTracking  bool
+		clickTracking bool
+		wantClicks    int
+		wantPixel     bool
+	}{
+		{name: "tracking disabled leaves content untouched"},
+		{name: "open tracking injects the pixel before </body>", openTracking: true, wantPixel: true},
+		{name: "click tracking rewrites http links only", clickTracking: true, wantClicks: 2},
+		{name: "both flags", openTracking: true, clickTracking: true, wantClicks: 2, wantPixel: true},
+	}
+
+	clickURL := regexp.MustCompile(`https://track\.example\.com/api/v1/track/click/([^"]+)`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tr.Instrument(emailQueueID, content, tt.openTracking, tt.clickTracking)
+			if err != nil {
+				t.Fatalf("Instrument() unexpected error: %v", err)
+			}
+
+			if !tt.openTracking && !tt.clickTracking && got != content {
+				t.Errorf("Instrument() changed content without tracking: %s", got)
+			}
+
+			hasPixel := strings.Contains(got, `/api/v1/track/open/`)
+			if hasPixel != tt.wantPixel {
+				t.Errorf("Instrument() pixel present = %v, want %v", hasPixel, tt.wantPixel)
+			}
+			if tt.wantPixel && !strings.HasSuffix(got, `style="display:none" /></body></html>`) {
+				t.Errorf("Instrument() pixel not placed before </body>: %s", got)
+			}
+
+			clicks := clickURL.FindAllStringSubmatch(got, -1)
+			if len(clicks) != tt.wantClicks {
+				t.Fatalf("Instrument() rewrote %d links, want %d: %s", len(clicks), tt.wantClicks, got)
+			}
+			if tt.wantClicks > 0 {
+				token, err := tr.ParseClickToken(clicks[0][1])
+				if err != nil {
+					t.Fatalf("ParseClickToken() unexpected error: %v", err)
+				}
+				if token.EmailQueueID != emailQueueID || token.URL != "https://example.com/a?x=1&y=2" {
+					t.Errorf("ParseClickToken() = %+v", token)
+				}
+				if !strings.Contains(got, `mailto:me@example.com`) || !strings.Contains(got, `/api/v1/unsubscribe/x`) {
+					t.Errorf("Instrument() rewrote links it should skip: %s", got)
+				}
+			}
+		})
+	}
+}
+
+func Test_ParseOpenToken(t *testing.T) {
+	tr := newTestTracker(t)
+	emailQueueID := uuid.New()
+
+	got, err := tr.Instrument(emailQueueID, "plain text", true, false)
+	if err != nil {
+		t.Fatalf("Instrument() unexpected error: %v", err)
+	}
+
+	token := regexp.MustCompile(`/api/v1/track/open/([^"]+)`).FindStringSubmatch(got)[1]
+	openToken, err := tr.ParseOpenToken(token)
+	if err != nil || openToken.EmailQueueID != emailQueueID {
+		t.Errorf("ParseOpenToken() = %+v, %v", openToken, err)
+	}
+
+	if _, err := tr.ParseOpenToken(token + "x"); err == nil {
+		t.Error("ParseOpenToken() expected error for tampered token")
+	}
+}
+
+func Test_ParseUnsubscribeToken(t *testing.T) {
+	tr := newTestTracker(t)
+	emailQueueID := uuid.New()
+
+	unsubscribeURL, err := tr.UnsubscribeURL(emailQueueID)
+	if err != nil {
+		t.Fatalf("UnsubscribeURL() unexpected error: %v", err)
+	}
+	token, found := strings.CutPrefix(unsubscribeURL, "https://track.example.com/api/v1/unsubscribe/")
+	if !found {
+		t.Fatalf("UnsubscribeURL() = %q, want the unsubscribe endpoint", unsubscribeURL)
+	}
+
+	unsubscribeToken, err := tr.ParseUnsubscribeToken(token)
+	if err != nil || unsubscribeToken.EmailQueueID != emailQueueID {
+		t.Errorf("ParseUnsubscribeToken() = %+v, %v", unsubscribeToken, err)
+	}
+
+	// Open pixels carry the same email, they must not unsubscribe the recipient.
+	tracked, err := tr.Instrument(emailQueueID, "plain text", true, false)
+	if err != nil {
+		t.Fatalf("Instrument() unexpected error: %v", err)
+	}
+	openToken := regexp.MustCompile(`/api/v1/track/open/([^"]+)`).FindStringSubmatch(tracked)[1]
+	if _, err := tr.ParseUnsubscribeToken(openToken); err == nil {
+		t.Error("ParseUnsubscribeToken() expected error for an open token")
+	}
+}
+
+func Test_AddUnsubscribeLink(t *testing.T) {
+	url := "https://track.example.com/api/v1/unsubscribe/abc"
+
+	got := AddUnsubscribeLink("<html><body><p>Hi</p></body></html>", url)
+	if !strings.HasPrefix(got, "<html><body><p>Hi</p><p") || !strings.HasSuffix(got, "</p></body></html>") {
+		t.Errorf("AddUnsubscribeLink() footer not placed before </body>: %s", got)
+	}
+	if !strings.Contains(got, `href="`+url+`"`) {
+		t.Errorf("AddUnsubscribeLink() missing link: %s", got)
+	}
+
+	if got := AddUnsubscribeLink("<p>Hi</p>", url); !strings.HasPrefix(got, "<p>Hi</p><p") {
+		t.Errorf("AddUnsubscribeLink() footer not appended: %s", got)
+	}
+}
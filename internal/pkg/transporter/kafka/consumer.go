@@ -0,0 +1,123 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+)
+
+// MessageHandler processes a single message. The message offset is committed only when it returns nil.
+type MessageHandler func(ctx context.Context, msg *sarama.ConsumerMessage) error
+
+type KafkaConsumer interface {
+	Consume(ctx context.Context, topics []string, handler MessageHandler) error
+	Close() error
+}
+
+type kafkaConsumer struct {
+	group   sarama.ConsumerGroup
+	groupID string
+	brokers []string
+}
+
+func NewKafkaConsumer(conf config.ImmutableConfig) (KafkaConsumer, error) {
+	kConf := conf.GetKafkaConf()
+	brokers := strings.Split(kConf.Broker, ",")
+
+	cfg := sarama.NewConfig()
+	cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	cfg.Consumer.Offsets.AutoCommit.Enable = false
+	cfg.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{sarama.NewBalanceStrategyRoundRobin()}
+	cfg.Version = sarama.V2_8_0_0
+
+	// Retry connecting to Kafka
+	var group sarama.ConsumerGroup
+	var err error
+	for i := 0; i < 5; i++ {
+		group, err = sarama.NewConsumerGroup(brokers, kConf.ConsumerGroup, cfg)
+		if err == nil {
+			break
+		}
+		log.Warnf("Kafka consumer group not ready, retrying in 5s... (%v)", err)
+		time.Sleep(5 * time.Second)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka consumer group after retries: %w", err)
+	}
+
+	log.Infof("Kafka consumer group %s connected to %v", kConf.ConsumerGroup, brokers)
+
+	return &kafkaConsumer{
+		group:   group,
+		groupID: kConf.ConsumerGroup,
+		brokers: brokers,
+	}, nil
+}
+
+// Consume joins the consumer group and blocks until ctx is cancelled. When the handler fails the
+// session is restarted so the message is redelivered from the last committed offset.
+func (c *kafkaConsumer) Consume(ctx context.Context, topics []string, handler MessageHandler) error {
+	h := &groupHandler{handler: handler}
+	for {
+		if err := c.group.Consume(ctx, topics, h); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return nil
+			}
+			log.Errorf("Kafka consumer group %s session ended: %v", c.groupID, err)
+			time.Sleep(time.Second)
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (c *kafkaConsumer) Close() error {
+	if err := c.group.Close(); err != nil {
+		log.Errorf("Failed to close Kafka consumer group: %v", err)
+		return fmt.Errorf("failed to close Kafka consumer group: %w", err)
+	}
+
+	log.Infof("Kafka consumer group %s disconnected from %v", c.groupID, c.brokers)
+	return nil
+}
+
+type groupHandler struct {
+	handler MessageHandler
+}
+
+func (h *groupHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *groupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			if err := h.handler(session.Context(), msg); err != nil {
+				log.Errorf("Failed to handle message from %s [partition=%d offset=%d]: %v", msg.Topic, msg.Partition, msg.Offset, err)
+				return err
+			}
+
+			session.MarkMessage(msg, "")
+			session.Commit()
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+)
+
+const (
+	TransportLog  = "log"
+	TransportSMTP = "smtp"
+
+	// defaultMessageIDDomain is used for Message-IDs of emails whose sender has no parsable address.
+	defaultMessageIDDomain = "sequence-service.local"
+)
+
+var (
+	log = logger.NewLogger("MAIL")
+
+	messageIDPattern = regexp.MustCompile(`<[^<>\s]+>`)
+)
+
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	HTML    string
+	Headers map[string]string
+	// Server is the SMTP server of the mailbox the message is sent from, nil when it has no mailbox.
+	Server *Server
+}
+
+// Server is the SMTP server and decrypted credentials of a mailbox.
+type Server struct {
+	MailboxID uuid.UUID
+	Host      string
+	Port      int
+	Username  string
+	Password  string
+}
+
+// PermanentError is a send failure that fails the same way when retried, e.g. a 5xx SMTP reply or a
+// mailbox without SMTP server.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent marks err as a permanent send failure.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether a send failure must not be retried.
+func IsPermanent(err error) bool {
+	var permanentErr *PermanentError
+	return errors.As(err, &permanentErr)
+}
+
+// Sender delivers a rendered message through a mail transport.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewSender returns the transport selected by MAIL.TRANSPORT. The smtp transport lives in its own
+// package, which depends on this one, and is created by smtp.NewSender.
+func NewSender(conf config.ImmutableConfig) (Sender, error) {
+	transport := conf.GetMailConf().Transport
+	switch transport {
+	case "", TransportLog:
+		return &logSender{}, nil
+	default:
+		return nil, fmt.Errorf("unknown mail transport: %s", transport)
+	}
+}
+
+// logSender only logs messages. It is used for local development where no mail server is available.
+type logSender struct{}
+
+func (s *logSender) Send(ctx context.Context, msg *Message) error {
+	log.Infof("Sending email from %q to %q with subject %q", msg.From, msg.To, msg.Subject)
+	return nil
+}
+
+// NewMessageID returns the Message-ID of an email. It is derived from the email_queues ID and the
+// domain of the sender, so retries of an email are sent with the same Message-ID.
+func NewMessageID(emailQueueID uuid.UUID, from string) string {
+	domain := defaultMessageIDDomain
+	if addr, err := netmail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(addr.Address, "@"); at >= 0 && at < len(addr.Address)-1 {
+			domain = strings.ToLower(addr.Address[at+1:])
+		}
+	}
+	return fmt.Sprintf("<%s@%s>", emailQueueID, domain)
+}
+
+// ParseMessageIDs returns the Message-IDs listed in In-Reply-To or References header values, most
+// recent first as replies list the email they answer last in References.
+func ParseMessageIDs(headers ...string) []string {
+	var messageIDs []string
+	seen := make(map[string]bool)
+	for _, header := range headers {
+		matches := messageIDPattern.FindAllString(header, -1)
+		for i := len(matches) - 1; i >= 0; i-- {
+			if !seen[matches[i]] {
+				seen[matches[i]] = true
+				messageIDs = append(messageIDs, matches[i])
+			}
+		}
+	}
+	return messageIDs
+}
@@ -0,0 +1,51 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func Test_NewMessageID(t *testing.T) {
+	emailQueueID := uuid.MustParse("5f0c7a3e-8d52-4a51-9a6b-2f0b4c1d9e10")
+
+	tests := []struct {
+		from string
+		want string
+	}{
+		{from: "sales@Acme.example", want: "<5f0c7a3e-8d52-4a51-9a6b-2f0b4c1d9e10@acme.example>"},
+		{from: "Ada <ada@acme.example>", want: "<5f0c7a3e-8d52-4a51-9a6b-2f0b4c1d9e10@acme.example>"},
+		{from: "", want: "<5f0c7a3e-8d52-4a51-9a6b-2f0b4c1d9e10@sequence-service.local>"},
+	}
+
+	for _, tt := range tests {
+		if got := NewMessageID(emailQueueID, tt.from); got != tt.want {
+			t.Errorf("NewMessageID(%q) = %q, want %q", tt.from, got, tt.want)
+		}
+	}
+}
+
+func Test_ParseMessageIDs(t *testing.T) {
+	got := ParseMessageIDs("<c@acme.example>", "<a@acme.example>\r\n <b@acme.example> <c@acme.example>", "")
+	want := []string{"<c@acme.example>", "<b@acme.example>", "<a@acme.example>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMessageIDs() = %v, want %v", got, want)
+	}
+
+	if got := ParseMessageIDs("no ids here"); len(got) != 0 {
+		t.Errorf("ParseMessageIDs() = %v, want none", got)
+	}
+}
+
+func Test_IsPermanent(t *testing.T) {
+	err := fmt.Errorf("send: %w", Permanent(errors.New("550 rejected")))
+	if !IsPermanent(err) || err.Error() != "send: 550 rejected" {
+		t.Errorf("IsPermanent(%v) = false, want true", err)
+	}
+	if IsPermanent(errors.New("connection reset")) || Permanent(nil) != nil {
+		t.Error("IsPermanent() = true for a retryable error")
+	}
+}
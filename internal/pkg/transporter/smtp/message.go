@@ -0,0 +1,117 @@
+package smtp
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/mail"
+)
+
+var (
+	anchorPattern     = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+	paragraphPattern  = regexp.MustCompile(`(?i)</(?:p|h[1-6]|blockquote|table)>`)
+	lineBreakPattern  = regexp.MustCompile(`(?i)<br\s*/?>|</(?:div|li|tr)>`)
+	invisiblePattern  = regexp.MustCompile(`(?is)<(head|style|script)\b.*?</(?:head|style|script)>`)
+	tagPattern        = regexp.MustCompile(`(?s)<[^>]*>`)
+	spacePattern      = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// buildMessage renders msg as a multipart/alternative MIME message with a plain text part derived from
+// the HTML part. Both parts are quoted-printable encoded so long lines stay within SMTP line limits.
+func buildMessage(msg *mail.Message, from, to *netmail.Address, now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	header := textproto.MIMEHeader{}
+	for name, value := range msg.Headers {
+		if strings.ContainsAny(name+value, "\r\n") {
+			return nil, fmt.Errorf("invalid header %q", name)
+		}
+		header.Set(name, value)
+	}
+	header.Set("From", from.String())
+	header.Set("To", to.String())
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	if header.Get("Date") == "" {
+		header.Set("Date", now.Format(time.RFC1123Z))
+	}
+	header.Set("MIME-Version", "1.0")
+	header.Set("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+
+	if err := writePart(parts, "text/plain", htmlToText(msg.HTML)); err != nil {
+		return nil, err
+	}
+	if err := writePart(parts, "text/html", msg.HTML); err != nil {
+		return nil, err
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, header.Get(name))
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+func writePart(parts *multipart.Writer, contentType, content string) error {
+	w, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// htmlToText returns a plain text alternative of an HTML body. Paragraphs are separated by blank lines,
+// other block elements by line breaks, and links keep their URL after the link text so tracked links
+// still work in text-only clients.
+func htmlToText(body string) string {
+	text := invisiblePattern.ReplaceAllString(body, "")
+	text = anchorPattern.ReplaceAllStringFunc(text, func(anchor string) string {
+		groups := anchorPattern.FindStringSubmatch(anchor)
+		href := html.UnescapeString(groups[1])
+		label := strings.TrimSpace(tagPattern.ReplaceAllString(groups[2], ""))
+		if label == "" || html.UnescapeString(label) == href {
+			return href
+		}
+		return label + " (" + href + ")"
+	})
+	text = paragraphPattern.ReplaceAllString(text, "\n\n")
+	text = lineBreakPattern.ReplaceAllString(text, "\n")
+	text = tagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacePattern.ReplaceAllString(line, " "))
+	}
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,386 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	netsmtp "net/smtp"
+	"net/textproto"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rohanchauhan02/sequence-service/internal/config"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/logger"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/mail"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultIdleTimeout  = time.Minute
+	defaultMaxIdleConns = 2
+
+	// implicitTLSPort is the submissions port (RFC 8314), where TLS starts before the SMTP greeting.
+	implicitTLSPort = 465
+)
+
+var log = logger.NewLogger("SMTP")
+
+// Sender sends emails through the SMTP server of their mailbox. Close closes the pooled connections.
+type Sender interface {
+	mail.Sender
+	Close() error
+}
+
+type options struct {
+	timeout       time.Duration
+	idleTimeout   time.Duration
+	maxIdleConns  int
+	localName     string
+	allowInsecure bool
+	// tlsConfig is cloned for every connection, nil uses the system roots.
+	tlsConfig *tls.Config
+}
+
+type sender struct {
+	opts options
+
+	mu     sync.Mutex
+	idle   map[poolKey][]*conn
+	closed bool
+}
+
+// poolKey identifies the connections of a mailbox. Connections are not shared when the server or the
+// username of a mailbox changes.
+type poolKey struct {
+	mailboxID string
+	addr      string
+	username  string
+}
+
+type conn struct {
+	netConn   net.Conn
+	client    *netsmtp.Client
+	idleSince time.Time
+}
+
+// NewSender returns the smtp transport configured by MAIL.SMTP.
+func NewSender(conf config.ImmutableConfig) Sender {
+	smtpConf := conf.GetMailConf().SMTP
+	return newSender(options{
+		timeout:       time.Duration(smtpConf.TimeoutSeconds) * time.Second,
+		idleTimeout:   time.Duration(smtpConf.IdleTimeoutSeconds) * time.Second,
+		maxIdleConns:  smtpConf.MaxIdleConns,
+		localName:     smtpConf.LocalName,
+		allowInsecure: smtpConf.AllowInsecure,
+	})
+}
+
+func newSender(opts options) *sender {
+	if opts.timeout <= 0 {
+		opts.timeout = defaultTimeout
+	}
+	if opts.idleTimeout <= 0 {
+		opts.idleTimeout = defaultIdleTimeout
+	}
+	if opts.maxIdleConns <= 0 {
+		opts.maxIdleConns = defaultMaxIdleConns
+	}
+	return &sender{
+		opts: opts,
+		idle: make(map[poolKey][]*conn),
+	}
+}
+
+// Send delivers msg over a pooled connection of its mailbox, dialing a new one when none is idle.
+// 5xx replies are returned as mail.PermanentError; 4xx replies, timeouts and network errors are
+// retryable. The *textproto.Error of a rejection is kept in the chain for bounce classification.
+func (s *sender) Send(ctx context.Context, msg *mail.Message) error {
+	if msg.Server == nil || msg.Server.Host == "" || msg.Server.Port == 0 {
+		return mail.Permanent(errors.New("smtp: email has no mailbox SMTP server"))
+	}
+
+	from, err := netmail.ParseAddress(msg.From)
+	if err != nil {
+		return mail.Permanent(fmt.Errorf("smtp: invalid sender %q: %w", msg.From, err))
+	}
+	to, err := netmail.ParseAddress(msg.To)
+	if err != nil {
+		return mail.Permanent(fmt.Errorf("smtp: invalid recipient %q: %w", msg.To, err))
+	}
+
+	data, err := buildMessage(msg, from, to, time.Now())
+	if err != nil {
+		return mail.Permanent(fmt.Errorf("smtp: %w", err))
+	}
+
+	key := poolKey{
+		mailboxID: msg.Server.MailboxID.String(),
+		addr:      net.JoinHostPort(msg.Server.Host, strconv.Itoa(msg.Server.Port)),
+		username:  msg.Server.Username,
+	}
+	c, err := s.get(ctx, key, msg.Server)
+	if err != nil {
+		return err
+	}
+
+	// The deadline bounds the whole transaction; cancelling ctx aborts it by expiring the deadline.
+	c.netConn.SetDeadline(s.deadline(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		c.netConn.SetDeadline(time.Now())
+	})
+	err = send(c.client, from.Address, to.Address, data)
+	aborted := !stop()
+
+	if err != nil {
+		var protoErr *textproto.Error
+		if !aborted && errors.As(err, &protoErr) && c.client.Reset() == nil {
+			s.put(key, c)
+		} else {
+			c.close()
+		}
+		return classify(err)
+	}
+
+	s.put(key, c)
+	return nil
+}
+
+// Close closes the idle connections. Connections in use are closed when their send returns.
+func (s *sender) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	idle := s.idle
+	s.idle = make(map[poolKey][]*conn)
+	s.mu.Unlock()
+
+	for _, conns := range idle {
+		for _, c := range conns {
+			c.quit()
+		}
+	}
+	return nil
+}
+
+// get returns an idle connection of key that still answers RSET, or dials a new one. Connections idle
+// for longer than idleTimeout are closed first, across all mailboxes.
+func (s *sender) get(ctx context.Context, key poolKey, server *mail.Server) (*conn, error) {
+	for {
+		c := s.take(key)
+		if c == nil {
+			return s.dial(ctx, key.addr, server)
+		}
+
+		c.netConn.SetDeadline(s.deadline(ctx))
+		if err := c.client.Reset(); err != nil {
+			log.Warnf("Dropping stale connection to %s: %v", key.addr, err)
+			c.close()
+			continue
+		}
+		return c, nil
+	}
+}
+
+func (s *sender) take(key poolKey) *conn {
+	s.mu.Lock()
+	var expired []*conn
+	now := time.Now()
+	for k, conns := range s.idle {
+		fresh := conns[:0]
+		for _, c := range conns {
+			if now.Sub(c.idleSince) > s.opts.idleTimeout {
+				expired = append(expired, c)
+			} else {
+				fresh = append(fresh, c)
+			}
+		}
+		if len(fresh) == 0 {
+			delete(s.idle, k)
+		} else {
+			s.idle[k] = fresh
+		}
+	}
+
+	var c *conn
+	if conns := s.idle[key]; len(conns) > 0 {
+		c = conns[len(conns)-1]
+		if len(conns) == 1 {
+			delete(s.idle, key)
+		} else {
+			s.idle[key] = conns[:len(conns)-1]
+		}
+	}
+	s.mu.Unlock()
+
+	for _, e := range expired {
+		e.quit()
+	}
+	return c
+}
+
+// put returns a connection to the pool, or closes it when the pool of its mailbox is full.
+func (s *sender) put(key poolKey, c *conn) {
+	s.mu.Lock()
+	if s.closed || len(s.idle[key]) >= s.opts.maxIdleConns {
+		s.mu.Unlock()
+		c.quit()
+		return
+	}
+	c.idleSince = time.Now()
+	c.netConn.SetDeadline(time.Time{})
+	s.idle[key] = append(s.idle[key], c)
+	s.mu.Unlock()
+}
+
+// dial connects to the server of a mailbox, upgrades the connection with STARTTLS, unless the port
+// uses implicit TLS, and authenticates when the mailbox has a username.
+func (s *sender) dial(ctx context.Context, addr string, server *mail.Server) (*conn, error) {
+	tlsConfig := s.tlsConfig(server.Host)
+	dialer := &net.Dialer{Timeout: s.opts.timeout}
+
+	var netConn net.Conn
+	var err error
+	if server.Port == implicitTLSPort {
+		netConn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		netConn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("smtp dial %s: %w", addr, err)
+	}
+	c := &conn{netConn: netConn}
+
+	netConn.SetDeadline(s.deadline(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		netConn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := s.handshake(c, server, tlsConfig); err != nil {
+		c.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *sender) handshake(c *conn, server *mail.Server, tlsConfig *tls.Config) error {
+	client, err := netsmtp.NewClient(c.netConn, server.Host)
+	if err != nil {
+		return sessionError("greeting", err)
+	}
+	c.client = client
+
+	if s.opts.localName != "" {
+		if err := client.Hello(s.opts.localName); err != nil {
+			return sessionError("ehlo", err)
+		}
+	}
+
+	if server.Port != implicitTLSPort {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return sessionError("starttls", err)
+			}
+		} else if !s.opts.allowInsecure {
+			return mail.Permanent(fmt.Errorf("smtp: %s does not support STARTTLS", server.Host))
+		}
+	}
+
+	if server.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return mail.Permanent(fmt.Errorf("smtp: %s does not support AUTH", server.Host))
+		}
+		if err := client.Auth(netsmtp.PlainAuth("", server.Username, server.Password, server.Host)); err != nil {
+			return sessionError("auth", err)
+		}
+	}
+	return nil
+}
+
+func (s *sender) tlsConfig(host string) *tls.Config {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if s.opts.tlsConfig != nil {
+		tlsConfig = s.opts.tlsConfig.Clone()
+	}
+	tlsConfig.ServerName = host
+	return tlsConfig
+}
+
+// deadline returns the timeout of a connection step, shortened to the deadline of ctx.
+func (s *sender) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(s.opts.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+// send runs one mail transaction on an established connection. Rejections of the recipient are marked
+// with bounce.Recipient, since only those can be hard bounces.
+func send(client *netsmtp.Client, from, to string, data []byte) error {
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("rcpt to: %w", bounce.Recipient(err))
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return fmt.Errorf("data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	return nil
+}
+
+// classify marks errors of a mail transaction that fail again when retried as permanent: 5xx replies
+// such as rejected recipients. 4xx replies, timeouts and network errors are retryable.
+func classify(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return mail.Permanent(fmt.Errorf("smtp %w", err))
+	}
+	return fmt.Errorf("smtp %w", err)
+}
+
+// sessionError reports a failure to set up a session, e.g. a 535 failed authentication. The reply is
+// only kept as text so it is not classified as the bounce of a recipient, and the text avoids the
+// "step: code" form bounces are also parsed from.
+func sessionError(step string, err error) error {
+	var protoErr *textproto.Error
+	if !errors.As(err, &protoErr) {
+		return fmt.Errorf("smtp %s: %w", step, err)
+	}
+
+	err = fmt.Errorf("smtp %s rejected with %d %s", step, protoErr.Code, protoErr.Msg)
+	if protoErr.Code >= 500 {
+		return mail.Permanent(err)
+	}
+	return err
+}
+
+// quit ends the session politely before closing, for connections that are in a clean state.
+func (c *conn) quit() {
+	c.netConn.SetDeadline(time.Now().Add(time.Second))
+	if err := c.client.Quit(); err != nil {
+		c.close()
+	}
+}
+
+func (c *conn) close() {
+	if c.client != nil {
+		c.client.Close()
+		return
+	}
+	c.netConn.Close()
+}
@@ -0,0 +1,430 @@
+package smtp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	netmail "net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/transporter/mail"
+)
+
+// fakeServer is an in-process SMTP server supporting STARTTLS, AUTH PLAIN and a single recipient per
+// transaction. Recipients listed in rejects are refused with the given reply.
+type fakeServer struct {
+	t         *testing.T
+	listener  net.Listener
+	tlsConfig *tls.Config
+	username  string
+	password  string
+	noTLS     bool
+	// silent accepts connections without ever sending the greeting.
+	silent bool
+	// dropAfterData closes the connection after every accepted message.
+	dropAfterData bool
+	rejects       map[string]string
+	// dataRejection, when set, is the reply to every message instead of accepting it.
+	dataRejection string
+
+	conns atomic.Int32
+	mu    sync.Mutex
+	data  []string
+}
+
+func newFakeServer(t *testing.T, configure func(*fakeServer)) (*fakeServer, *tls.Config) {
+	serverTLS, clientTLS := testTLSConfigs(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	s := &fakeServer{
+		t:         t,
+		listener:  listener,
+		tlsConfig: serverTLS,
+		username:  "sales@acme.example",
+		password:  "secret",
+		rejects:   map[string]string{},
+	}
+	if configure != nil {
+		configure(s)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.conns.Add(1)
+			go s.serve(conn)
+		}
+	}()
+	return s, clientTLS
+}
+
+func (s *fakeServer) server() *mail.Server {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return &mail.Server{
+		MailboxID: uuid.New(),
+		Host:      host,
+		Port:      portNumber,
+		Username:  s.username,
+		Password:  s.password,
+	}
+}
+
+func (s *fakeServer) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.data...)
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	if s.silent {
+		io.Copy(io.Discard, conn)
+		return
+	}
+
+	text := textproto.NewConn(conn)
+	secure := false
+	reply := func(lines ...string) {
+		for _, line := range lines {
+			text.PrintfLine("%s", line)
+		}
+	}
+
+	reply("220 fake.example ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			lines := []string{"250-fake.example"}
+			if !secure && !s.noTLS {
+				lines = append(lines, "250-STARTTLS")
+			}
+			if secure || s.noTLS {
+				lines = append(lines, "250-AUTH PLAIN")
+			}
+			reply(append(lines, "250 8BITMIME")...)
+		case "STARTTLS":
+			reply("220 2.0.0 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, text, secure = tlsConn, textproto.NewConn(tlsConn), true
+		case "AUTH":
+			_, encoded, _ := strings.Cut(arg, " ")
+			credentials, _ := base64.StdEncoding.DecodeString(encoded)
+			if string(credentials) == "\x00"+s.username+"\x00"+s.password {
+				reply("235 2.7.0 Authentication successful")
+			} else {
+				reply("535 5.7.8 Authentication credentials invalid")
+			}
+		case "MAIL", "RSET", "NOOP":
+			reply("250 2.0.0 OK")
+		case "RCPT":
+			recipient := strings.Trim(strings.TrimPrefix(strings.ToUpper(arg), "TO:"), "<>")
+			if rejection, ok := s.rejects[strings.ToLower(recipient)]; ok {
+				reply(rejection)
+			} else {
+				reply("250 2.1.5 OK")
+			}
+		case "DATA":
+			reply("354 Start mail input")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			if s.dataRejection != "" {
+				reply(s.dataRejection)
+				continue
+			}
+			s.mu.Lock()
+			s.data = append(s.data, string(data))
+			s.mu.Unlock()
+			reply("250 2.0.0 Queued")
+			if s.dropAfterData {
+				return
+			}
+		case "QUIT":
+			reply("221 2.0.0 Bye")
+			return
+		default:
+			reply("502 5.5.2 Command not recognized")
+		}
+	}
+}
+
+// testTLSConfigs returns a server config with a self-signed certificate for 127.0.0.1 and a client
+// config trusting it.
+func testTLSConfigs(t *testing.T) (*tls.Config, *tls.Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fake.example"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	serverTLS := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	return serverTLS, &tls.Config{RootCAs: roots}
+}
+
+func testMessage(server *mail.Server, to string) *mail.Message {
+	return &mail.Message{
+		From:    "Sales <sales@acme.example>",
+		To:      to,
+		Subject: "Quick question, Ada ✓",
+		HTML:    `<p>Hi Ada,</p><p>See <a href="https://acme.example/demo?a=1&amp;b=2">our demo</a>.</p>`,
+		Headers: map[string]string{"Message-ID": "<id-1@acme.example>"},
+		Server:  server,
+	}
+}
+
+func Test_Send(t *testing.T) {
+	fake, clientTLS := newFakeServer(t, nil)
+	s := newSender(options{timeout: 5 * time.Second, tlsConfig: clientTLS})
+	defer s.Close()
+
+	server := fake.server()
+	for range 3 {
+		if err := s.Send(context.Background(), testMessage(server, "ada@lead.example")); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if got := fake.conns.Load(); got != 1 {
+		t.Errorf("connections = %d, want 1 reused connection", got)
+	}
+
+	messages := fake.messages()
+	if len(messages) != 3 {
+		t.Fatalf("received %d messages, want 3", len(messages))
+	}
+
+	msg, err := netmail.ReadMessage(strings.NewReader(messages[0]))
+	if err != nil {
+		t.Fatalf("invalid message: %v", err)
+	}
+	if got := msg.Header.Get("Message-Id"); got != "<id-1@acme.example>" {
+		t.Errorf("Message-ID = %q", got)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != "Quick question, Ada ✓" {
+		t.Errorf("Subject = %q", subject)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q", msg.Header.Get("Content-Type"))
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	want := map[string]string{
+		"text/plain; charset=utf-8": "Hi Ada,\n\nSee our demo (https://acme.example/demo?a=1&b=2).",
+		"text/html; charset=utf-8":  testMessage(server, "").HTML,
+	}
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid part: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		contentType := part.Header.Get("Content-Type")
+		if string(body) != want[contentType] {
+			t.Errorf("%s part = %q, want %q", contentType, body, want[contentType])
+		}
+		delete(want, contentType)
+	}
+	if len(want) != 0 {
+		t.Errorf("missing parts: %v", want)
+	}
+}
+
+func Test_Send_reconnectsDroppedConnection(t *testing.T) {
+	fake, clientTLS := newFakeServer(t, func(s *fakeServer) { s.dropAfterData = true })
+	s := newSender(options{timeout: 5 * time.Second, tlsConfig: clientTLS})
+	defer s.Close()
+
+	server := fake.server()
+	for range 2 {
+		if err := s.Send(context.Background(), testMessage(server, "ada@lead.example")); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	if got := fake.conns.Load(); got != 2 {
+		t.Errorf("connections = %d, want 2", got)
+	}
+}
+
+func Test_Send_errors(t *testing.T) {
+	fake, clientTLS := newFakeServer(t, func(s *fakeServer) {
+		s.rejects["unknown@lead.example"] = "550 5.1.1 User unknown"
+		s.rejects["full@lead.example"] = "452 4.2.2 Mailbox full"
+	})
+	s := newSender(options{timeout: 5 * time.Second, tlsConfig: clientTLS})
+	defer s.Close()
+	server := fake.server()
+
+	err := s.Send(context.Background(), testMessage(server, "unknown@lead.example"))
+	if !mail.IsPermanent(err) {
+		t.Errorf("Send() error = %v, want permanent", err)
+	}
+	if b := bounce.Classify(err); b == nil || b.Kind != bounce.KindHard || b.Status != "5.1.1" {
+		t.Errorf("bounce.Classify() = %+v, want hard 5.1.1", b)
+	}
+
+	err = s.Send(context.Background(), testMessage(server, "full@lead.example"))
+	if err == nil || mail.IsPermanent(err) {
+		t.Errorf("Send() error = %v, want retryable", err)
+	}
+	if b := bounce.Classify(err); b == nil || b.Kind != bounce.KindSoft {
+		t.Errorf("bounce.Classify() = %+v, want soft", b)
+	}
+
+	if err := s.Send(context.Background(), testMessage(server, "ada@lead.example")); err != nil {
+		t.Errorf("Send() error = %v", err)
+	}
+	if got := fake.conns.Load(); got != 1 {
+		t.Errorf("connections = %d, want rejections to keep the connection", got)
+	}
+
+	badAuth := fake.server()
+	badAuth.Password = "wrong"
+	err = s.Send(context.Background(), testMessage(badAuth, "ada@lead.example"))
+	if !mail.IsPermanent(err) || bounce.Classify(err) != nil {
+		t.Errorf("Send() error = %v, want permanent and not a bounce", err)
+	}
+}
+
+func Test_Send_dataRejectionIsNotHardBounce(t *testing.T) {
+	fake, clientTLS := newFakeServer(t, func(s *fakeServer) {
+		s.dataRejection = "550 5.1.1 User unknown"
+	})
+	s := newSender(options{timeout: 5 * time.Second, tlsConfig: clientTLS})
+	defer s.Close()
+
+	err := s.Send(context.Background(), testMessage(fake.server(), "ada@lead.example"))
+	if !mail.IsPermanent(err) {
+		t.Errorf("Send() error = %v, want permanent", err)
+	}
+	if b := bounce.Classify(err); b == nil || b.Kind != bounce.KindSoft {
+		t.Errorf("bounce.Classify() = %+v, want soft", b)
+	}
+}
+
+func Test_Send_requiresTLS(t *testing.T) {
+	fake, _ := newFakeServer(t, func(s *fakeServer) {
+		s.noTLS = true
+		s.username = ""
+	})
+	server := fake.server()
+
+	s := newSender(options{timeout: 5 * time.Second})
+	defer s.Close()
+	if err := s.Send(context.Background(), testMessage(server, "ada@lead.example")); !mail.IsPermanent(err) {
+		t.Errorf("Send() error = %v, want permanent", err)
+	}
+
+	insecure := newSender(options{timeout: 5 * time.Second, allowInsecure: true})
+	defer insecure.Close()
+	if err := insecure.Send(context.Background(), testMessage(server, "ada@lead.example")); err != nil {
+		t.Errorf("Send() error = %v", err)
+	}
+}
+
+func Test_Send_timeout(t *testing.T) {
+	fake, clientTLS := newFakeServer(t, func(s *fakeServer) { s.silent = true })
+	s := newSender(options{timeout: 100 * time.Millisecond, tlsConfig: clientTLS})
+	defer s.Close()
+
+	start := time.Now()
+	err := s.Send(context.Background(), testMessage(fake.server(), "ada@lead.example"))
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() || mail.IsPermanent(err) {
+		t.Errorf("Send() error = %v, want retryable timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Send() took %v", elapsed)
+	}
+}
+
+func Test_Send_withoutServer(t *testing.T) {
+	s := newSender(options{})
+	msg := testMessage(nil, "ada@lead.example")
+	if err := s.Send(context.Background(), msg); !mail.IsPermanent(err) {
+		t.Errorf("Send() error = %v, want permanent", err)
+	}
+}
+
+func Test_htmlToText(t *testing.T) {
+	body := `<html><head><style>p { color: red }</style></head><body>
+		<p>Hi   Ada,</p>
+		<div>Line one<br/>Line two</div>
+		<p><a href="https://acme.example">https://acme.example</a> &amp; <a href="https://acme.example/x"><b>more</b></a></p>
+	</body></html>`
+	want := "Hi Ada,\n\nLine one\nLine two\n\nhttps://acme.example & more (https://acme.example/x)"
+	if got := htmlToText(body); got != want {
+		t.Errorf("htmlToText() = %q, want %q", got, want)
+	}
+}
+
+func Test_buildMessage_rejectsHeaderInjection(t *testing.T) {
+	msg := testMessage(nil, "ada@lead.example")
+	msg.Headers["X-Campaign"] = "a\r\nBcc: victim@example.com"
+
+	from, _ := netmail.ParseAddress(msg.From)
+	to, _ := netmail.ParseAddress(msg.To)
+	if _, err := buildMessage(msg, from, to, time.Now()); err == nil {
+		t.Error("buildMessage() error = nil, want invalid header")
+	}
+}
@@ -0,0 +1,103 @@
+// Package unenroll ends enrollments before their last step and cancels the emails they have not sent
+// yet. Unsubscribes, contact deletes, replies and bounces all go through it, so they agree on which
+// enrollments and emails are still pending.
+package unenroll
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/capacity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Unfinished are the statuses of enrollments that may still send.
+var Unfinished = []models.SequenceContactStatus{
+	models.SequenceContactStatusPending,
+	models.SequenceContactStatusInProgress,
+	models.SequenceContactStatusPaused,
+}
+
+// pendingEmails are the statuses of emails that were not handed to the mail transport yet.
+var pendingEmails = []models.EmailQueueStatus{models.EmailQueueStatusScheduled, models.EmailQueueStatusQueued}
+
+// Enrollment ends an unfinished enrollment with status and, when not empty, reason. It reports false
+// when the enrollment already ended.
+func Enrollment(tx *gorm.DB, sequenceContactID uuid.UUID, status models.SequenceContactStatus, reason string) (bool, error) {
+	result := tx.Exec(`UPDATE sequence_contacts
+		SET status = ?, completed_reason = NULLIF(?, ''), next_send_at = NULL, completed_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND status IN ?`,
+		status, reason, sequenceContactID, Unfinished)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Bounce does the bookkeeping of a bounce of an email sent to recipient, the same way for rejections by
+// the SMTP server and for delivery status notifications: the bounce is counted against the mailbox of
+// the email, and a hard bounce also ends the enrollment as bounced, cancels its pending emails and
+// suppresses the recipient.
+func Bounce(tx *gorm.DB, emailQueueID, sequenceContactID uuid.UUID, recipient string, kind bounce.Kind) error {
+	if err := capacity.CountBounce(tx, emailQueueID, kind); err != nil {
+		return err
+	}
+	if kind != bounce.KindHard {
+		return nil
+	}
+
+	bounced, err := Enrollment(tx, sequenceContactID, models.SequenceContactStatusBounced, "")
+	if err != nil {
+		return err
+	}
+	if bounced {
+		if _, err := EnrollmentEmails(tx, sequenceContactID, "recipient bounced"); err != nil {
+			return err
+		}
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "type"}, {Name: "value"}},
+		DoNothing: true,
+	}).Create(&models.Suppression{
+		Type:   models.SuppressionTypeEmail,
+		Value:  strings.ToLower(strings.TrimSpace(recipient)),
+		Reason: models.SuppressionReasonBounced,
+	}).Error
+}
+
+// Contact cancels the unfinished enrollments of a contact and returns how many were cancelled.
+func Contact(tx *gorm.DB, contactID uuid.UUID) (int64, error) {
+	result := tx.Exec(`UPDATE sequence_contacts
+		SET status = ?, next_send_at = NULL, completed_at = NOW(), updated_at = NOW()
+		WHERE contact_id = ? AND status IN ?`,
+		models.SequenceContactStatusCancelled, contactID, Unfinished)
+	return result.RowsAffected, result.Error
+}
+
+// EnrollmentEmails cancels the scheduled and queued emails of an enrollment and gives back the mailbox
+// capacity reserved for them. It returns how many emails were cancelled.
+func EnrollmentEmails(tx *gorm.DB, sequenceContactID uuid.UUID, errorMessage string) (int64, error) {
+	return cancelEmails(tx, []uuid.UUID{sequenceContactID}, errorMessage)
+}
+
+// ContactEmails cancels the scheduled and queued emails of every enrollment of a contact and gives back
+// the mailbox capacity reserved for them. It returns how many emails were cancelled.
+func ContactEmails(tx *gorm.DB, contactID uuid.UUID, errorMessage string) (int64, error) {
+	return cancelEmails(tx, gorm.Expr("SELECT id FROM sequence_contacts WHERE contact_id = ?", contactID), errorMessage)
+}
+
+// cancelEmails cancels the pending emails of the enrollments given as a list of IDs or a subquery.
+func cancelEmails(tx *gorm.DB, sequenceContactIDs any, errorMessage string) (int64, error) {
+	if err := capacity.Release(tx, "sequence_contact_id IN (?) AND status IN ?", sequenceContactIDs, pendingEmails); err != nil {
+		return 0, err
+	}
+
+	result := tx.Model(&models.EmailQueue{}).
+		Where("sequence_contact_id IN (?) AND status IN ?", sequenceContactIDs, pendingEmails).
+		Updates(map[string]any{
+			"status":        models.EmailQueueStatusCancelled,
+			"error_message": errorMessage,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,137 @@
+package unenroll
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rohanchauhan02/sequence-service/internal/models"
+	"github.com/rohanchauhan02/sequence-service/internal/pkg/bounce"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	return db, mock
+}
+
+func Test_Enrollment(t *testing.T) {
+	sequenceContactID := uuid.New()
+
+	tests := []struct {
+		name         string
+		status       models.SequenceContactStatus
+		reason       string
+		rowsAffected int64
+		want         bool
+	}{
+		{name: "success - completed with a reason", status: models.SequenceContactStatusCompleted, reason: models.CompletedReasonReplied, rowsAffected: 1, want: true},
+		{name: "success - bounced without a reason", status: models.SequenceContactStatusBounced, rowsAffected: 1, want: true},
+		{name: "success - already ended", status: models.SequenceContactStatusBounced, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDB(t)
+			mock.ExpectExec(`UPDATE sequence_contacts\s+SET status = \$1, completed_reason = NULLIF\(\$2, ''\)`).
+				WithArgs(tt.status, tt.reason, sequenceContactID,
+					models.SequenceContactStatusPending, models.SequenceContactStatusInProgress, models.SequenceContactStatusPaused).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			got, err := Enrollment(db, sequenceContactID, tt.status, tt.reason)
+			if err != nil {
+				t.Fatalf("Enrollment() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Enrollment() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ContactEmails(t *testing.T) {
+	db, mock := newDB(t)
+	contactID := uuid.New()
+
+	mock.ExpectExec(`UPDATE mailbox_daily_counts mdc`).
+		WithArgs(contactID, models.EmailQueueStatusScheduled, models.EmailQueueStatusQueued).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "email_queues" SET .*WHERE sequence_contact_id IN \(SELECT id FROM sequence_contacts WHERE contact_id = \$\d+\) AND status IN`).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	cancelled, err := ContactEmails(db, contactID, "contact deleted")
+	if err != nil {
+		t.Fatalf("ContactEmails() unexpected error: %v", err)
+	}
+	if cancelled != 2 {
+		t.Errorf("ContactEmails() = %d, want 2", cancelled)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func Test_Bounce(t *testing.T) {
+	emailQueueID, sequenceContactID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		kind      bounce.Kind
+		expectSQL func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "success - soft bounce is only counted",
+			kind: bounce.KindSoft,
+			expectSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`SET soft_bounce_count = mdc\.soft_bounce_count \+ 1`).
+					WithArgs(emailQueueID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "success - hard bounce ends the enrollment and suppresses the recipient",
+			kind: bounce.KindHard,
+			expectSQL: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`SET hard_bounce_count = mdc\.hard_bounce_count \+ 1`).
+					WithArgs(emailQueueID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE sequence_contacts`).
+					WithArgs(models.SequenceContactStatusBounced, "", sequenceContactID,
+						models.SequenceContactStatusPending, models.SequenceContactStatusInProgress, models.SequenceContactStatusPaused).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE mailbox_daily_counts mdc`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "email_queues"`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`INSERT INTO "suppressions" .* ON CONFLICT \("type","value"\) DO NOTHING`).
+					WithArgs(models.SuppressionTypeEmail, "lead@example.com", models.SuppressionReasonBounced, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newDB(t)
+			tt.expectSQL(mock)
+
+			if err := Bounce(db, emailQueueID, sequenceContactID, " Lead@Example.com ", tt.kind); err != nil {
+				t.Fatalf("Bounce() unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
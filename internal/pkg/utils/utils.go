@@ -5,7 +5,7 @@ import (
 	"runtime"
 	"strings"
 
-	"github.com/go-playground/validator"
+	"github.com/go-playground/validator/v10"
 )
 
 type CustomValidator struct {